@@ -23,6 +23,33 @@ func benchmarkQuery(b *testing.B, schema graphql.Schema, query string) {
 	benchmark_result = result
 }
 
+func benchmarkCachedQuery(b *testing.B, schema graphql.Schema, query string) {
+	params := graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+		DocumentCache: graphql.NewDocumentCache(1),
+	}
+
+	var result *graphql.Result
+	for n := 0; n < b.N; n++ {
+		result = graphql.Do(params)
+	}
+	benchmark_result = result
+}
+
+func benchmarkPreparedQuery(b *testing.B, schema graphql.Schema, query string) {
+	prepared, err := graphql.Prepare(schema, parseDocument(b, query), "")
+	if err != nil {
+		b.Fatalf("unexpected error preparing query: %v", err)
+	}
+
+	var result *graphql.Result
+	for n := 0; n < b.N; n++ {
+		result = prepared.Execute(graphql.PreparedParams{})
+	}
+	benchmark_result = result
+}
+
 func BenchmarkQuery_BasicQuery(b *testing.B) {
 	query := `
 		{
@@ -218,3 +245,71 @@ func BenchmarkQuery_DeeperQueryWithManyRootFieldsAndList(b *testing.B) {
 	`
 	benchmarkQuery(b, testutil.StarWarsSchema, query)
 }
+
+func BenchmarkCachedQuery_BasicQuery(b *testing.B) {
+	query := `
+		{
+			hero {
+				name
+			}
+		}
+	`
+	benchmarkCachedQuery(b, testutil.StarWarsSchema, query)
+}
+
+func BenchmarkCachedQuery_DeeperQueryWithList(b *testing.B) {
+	query := `
+		{
+			hero {
+				id
+				name
+				friends {
+					id
+					name
+					friends {
+						id
+						name
+						appearsIn
+					}
+					appearsIn
+				}
+				appearsIn
+			}
+		}
+	`
+	benchmarkCachedQuery(b, testutil.StarWarsSchema, query)
+}
+
+func BenchmarkPreparedQuery_BasicQuery(b *testing.B) {
+	query := `
+		{
+			hero {
+				name
+			}
+		}
+	`
+	benchmarkPreparedQuery(b, testutil.StarWarsSchema, query)
+}
+
+func BenchmarkPreparedQuery_DeeperQueryWithList(b *testing.B) {
+	query := `
+		{
+			hero {
+				id
+				name
+				friends {
+					id
+					name
+					friends {
+						id
+						name
+						appearsIn
+					}
+					appearsIn
+				}
+				appearsIn
+			}
+		}
+	`
+	benchmarkPreparedQuery(b, testutil.StarWarsSchema, query)
+}
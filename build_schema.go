@@ -0,0 +1,640 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// BuildSchema builds a schema from its definition in the schema definition
+// language. See BuildASTSchema.
+func BuildSchema(sdl string) (Schema, error) {
+	AST, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: sdl,
+			Name: "GraphQL SDL",
+		}),
+	})
+	if err != nil {
+		return Schema{}, err
+	}
+	return BuildASTSchema(AST)
+}
+
+// BuildASTSchema builds a schema from a document of type system definitions.
+// The descriptions of types, fields, arguments, enum values, input fields and
+// directives are kept, as are the directives used on them, with their
+// arguments coerced. Type extensions are merged into the types they extend.
+// The directives used on the schema definition itself are not kept, as a
+// Schema has no place for them.
+//
+// The schema has no resolvers: fields resolve to the property of the same
+// name of their source, and values of interfaces and unions resolve to the
+// object type named by their "__typename" property.
+func BuildASTSchema(AST *ast.Document) (Schema, error) {
+	b := &schemaBuilder{
+		definitions: map[string]ast.Node{},
+		types:       map[string]Type{},
+	}
+	if AST == nil {
+		return Schema{}, gqlerrors.NewFormattedError("Must provide a document.")
+	}
+	if err := b.collect(AST.Definitions); err != nil {
+		return Schema{}, err
+	}
+
+	// Types are built without the directives used on them, which may only be
+	// coerced once every directive is defined, and so are directive
+	// definitions without the default values of their arguments. Fields are
+	// built when the schema is, through thunks.
+	types := []Type{}
+	for _, name := range b.names {
+		types = append(types, b.namedType(name))
+	}
+	directives := []*Directive{}
+	for _, definition := range b.directiveDefinitions {
+		directives = append(directives, b.directive(definition))
+	}
+	for _, directive := range SpecifiedDirectives {
+		if !b.directiveNames[directive.Name] {
+			directives = append(directives, directive)
+		}
+	}
+	b.directives = directives
+	for i, definition := range b.directiveDefinitions {
+		b.completeDirective(directives[i], definition)
+	}
+	for _, name := range b.names {
+		b.completeNamedType(name)
+	}
+
+	query, mutation, subscription := b.rootTypes()
+	if b.err != nil {
+		return Schema{}, b.err
+	}
+	schema, err := NewSchema(SchemaConfig{
+		Query:        query,
+		Mutation:     mutation,
+		Subscription: subscription,
+		Types:        types,
+		Directives:   directives,
+	})
+	if b.err != nil {
+		return Schema{}, b.err
+	}
+	return schema, err
+}
+
+// schemaBuilder builds the types and directives of a schema from their
+// definitions, recording the first error met.
+type schemaBuilder struct {
+	// definitions holds the type definitions by name, merged with their
+	// extensions, and names their names in order of definition.
+	definitions map[string]ast.Node
+	names       []string
+
+	directiveDefinitions []*ast.DirectiveDefinition
+	directiveNames       map[string]bool
+
+	schemaDefinition *ast.SchemaDefinition
+	operationTypes   []*ast.OperationTypeDefinition
+
+	types      map[string]Type
+	directives []*Directive
+
+	err error
+}
+
+func (b *schemaBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// collect gathers the definitions of a document, merging type extensions into
+// the types they extend.
+func (b *schemaBuilder) collect(definitions []ast.Node) error {
+	b.directiveNames = map[string]bool{}
+	extensions := []ast.Node{}
+	for _, definition := range definitions {
+		switch definition := definition.(type) {
+		case *ast.SchemaDefinition:
+			err := invariant(b.schemaDefinition == nil, "Must provide only one schema definition.")
+			if err != nil {
+				return err
+			}
+			b.schemaDefinition = definition
+			b.operationTypes = append(b.operationTypes, definition.OperationTypes...)
+		case *ast.DirectiveDefinition:
+			name := nameValue(definition.Name)
+			err := invariant(
+				!b.directiveNames[name],
+				fmt.Sprintf(`There can be only one directive named "@%v".`, name),
+			)
+			if err != nil {
+				return err
+			}
+			b.directiveNames[name] = true
+			b.directiveDefinitions = append(b.directiveDefinitions, definition)
+		case *ast.ScalarDefinition, *ast.ObjectDefinition, *ast.InterfaceDefinition,
+			*ast.UnionDefinition, *ast.EnumDefinition, *ast.InputObjectDefinition:
+			name := typeDefinitionName(definition)
+			if _, ok := specifiedScalars[name]; ok {
+				continue
+			}
+			_, defined := b.definitions[name]
+			err := invariant(!defined, fmt.Sprintf(`There can be only one type named "%v".`, name))
+			if err != nil {
+				return err
+			}
+			b.definitions[name] = definition
+			b.names = append(b.names, name)
+		case *ast.SchemaExtensionDefinition, *ast.TypeExtensionDefinition,
+			*ast.ScalarExtensionDefinition, *ast.InterfaceExtensionDefinition,
+			*ast.UnionExtensionDefinition, *ast.EnumExtensionDefinition,
+			*ast.InputObjectExtensionDefinition:
+			extensions = append(extensions, definition)
+		default:
+			return invariant(false, fmt.Sprintf("A schema cannot be built from a %v.", definition.GetKind()))
+		}
+	}
+	for _, extension := range extensions {
+		if err := b.extend(extension); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extend merges an extension into the definition it extends.
+func (b *schemaBuilder) extend(extension ast.Node) error {
+	if extension, ok := extension.(*ast.SchemaExtensionDefinition); ok {
+		if extension.Definition != nil {
+			b.operationTypes = append(b.operationTypes, extension.Definition.OperationTypes...)
+		}
+		return nil
+	}
+	var extended ast.Node
+	switch extension := extension.(type) {
+	case *ast.TypeExtensionDefinition:
+		extended = extension.Definition
+	case *ast.ScalarExtensionDefinition:
+		extended = extension.Definition
+	case *ast.InterfaceExtensionDefinition:
+		extended = extension.Definition
+	case *ast.UnionExtensionDefinition:
+		extended = extension.Definition
+	case *ast.EnumExtensionDefinition:
+		extended = extension.Definition
+	case *ast.InputObjectExtensionDefinition:
+		extended = extension.Definition
+	}
+	name := typeDefinitionName(extended)
+	definition, ok := b.definitions[name]
+	err := invariant(
+		ok && definition.GetKind() == extended.GetKind(),
+		fmt.Sprintf(`Cannot extend type "%v" as it is not defined with the same kind.`, name),
+	)
+	if err != nil {
+		return err
+	}
+	switch definition := definition.(type) {
+	case *ast.ScalarDefinition:
+		extended := extended.(*ast.ScalarDefinition)
+		merged := *definition
+		merged.Directives = concatDirectives(definition.Directives, extended.Directives)
+		b.definitions[name] = &merged
+	case *ast.ObjectDefinition:
+		extended := extended.(*ast.ObjectDefinition)
+		merged := *definition
+		merged.Interfaces = append(append([]*ast.Named{}, definition.Interfaces...), extended.Interfaces...)
+		merged.Directives = concatDirectives(definition.Directives, extended.Directives)
+		merged.Fields = append(append([]*ast.FieldDefinition{}, definition.Fields...), extended.Fields...)
+		b.definitions[name] = &merged
+	case *ast.InterfaceDefinition:
+		extended := extended.(*ast.InterfaceDefinition)
+		merged := *definition
+		merged.Directives = concatDirectives(definition.Directives, extended.Directives)
+		merged.Fields = append(append([]*ast.FieldDefinition{}, definition.Fields...), extended.Fields...)
+		b.definitions[name] = &merged
+	case *ast.UnionDefinition:
+		extended := extended.(*ast.UnionDefinition)
+		merged := *definition
+		merged.Directives = concatDirectives(definition.Directives, extended.Directives)
+		merged.Types = append(append([]*ast.Named{}, definition.Types...), extended.Types...)
+		b.definitions[name] = &merged
+	case *ast.EnumDefinition:
+		extended := extended.(*ast.EnumDefinition)
+		merged := *definition
+		merged.Directives = concatDirectives(definition.Directives, extended.Directives)
+		merged.Values = append(append([]*ast.EnumValueDefinition{}, definition.Values...), extended.Values...)
+		b.definitions[name] = &merged
+	case *ast.InputObjectDefinition:
+		extended := extended.(*ast.InputObjectDefinition)
+		merged := *definition
+		merged.Directives = concatDirectives(definition.Directives, extended.Directives)
+		merged.Fields = append(append([]*ast.InputValueDefinition{}, definition.Fields...), extended.Fields...)
+		b.definitions[name] = &merged
+	}
+	return nil
+}
+
+func concatDirectives(directives []*ast.Directive, more []*ast.Directive) []*ast.Directive {
+	return append(append([]*ast.Directive{}, directives...), more...)
+}
+
+// specifiedScalars are the scalars defined by the GraphQL specification,
+// which definitions in the schema definition language do not replace.
+var specifiedScalars = map[string]*Scalar{
+	"String":  String,
+	"Int":     Int,
+	"Float":   Float,
+	"Boolean": Boolean,
+	"ID":      ID,
+}
+
+// namedType returns the type of the given name, building it on first use.
+func (b *schemaBuilder) namedType(name string) Type {
+	if scalar, ok := specifiedScalars[name]; ok {
+		return scalar
+	}
+	if ttype, ok := b.types[name]; ok {
+		return ttype
+	}
+	var ttype Type
+	switch definition := b.definitions[name].(type) {
+	case *ast.ScalarDefinition:
+		ttype = NewScalar(ScalarConfig{
+			Name:         name,
+			Description:  descriptionValue(definition.Description),
+			Serialize:    func(value interface{}) interface{} { return value },
+			ParseValue:   func(value interface{}) interface{} { return value },
+			ParseLiteral: valueFromASTUntyped,
+		})
+	case *ast.ObjectDefinition:
+		ttype = NewObject(ObjectConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			Interfaces:  b.interfaces(definition.Interfaces),
+			Fields:      b.fields(name, definition.Fields),
+		})
+	case *ast.InterfaceDefinition:
+		ttype = NewInterface(InterfaceConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			Fields:      b.fields(name, definition.Fields),
+			ResolveType: resolveTypeByTypename,
+		})
+	case *ast.UnionDefinition:
+		ttype = NewUnion(UnionConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			Types:       b.objects(definition.Types),
+			ResolveType: resolveTypeByTypename,
+		})
+	case *ast.EnumDefinition:
+		values := EnumValueConfigMap{}
+		for _, valueAST := range definition.Values {
+			if valueAST != nil {
+				values[nameValue(valueAST.Name)] = &EnumValueConfig{
+					Description: descriptionValue(valueAST.Description),
+				}
+			}
+		}
+		ttype = NewEnum(EnumConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			Values:      values,
+		})
+	case *ast.InputObjectDefinition:
+		ttype = NewInputObject(InputObjectConfig{
+			Name:        name,
+			Description: descriptionValue(definition.Description),
+			Fields:      b.inputFields(name, definition.Fields),
+		})
+	default:
+		b.fail(gqlerrors.NewFormattedError(fmt.Sprintf(`Unknown type "%v".`, name)))
+		return nil
+	}
+	if err := ttype.Error(); err != nil {
+		b.fail(err)
+	}
+	b.types[name] = ttype
+	return ttype
+}
+
+// completeNamedType sets the directives used on a type and its enum values.
+func (b *schemaBuilder) completeNamedType(name string) {
+	switch ttype := b.types[name].(type) {
+	case *Scalar:
+		definition := b.definitions[name].(*ast.ScalarDefinition)
+		ttype.scalarConfig.Directives = b.appliedDirectives(definition.Directives)
+	case *Object:
+		definition := b.definitions[name].(*ast.ObjectDefinition)
+		ttype.typeConfig.Directives = b.appliedDirectives(definition.Directives)
+	case *Interface:
+		definition := b.definitions[name].(*ast.InterfaceDefinition)
+		ttype.typeConfig.Directives = b.appliedDirectives(definition.Directives)
+	case *Union:
+		definition := b.definitions[name].(*ast.UnionDefinition)
+		ttype.typeConfig.Directives = b.appliedDirectives(definition.Directives)
+	case *Enum:
+		definition := b.definitions[name].(*ast.EnumDefinition)
+		ttype.enumConfig.Directives = b.appliedDirectives(definition.Directives)
+		valueASTs := map[string]*ast.EnumValueDefinition{}
+		for _, valueAST := range definition.Values {
+			if valueAST != nil {
+				valueASTs[nameValue(valueAST.Name)] = valueAST
+			}
+		}
+		for _, value := range ttype.Values() {
+			value.Directives = b.appliedDirectives(valueASTs[value.Name].Directives)
+			value.DeprecationReason = deprecationReason(value.Directives)
+		}
+	case *InputObject:
+		definition := b.definitions[name].(*ast.InputObjectDefinition)
+		ttype.typeConfig.Directives = b.appliedDirectives(definition.Directives)
+	}
+}
+
+// typeFromAST returns the type referred to by a type reference.
+func (b *schemaBuilder) typeFromAST(typeAST ast.Type) Type {
+	switch typeAST := typeAST.(type) {
+	case *ast.List:
+		if ofType := b.typeFromAST(typeAST.Type); ofType != nil {
+			return NewList(ofType)
+		}
+	case *ast.NonNull:
+		if ofType := b.typeFromAST(typeAST.Type); ofType != nil {
+			return NewNonNull(ofType)
+		}
+	case *ast.Named:
+		return b.namedType(nameValue(typeAST.Name))
+	}
+	return nil
+}
+
+func (b *schemaBuilder) interfaces(namedASTs []*ast.Named) InterfacesThunk {
+	return func() []*Interface {
+		interfaces := []*Interface{}
+		for _, namedAST := range namedASTs {
+			ttype := b.typeFromAST(namedAST)
+			iface, ok := ttype.(*Interface)
+			if !ok {
+				b.fail(gqlerrors.NewFormattedError(fmt.Sprintf(`Type "%v" is not an Interface type.`, ttype)))
+				continue
+			}
+			interfaces = append(interfaces, iface)
+		}
+		return interfaces
+	}
+}
+
+func (b *schemaBuilder) objects(namedASTs []*ast.Named) []*Object {
+	objects := []*Object{}
+	for _, namedAST := range namedASTs {
+		ttype := b.typeFromAST(namedAST)
+		object, ok := ttype.(*Object)
+		if !ok {
+			b.fail(gqlerrors.NewFormattedError(fmt.Sprintf(`Type "%v" is not an Object type.`, ttype)))
+			continue
+		}
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+func (b *schemaBuilder) fields(typeName string, fieldASTs []*ast.FieldDefinition) FieldsThunk {
+	return func() Fields {
+		fields := Fields{}
+		for _, fieldAST := range fieldASTs {
+			if fieldAST == nil {
+				continue
+			}
+			fieldName := nameValue(fieldAST.Name)
+			ttype := b.typeFromAST(fieldAST.Type)
+			if ttype != nil && !IsOutputType(ttype) {
+				message := fmt.Sprintf(`%v.%v field type must be Output Type but got: %v.`, typeName, fieldName, ttype)
+				b.fail(gqlerrors.NewFormattedError(message))
+				continue
+			}
+			args := FieldConfigArgument{}
+			for _, argAST := range fieldAST.Arguments {
+				if argAST == nil {
+					continue
+				}
+				element := fmt.Sprintf("%v.%v(%v:)", typeName, fieldName, nameValue(argAST.Name))
+				args[nameValue(argAST.Name)] = b.argument(element, argAST)
+			}
+			fields[fieldName] = &Field{
+				Type:        ttype,
+				Args:        args,
+				Description: descriptionValue(fieldAST.Description),
+				Directives:  b.appliedDirectives(fieldAST.Directives),
+			}
+		}
+		return fields
+	}
+}
+
+func (b *schemaBuilder) inputFields(typeName string, fieldASTs []*ast.InputValueDefinition) InputObjectConfigFieldMapThunk {
+	return func() InputObjectConfigFieldMap {
+		fields := InputObjectConfigFieldMap{}
+		for _, fieldAST := range fieldASTs {
+			if fieldAST == nil {
+				continue
+			}
+			fieldName := nameValue(fieldAST.Name)
+			arg := b.argument(fmt.Sprintf("%v.%v", typeName, fieldName), fieldAST)
+			fields[fieldName] = &InputObjectFieldConfig{
+				Type:         arg.Type,
+				DefaultValue: arg.DefaultValue,
+				Description:  arg.Description,
+				Directives:   arg.Directives,
+			}
+		}
+		return fields
+	}
+}
+
+// argument returns the configuration of an argument or input field.
+func (b *schemaBuilder) argument(element string, inputValueAST *ast.InputValueDefinition) *ArgumentConfig {
+	ttype, ok := b.typeFromAST(inputValueAST.Type).(Input)
+	if ok && !IsInputType(ttype) {
+		message := fmt.Sprintf(`%v type must be Input Type but got: %v.`, element, ttype)
+		b.fail(gqlerrors.NewFormattedError(message))
+		return &ArgumentConfig{}
+	}
+	return &ArgumentConfig{
+		Type:         ttype,
+		DefaultValue: b.defaultValue(element, ttype, inputValueAST.DefaultValue),
+		Description:  descriptionValue(inputValueAST.Description),
+		Directives:   b.appliedDirectives(inputValueAST.Directives),
+	}
+}
+
+func (b *schemaBuilder) defaultValue(element string, ttype Input, valueAST ast.Value) interface{} {
+	if valueAST == nil || ttype == nil {
+		return nil
+	}
+	if valid, messages := isValidLiteralValue(ttype, valueAST); !valid {
+		message := fmt.Sprintf(`%v has invalid default value: %v`, element, messages[0])
+		b.fail(gqlerrors.NewFormattedError(message))
+		return nil
+	}
+	return valueFromAST(valueAST, ttype, nil)
+}
+
+// directive returns a directive definition, without the default values and
+// the directives of its arguments.
+func (b *schemaBuilder) directive(definition *ast.DirectiveDefinition) *Directive {
+	name := nameValue(definition.Name)
+	args := FieldConfigArgument{}
+	for _, argAST := range definition.Arguments {
+		if argAST == nil {
+			continue
+		}
+		ttype, ok := b.typeFromAST(argAST.Type).(Input)
+		if ok && !IsInputType(ttype) {
+			message := fmt.Sprintf(`@%v(%v:) type must be Input Type but got: %v.`, name, nameValue(argAST.Name), ttype)
+			b.fail(gqlerrors.NewFormattedError(message))
+		}
+		args[nameValue(argAST.Name)] = &ArgumentConfig{
+			Type:        ttype,
+			Description: descriptionValue(argAST.Description),
+		}
+	}
+	locations := []string{}
+	for _, location := range definition.Locations {
+		locations = append(locations, nameValue(location))
+	}
+	directive := NewDirective(DirectiveConfig{
+		Name:        name,
+		Description: descriptionValue(definition.Description),
+		Locations:   locations,
+		Args:        args,
+	})
+	if directive.err != nil {
+		b.fail(directive.err)
+	}
+	return directive
+}
+
+// completeDirective sets the default values and the directives of the
+// arguments of a directive definition.
+func (b *schemaBuilder) completeDirective(directive *Directive, definition *ast.DirectiveDefinition) {
+	argASTs := map[string]*ast.InputValueDefinition{}
+	for _, argAST := range definition.Arguments {
+		if argAST != nil {
+			argASTs[nameValue(argAST.Name)] = argAST
+		}
+	}
+	for _, arg := range directive.Args {
+		argAST := argASTs[arg.Name()]
+		element := fmt.Sprintf("@%v(%v:)", directive.Name, arg.Name())
+		arg.DefaultValue = b.defaultValue(element, arg.Type, argAST.DefaultValue)
+		arg.Directives = b.appliedDirectives(argAST.Directives)
+		arg.DeprecationReason = deprecationReason(arg.Directives)
+	}
+}
+
+func (b *schemaBuilder) appliedDirectives(directiveASTs []*ast.Directive) []*AppliedDirective {
+	directives, err := AppliedDirectivesFromAST(b.directives, directiveASTs)
+	if err != nil {
+		b.fail(err)
+	}
+	return directives
+}
+
+// rootTypes returns the root types given by the schema definition, or
+// else the types named Query, Mutation and Subscription.
+func (b *schemaBuilder) rootTypes() (query *Object, mutation *Object, subscription *Object) {
+	names := map[string]string{}
+	if b.schemaDefinition == nil {
+		defaultNames := map[string]string{
+			ast.OperationTypeQuery:        "Query",
+			ast.OperationTypeMutation:     "Mutation",
+			ast.OperationTypeSubscription: "Subscription",
+		}
+		for operation, name := range defaultNames {
+			if _, ok := b.definitions[name]; ok {
+				names[operation] = name
+			}
+		}
+	}
+	for _, operationType := range b.operationTypes {
+		if operationType == nil || operationType.Type == nil {
+			continue
+		}
+		err := invariant(
+			names[operationType.Operation] == "",
+			fmt.Sprintf("Must provide only one %v type in schema.", operationType.Operation),
+		)
+		if err != nil {
+			b.fail(err)
+			return nil, nil, nil
+		}
+		names[operationType.Operation] = nameValue(operationType.Type.Name)
+	}
+	object := func(operation string) *Object {
+		name := names[operation]
+		if name == "" {
+			return nil
+		}
+		ttype := b.namedType(name)
+		object, ok := ttype.(*Object)
+		if !ok && ttype != nil {
+			b.fail(gqlerrors.NewFormattedError(fmt.Sprintf(`The %v type "%v" must be an Object type.`, operation, name)))
+		}
+		return object
+	}
+	return object(ast.OperationTypeQuery), object(ast.OperationTypeMutation), object(ast.OperationTypeSubscription)
+}
+
+// resolveTypeByTypename resolves the object type of a value of an interface
+// or a union of a schema built from the schema definition language from its
+// "__typename" property.
+func resolveTypeByTypename(p ResolveTypeParams) *Object {
+	value, ok := p.Value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	name, _ := value["__typename"].(string)
+	object, _ := p.Info.Schema.Type(name).(*Object)
+	return object
+}
+
+func typeDefinitionName(definition ast.Node) string {
+	switch definition := definition.(type) {
+	case *ast.ScalarDefinition:
+		return nameValue(definition.Name)
+	case *ast.ObjectDefinition:
+		return nameValue(definition.Name)
+	case *ast.InterfaceDefinition:
+		return nameValue(definition.Name)
+	case *ast.UnionDefinition:
+		return nameValue(definition.Name)
+	case *ast.EnumDefinition:
+		return nameValue(definition.Name)
+	case *ast.InputObjectDefinition:
+		return nameValue(definition.Name)
+	}
+	return ""
+}
+
+func descriptionValue(description *ast.StringValue) string {
+	if description == nil {
+		return ""
+	}
+	return description.Value
+}
+
+func nameValue(name *ast.Name) string {
+	if name == nil {
+		return ""
+	}
+	return name.Value
+}
@@ -0,0 +1,314 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+const buildSchemaTestSDL = `
+directive @auth(role: Role = ADMIN) on OBJECT | FIELD_DEFINITION
+directive @limit(max: Int!) on ARGUMENT_DEFINITION
+
+schema {
+  query: Root
+}
+
+enum Role {
+  ADMIN
+  USER @deprecated
+}
+
+scalar Date
+
+interface Node {
+  id: ID!
+}
+
+type User implements Node @auth(role: USER) {
+  id: ID!
+  name: String
+  joined: Date
+}
+
+type Robot implements Node {
+  id: ID!
+  model: String @deprecated(reason: "Use serial.")
+}
+
+union Agent = User | Robot
+
+input Filter {
+  role: Role = USER
+  names: [String!]
+}
+
+type Root {
+  agents(filter: Filter, first: Int = 10 @limit(max: 100)): [Agent] @auth
+  node: Node
+}
+
+extend type Robot {
+  serial: String
+}
+`
+
+func TestBuildSchema_BuildsExecutableSchemas(t *testing.T) {
+	schema, err := graphql.BuildSchema(buildSchemaTestSDL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"agents": []interface{}{
+				map[string]interface{}{"__typename": "User", "name": "Ann", "joined": "2020-01-01"},
+				map[string]interface{}{"__typename": "Robot", "serial": "R2"},
+			},
+			"node": map[string]interface{}{"id": "1"},
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			agents(filter: {names: ["Ann"]}) {
+				__typename
+				... on User { name joined }
+				... on Robot { serial }
+			}
+			node { id }
+		}`,
+		RootObject: map[string]interface{}{
+			"agents": []interface{}{
+				map[string]interface{}{"__typename": "User", "name": "Ann", "joined": "2020-01-01"},
+				map[string]interface{}{"__typename": "Robot", "serial": "R2"},
+			},
+			"node": map[string]interface{}{"__typename": "User", "id": 1},
+		},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestBuildSchema_KeepsAppliedDirectives(t *testing.T) {
+	schema, err := graphql.BuildSchema(buildSchemaTestSDL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	root := schema.Type("Root").(*graphql.Object)
+	user := schema.Type("User").(*graphql.Object)
+	robot := schema.Type("Robot").(*graphql.Object)
+	role := schema.Type("Role").(*graphql.Enum)
+	filter := schema.Type("Filter").(*graphql.InputObject)
+
+	agents := root.Fields()["agents"]
+	var first *graphql.Argument
+	for _, arg := range agents.Args {
+		if arg.Name() == "first" {
+			first = arg
+		}
+	}
+	var deprecatedRole *graphql.EnumValueDefinition
+	for _, value := range role.Values() {
+		if value.Name == "USER" {
+			deprecatedRole = value
+		}
+	}
+	tests := []struct {
+		directives []*graphql.AppliedDirective
+		name       string
+		args       map[string]interface{}
+	}{
+		{user.Directives(), "auth", map[string]interface{}{"role": "USER"}},
+		{agents.Directives, "auth", map[string]interface{}{"role": "ADMIN"}},
+		{first.Directives, "limit", map[string]interface{}{"max": 100}},
+		{robot.Fields()["model"].Directives, "deprecated", map[string]interface{}{"reason": "Use serial."}},
+		{deprecatedRole.Directives, "deprecated", map[string]interface{}{"reason": graphql.DefaultDeprecationReason}},
+	}
+	for _, test := range tests {
+		if len(test.directives) != 1 {
+			t.Fatalf("Expected one @%v directive, got %v", test.name, len(test.directives))
+		}
+		directive := test.directives[0]
+		if directive.Name != test.name || !reflect.DeepEqual(test.args, directive.Args) {
+			t.Fatalf("Unexpected directive, Diff: %v", testutil.Diff(test.args, directive.Args))
+		}
+	}
+	if robot.Fields()["model"].DeprecationReason != "Use serial." {
+		t.Fatalf("Unexpected deprecation reason: %v", robot.Fields()["model"].DeprecationReason)
+	}
+	if deprecatedRole.DeprecationReason != graphql.DefaultDeprecationReason {
+		t.Fatalf("Unexpected deprecation reason: %v", deprecatedRole.DeprecationReason)
+	}
+	if first.DefaultValue != 10 || filter.Fields()["role"].DefaultValue != "USER" {
+		t.Fatalf("Unexpected default values: %v, %v", first.DefaultValue, filter.Fields()["role"].DefaultValue)
+	}
+	if len(robot.Interfaces()) != 1 || robot.Fields()["serial"] == nil {
+		t.Fatalf("Expected Robot to be extended, got fields %v", robot.Fields())
+	}
+	if schema.Directive("auth") == nil || schema.Directive("deprecated") == nil {
+		t.Fatalf("Expected schema to define @auth and @deprecated, got %v", schema.Directives())
+	}
+}
+
+func TestBuildSchema_UsesDefaultRootTypeNames(t *testing.T) {
+	schema, err := graphql.BuildSchema(`
+		type Query { hello: String }
+		type Mutation { greet: String }
+	`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.QueryType().Name() != "Query" || schema.MutationType().Name() != "Mutation" {
+		t.Fatalf("Unexpected root types: %v, %v", schema.QueryType(), schema.MutationType())
+	}
+	if schema.SubscriptionType() != nil {
+		t.Fatalf("Unexpected subscription type: %v", schema.SubscriptionType())
+	}
+}
+
+func TestBuildSchema_ReportsInvalidDefinitions(t *testing.T) {
+	tests := []struct {
+		sdl      string
+		expected gqlerrors.FormattedError
+	}{
+		{
+			`type Query { hello: Greeting }`,
+			gqlerrors.FormattedError{Message: `Unknown type "Greeting".`},
+		},
+		{
+			`type Query { hello: String } type Query { bye: String }`,
+			gqlerrors.FormattedError{Message: `There can be only one type named "Query".`},
+		},
+		{
+			`type Query { hello: String } extend input Query { bye: String }`,
+			gqlerrors.FormattedError{Message: `Cannot extend type "Query" as it is not defined with the same kind.`},
+		},
+		{
+			`input In { a: Int } type Query { hello: In }`,
+			gqlerrors.FormattedError{Message: `Query.hello field type must be Output Type but got: In.`},
+		},
+		{
+			`type Query { hello(a: Int = "1"): String }`,
+			gqlerrors.FormattedError{Message: `Query.hello(a:) has invalid default value: Expected type "Int", found "1".`},
+		},
+		{
+			`type Query { hello: String @unknown }`,
+			gqlerrors.FormattedError{
+				Message:   `Unknown directive "@unknown".`,
+				Locations: []location.SourceLocation{{Line: 1, Column: 28}},
+			},
+		},
+		{
+			`directive @tag(name: String!) on OBJECT type Query @tag { hello: String }`,
+			gqlerrors.FormattedError{
+				Message: `Query uses directive "@tag" with invalid argument "name": Expected "String!", found null.`,
+			},
+		},
+		{
+			`type Query { hello: String @deprecated(reason: 1) }`,
+			gqlerrors.FormattedError{
+				Message:   `Directive "@deprecated" has invalid argument "reason": Expected type "String", found 1.`,
+				Locations: []location.SourceLocation{{Line: 1, Column: 40}},
+			},
+		},
+	}
+	for _, test := range tests {
+		_, err := graphql.BuildSchema(test.sdl)
+		if test.expected.Locations == nil {
+			test.expected.Locations = []location.SourceLocation{}
+		}
+		if !reflect.DeepEqual(test.expected, err) {
+			t.Fatalf("Expected error to be equal, got: %v", testutil.Diff(test.expected, err))
+		}
+	}
+}
+
+func TestBuildSchema_KeepsDescriptions(t *testing.T) {
+	sdl := `"""
+Marks a restricted element.
+"""
+directive @internal(
+  """
+  Who may see it.
+  """
+  team: String
+) on FIELD_DEFINITION
+
+"""
+What to greet.
+"""
+enum Greeting {
+  """
+  A casual greeting.
+  """
+  HI
+}
+
+"""
+The root type.
+"""
+type Query {
+  """
+  Says "hello".
+  """
+  hello(
+    """
+    The greeting to use.
+    """
+    greeting: Greeting
+  ): String
+}
+`
+	schema, err := graphql.BuildSchema(sdl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := schema.QueryType()
+	hello := query.Fields()["hello"]
+	greeting := schema.Type("Greeting").(*graphql.Enum)
+	internal := schema.Directive("internal")
+	expected := []string{
+		"The root type.",
+		`Says "hello".`,
+		"The greeting to use.",
+		"What to greet.",
+		"A casual greeting.",
+		"Marks a restricted element.",
+		"Who may see it.",
+	}
+	descriptions := []string{
+		query.Description(),
+		hello.Description,
+		hello.Args[0].Description(),
+		greeting.Description(),
+		greeting.Values()[0].Description,
+		internal.Description,
+		internal.Args[0].Description(),
+	}
+	if !reflect.DeepEqual(expected, descriptions) {
+		t.Fatalf("Unexpected descriptions, Diff: %v", testutil.Diff(expected, descriptions))
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ __type(name: "Query") { description } }`,
+	})
+	expectedResult := &graphql.Result{
+		Data: map[string]interface{}{
+			"__type": map[string]interface{}{"description": "The root type."},
+		},
+	}
+	if !reflect.DeepEqual(expectedResult, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedResult, result))
+	}
+
+	printed := graphql.PrintSchema(schema)
+	if !reflect.DeepEqual(sdl, printed) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(sdl, printed))
+	}
+}
@@ -240,13 +240,35 @@ type ParseValueFn func(value interface{}) interface{}
 // ParseLiteralFn is a function type for parsing the literal value of a GraphQLScalar type
 type ParseLiteralFn func(valueAST ast.Value) interface{}
 
+// SerializeWithErrorFn is a function type for serializing a GraphQLScalar type value,
+// returning an error describing why the value could not be serialized
+type SerializeWithErrorFn func(value interface{}) (interface{}, error)
+
+// ParseValueWithErrorFn is a function type for parsing the value of a GraphQLScalar type,
+// returning an error describing why the value could not be parsed
+type ParseValueWithErrorFn func(value interface{}) (interface{}, error)
+
+// ParseLiteralWithErrorFn is a function type for parsing the literal value of a GraphQLScalar type,
+// returning an error describing why the literal could not be parsed
+type ParseLiteralWithErrorFn func(valueAST ast.Value) (interface{}, error)
+
 // ScalarConfig options for creating a new GraphQLScalar
+//
+// The error-returning functions take precedence over their plain counterparts
+// when both are provided.
 type ScalarConfig struct {
 	Name         string `json:"name"`
 	Description  string `json:"description"`
 	Serialize    SerializeFn
 	ParseValue   ParseValueFn
 	ParseLiteral ParseLiteralFn
+
+	SerializeWithError    SerializeWithErrorFn
+	ParseValueWithError   ParseValueWithErrorFn
+	ParseLiteralWithError ParseLiteralWithErrorFn
+
+	// Directives are the schema directives used on the type.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 // NewScalar creates a new GraphQLScalar
@@ -267,8 +289,12 @@ func NewScalar(config ScalarConfig) *Scalar {
 	st.PrivateName = config.Name
 	st.PrivateDescription = config.Description
 
+	hasSerialize := config.Serialize != nil || config.SerializeWithError != nil
+	hasParseValue := config.ParseValue != nil || config.ParseValueWithError != nil
+	hasParseLiteral := config.ParseLiteral != nil || config.ParseLiteralWithError != nil
+
 	err = invariant(
-		config.Serialize != nil,
+		hasSerialize,
 		fmt.Sprintf(`%v must provide "serialize" function. If this custom Scalar is `+
 			`also used as an input type, ensure "parseValue" and "parseLiteral" `+
 			`functions are also provided.`, st),
@@ -277,9 +303,9 @@ func NewScalar(config ScalarConfig) *Scalar {
 		st.err = err
 		return st
 	}
-	if config.ParseValue != nil || config.ParseLiteral != nil {
+	if hasParseValue || hasParseLiteral {
 		err = invariant(
-			config.ParseValue != nil && config.ParseLiteral != nil,
+			hasParseValue && hasParseLiteral,
 			fmt.Sprintf(`%v must provide both "parseValue" and "parseLiteral" functions.`, st),
 		)
 		if err != nil {
@@ -292,22 +318,55 @@ func NewScalar(config ScalarConfig) *Scalar {
 	return st
 }
 func (st *Scalar) Serialize(value interface{}) interface{} {
+	v, _ := st.SerializeWithError(value)
+	return v
+}
+func (st *Scalar) ParseValue(value interface{}) interface{} {
+	v, _ := st.ParseValueWithError(value)
+	return v
+}
+func (st *Scalar) ParseLiteral(valueAST ast.Value) interface{} {
+	v, _ := st.ParseLiteralWithError(valueAST)
+	return v
+}
+
+// SerializeWithError serializes the given value, returning the error reported by
+// the scalar if the value cannot be serialized.
+// Scalars defined without an error-returning function never return an error.
+func (st *Scalar) SerializeWithError(value interface{}) (interface{}, error) {
+	if st.scalarConfig.SerializeWithError != nil {
+		return st.scalarConfig.SerializeWithError(value)
+	}
 	if st.scalarConfig.Serialize == nil {
-		return value
+		return value, nil
 	}
-	return st.scalarConfig.Serialize(value)
+	return st.scalarConfig.Serialize(value), nil
 }
-func (st *Scalar) ParseValue(value interface{}) interface{} {
+
+// ParseValueWithError parses the given input value, returning the error reported by
+// the scalar if the value cannot be parsed.
+// Scalars defined without an error-returning function never return an error.
+func (st *Scalar) ParseValueWithError(value interface{}) (interface{}, error) {
+	if st.scalarConfig.ParseValueWithError != nil {
+		return st.scalarConfig.ParseValueWithError(value)
+	}
 	if st.scalarConfig.ParseValue == nil {
-		return value
+		return value, nil
 	}
-	return st.scalarConfig.ParseValue(value)
+	return st.scalarConfig.ParseValue(value), nil
 }
-func (st *Scalar) ParseLiteral(valueAST ast.Value) interface{} {
+
+// ParseLiteralWithError parses the given literal value, returning the error reported by
+// the scalar if the literal cannot be parsed.
+// Scalars defined without an error-returning function never return an error.
+func (st *Scalar) ParseLiteralWithError(valueAST ast.Value) (interface{}, error) {
+	if st.scalarConfig.ParseLiteralWithError != nil {
+		return st.scalarConfig.ParseLiteralWithError(valueAST)
+	}
 	if st.scalarConfig.ParseLiteral == nil {
-		return nil
+		return nil, nil
 	}
-	return st.scalarConfig.ParseLiteral(valueAST)
+	return st.scalarConfig.ParseLiteral(valueAST), nil
 }
 func (st *Scalar) Name() string {
 	return st.PrivateName
@@ -316,6 +375,9 @@ func (st *Scalar) Description() string {
 	return st.PrivateDescription
 
 }
+func (st *Scalar) Directives() []*AppliedDirective {
+	return st.scalarConfig.Directives
+}
 func (st *Scalar) String() string {
 	return st.PrivateName
 }
@@ -398,6 +460,9 @@ type ObjectConfig struct {
 	Fields      interface{} `json:"fields"`
 	IsTypeOf    IsTypeOfFn  `json:"isTypeOf"`
 	Description string      `json:"description"`
+
+	// Directives are the schema directives used on the type.
+	Directives []*AppliedDirective `json:"directives"`
 }
 type FieldsThunk func() Fields
 
@@ -438,7 +503,7 @@ func (gt *Object) Name() string {
 	return gt.PrivateName
 }
 func (gt *Object) Description() string {
-	return ""
+	return gt.PrivateDescription
 }
 func (gt *Object) String() string {
 	return gt.PrivateName
@@ -471,6 +536,9 @@ func (gt *Object) Fields() FieldDefinitionMap {
 	return gt.fields
 }
 
+func (gt *Object) Directives() []*AppliedDirective {
+	return gt.typeConfig.Directives
+}
 func (gt *Object) Interfaces() []*Interface {
 	var configInterfaces []*Interface
 	switch gt.typeConfig.Interfaces.(type) {
@@ -559,8 +627,13 @@ func defineFieldMap(ttype Named, fields Fields) (FieldDefinitionMap, error) {
 			Description:       field.Description,
 			Type:              field.Type,
 			Resolve:           field.Resolve,
+			Subscribe:         field.Subscribe,
 			DeprecationReason: field.DeprecationReason,
 			Parallel:          field.Parallel,
+			Directives:        field.Directives,
+		}
+		if fieldDef.DeprecationReason == "" {
+			fieldDef.DeprecationReason = deprecationReason(field.Directives)
 		}
 
 		fieldDef.Args = []*Argument{}
@@ -588,6 +661,18 @@ func defineFieldMap(ttype Named, fields Fields) (FieldDefinitionMap, error) {
 				PrivateDescription: arg.Description,
 				Type:               arg.Type,
 				DefaultValue:       arg.DefaultValue,
+				DeprecationReason:  arg.DeprecationReason,
+				Directives:         arg.Directives,
+			}
+			if fieldArg.DeprecationReason == "" {
+				fieldArg.DeprecationReason = deprecationReason(arg.Directives)
+			}
+			err = invariant(
+				fieldArg.DeprecationReason == "" || !isRequiredInput(fieldArg.Type, fieldArg.DefaultValue),
+				fmt.Sprintf(`Required argument %v.%v(%v:) cannot be deprecated.`, ttype, fieldName, argName),
+			)
+			if err != nil {
+				return resultFieldMap, err
 			}
 			fieldDef.Args = append(fieldDef.Args, fieldArg)
 		}
@@ -625,6 +710,36 @@ type ResolveInfo struct {
 	RootValue      interface{}
 	Operation      ast.Definition
 	VariableValues map[string]interface{}
+
+	// Path is the path of the field in the response.
+	Path *ResponsePath
+
+	// FieldDefinition is the definition of the field, holding the schema
+	// directives used on it.
+	FieldDefinition *FieldDefinition
+}
+
+// ResponsePath is a path in the response, linking each key, either a field's
+// response name or a list index, to the path of its parent.
+type ResponsePath struct {
+	Prev *ResponsePath
+	Key  interface{}
+}
+
+// WithKey returns the path of the given key within the path.
+func (p *ResponsePath) WithKey(key interface{}) *ResponsePath {
+	return &ResponsePath{
+		Prev: p,
+		Key:  key,
+	}
+}
+
+// AsArray returns the keys of the path, from the root of the response.
+func (p *ResponsePath) AsArray() []interface{} {
+	if p == nil {
+		return []interface{}{}
+	}
+	return append(p.Prev.AsArray(), p.Key)
 }
 
 type Fields map[string]*Field
@@ -637,14 +752,26 @@ type Field struct {
 	DeprecationReason string `json:"deprecationReason"`
 	Description       string `json:"description"`
 	Parallel          bool
+
+	// Subscribe, for a field of the subscription root type, returns the
+	// channel of events the field is subscribed to, as a chan interface{}.
+	// Each event is resolved by Resolve to produce a result.
+	Subscribe FieldResolveFn
+
+	// Directives are the schema directives used on the field.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 type FieldConfigArgument map[string]*ArgumentConfig
 
 type ArgumentConfig struct {
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
+
+	// Directives are the schema directives used on the argument.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 type FieldDefinitionMap map[string]*FieldDefinition
@@ -654,8 +781,12 @@ type FieldDefinition struct {
 	Type              Output         `json:"type"`
 	Args              []*Argument    `json:"args"`
 	Resolve           FieldResolveFn `json:"-"`
+	Subscribe         FieldResolveFn `json:"-"`
 	DeprecationReason string         `json:"deprecationReason"`
 	Parallel          bool
+
+	// Directives are the schema directives used on the field.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 type FieldArgument struct {
@@ -670,6 +801,10 @@ type Argument struct {
 	Type               Input       `json:"type"`
 	DefaultValue       interface{} `json:"defaultValue"`
 	PrivateDescription string      `json:"description"`
+	DeprecationReason  string      `json:"deprecationReason"`
+
+	// Directives are the schema directives used on the argument.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 func (st *Argument) Name() string {
@@ -717,6 +852,9 @@ type InterfaceConfig struct {
 	Fields      interface{} `json:"fields"`
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
+
+	// Directives are the schema directives used on the type.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 // ResolveTypeParams Params for ResolveTypeFn()
@@ -772,6 +910,9 @@ func (it *Interface) Name() string {
 func (it *Interface) Description() string {
 	return it.PrivateDescription
 }
+func (it *Interface) Directives() []*AppliedDirective {
+	return it.typeConfig.Directives
+}
 func (it *Interface) Fields() (fields FieldDefinitionMap) {
 	var configureFields Fields
 	switch it.typeConfig.Fields.(type) {
@@ -828,6 +969,9 @@ type UnionConfig struct {
 	Types       []*Object `json:"types"`
 	ResolveType ResolveTypeFn
 	Description string `json:"description"`
+
+	// Directives are the schema directives used on the type.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 func NewUnion(config UnionConfig) *Union {
@@ -895,6 +1039,9 @@ func (ut *Union) Name() string {
 func (ut *Union) Description() string {
 	return ut.PrivateDescription
 }
+func (ut *Union) Directives() []*AppliedDirective {
+	return ut.typeConfig.Directives
+}
 func (ut *Union) Error() error {
 	return ut.err
 }
@@ -938,17 +1085,26 @@ type EnumValueConfig struct {
 	Value             interface{} `json:"value"`
 	DeprecationReason string      `json:"deprecationReason"`
 	Description       string      `json:"description"`
+
+	// Directives are the schema directives used on the value.
+	Directives []*AppliedDirective `json:"directives"`
 }
 type EnumConfig struct {
 	Name        string             `json:"name"`
 	Values      EnumValueConfigMap `json:"values"`
 	Description string             `json:"description"`
+
+	// Directives are the schema directives used on the type.
+	Directives []*AppliedDirective `json:"directives"`
 }
 type EnumValueDefinition struct {
 	Name              string      `json:"name"`
 	Value             interface{} `json:"value"`
 	DeprecationReason string      `json:"deprecationReason"`
 	Description       string      `json:"description"`
+
+	// Directives are the schema directives used on the value.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 func NewEnum(config EnumConfig) *Enum {
@@ -1002,6 +1158,10 @@ func (gt *Enum) defineEnumValues(valueMap EnumValueConfigMap) ([]*EnumValueDefin
 			Value:             valueConfig.Value,
 			DeprecationReason: valueConfig.DeprecationReason,
 			Description:       valueConfig.Description,
+			Directives:        valueConfig.Directives,
+		}
+		if value.DeprecationReason == "" {
+			value.DeprecationReason = deprecationReason(valueConfig.Directives)
 		}
 		if value.Value == nil {
 			value.Value = valueName
@@ -1043,6 +1203,9 @@ func (gt *Enum) Name() string {
 func (gt *Enum) Description() string {
 	return gt.PrivateDescription
 }
+func (gt *Enum) Directives() []*AppliedDirective {
+	return gt.enumConfig.Directives
+}
 func (gt *Enum) String() string {
 	return gt.PrivateName
 }
@@ -1110,15 +1273,23 @@ type InputObject struct {
 	err error
 }
 type InputObjectFieldConfig struct {
-	Type         Input       `json:"type"`
-	DefaultValue interface{} `json:"defaultValue"`
-	Description  string      `json:"description"`
+	Type              Input       `json:"type"`
+	DefaultValue      interface{} `json:"defaultValue"`
+	Description       string      `json:"description"`
+	DeprecationReason string      `json:"deprecationReason"`
+
+	// Directives are the schema directives used on the field.
+	Directives []*AppliedDirective `json:"directives"`
 }
 type InputObjectField struct {
 	PrivateName        string      `json:"name"`
 	Type               Input       `json:"type"`
 	DefaultValue       interface{} `json:"defaultValue"`
 	PrivateDescription string      `json:"description"`
+	DeprecationReason  string      `json:"deprecationReason"`
+
+	// Directives are the schema directives used on the field.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 func (st *InputObjectField) Name() string {
@@ -1142,6 +1313,9 @@ type InputObjectConfig struct {
 	Name        string      `json:"name"`
 	Fields      interface{} `json:"fields"`
 	Description string      `json:"description"`
+
+	// Directives are the schema directives used on the type.
+	Directives []*AppliedDirective `json:"directives"`
 }
 
 func NewInputObject(config InputObjectConfig) *InputObject {
@@ -1199,6 +1373,19 @@ func (gt *InputObject) defineFieldMap() InputObjectFieldMap {
 		field.Type = fieldConfig.Type
 		field.PrivateDescription = fieldConfig.Description
 		field.DefaultValue = fieldConfig.DefaultValue
+		field.DeprecationReason = fieldConfig.DeprecationReason
+		if field.DeprecationReason == "" {
+			field.DeprecationReason = deprecationReason(fieldConfig.Directives)
+		}
+		field.Directives = fieldConfig.Directives
+		err = invariant(
+			field.DeprecationReason == "" || !isRequiredInput(field.Type, field.DefaultValue),
+			fmt.Sprintf(`Required input field %v.%v cannot be deprecated.`, gt, fieldName),
+		)
+		if err != nil {
+			gt.err = err
+			return resultFieldMap
+		}
 		resultFieldMap[fieldName] = field
 	}
 	return resultFieldMap
@@ -1212,6 +1399,9 @@ func (gt *InputObject) Name() string {
 func (gt *InputObject) Description() string {
 	return gt.PrivateDescription
 }
+func (gt *InputObject) Directives() []*AppliedDirective {
+	return gt.typeConfig.Directives
+}
 func (gt *InputObject) String() string {
 	return gt.PrivateName
 }
@@ -1325,6 +1515,13 @@ func (gl *NonNull) Error() error {
 
 var NameRegExp, _ = regexp.Compile("^[_a-zA-Z][_a-zA-Z0-9]*$")
 
+// isRequiredInput tells whether an argument or input field must be provided,
+// i.e. is of a non-null type and has no default value.
+func isRequiredInput(ttype Input, defaultValue interface{}) bool {
+	_, isNonNull := ttype.(*NonNull)
+	return isNonNull && defaultValue == nil
+}
+
 func assertValidName(name string) error {
 	return invariant(
 		NameRegExp.MatchString(name),
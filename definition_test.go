@@ -619,3 +619,82 @@ func TestTypeSystem_DefinitionExample_IncludesFieldsThunk(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(fieldMap["s"].Type, someObject))
 	}
 }
+
+func TestTypeSystem_DefinitionExample_ProhibitsDeprecatingRequiredInputs(t *testing.T) {
+	queryWithArg := func(arg *graphql.ArgumentConfig) graphql.SchemaConfig {
+		return graphql.SchemaConfig{
+			Query: graphql.NewObject(graphql.ObjectConfig{
+				Name: "Query",
+				Fields: graphql.Fields{
+					"field": &graphql.Field{
+						Type: graphql.String,
+						Args: graphql.FieldConfigArgument{
+							"arg": arg,
+						},
+					},
+				},
+			}),
+		}
+	}
+
+	_, err := graphql.NewSchema(queryWithArg(&graphql.ArgumentConfig{
+		Type:              graphql.NewNonNull(graphql.String),
+		DeprecationReason: "Unused",
+	}))
+	expected := `Required argument Query.field(arg:) cannot be deprecated.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf(`expected %v , got: %v`, expected, err)
+	}
+
+	_, err = graphql.NewSchema(queryWithArg(&graphql.ArgumentConfig{
+		Type: graphql.NewNonNull(graphql.String),
+		Directives: []*graphql.AppliedDirective{
+			{Name: "deprecated"},
+		},
+	}))
+	if err == nil || err.Error() != expected {
+		t.Fatalf(`expected %v , got: %v`, expected, err)
+	}
+
+	_, err = graphql.NewSchema(queryWithArg(&graphql.ArgumentConfig{
+		Type:              graphql.NewNonNull(graphql.String),
+		DefaultValue:      "default",
+		DeprecationReason: "Unused",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "Input",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"field": &graphql.InputObjectFieldConfig{
+				Type:              graphql.NewNonNull(graphql.String),
+				DeprecationReason: "Unused",
+			},
+		},
+	})
+	expected = `Required input field Input.field cannot be deprecated.`
+	if input.Error() == nil || input.Error().Error() != expected {
+		t.Fatalf(`expected %v , got: %v`, expected, input.Error())
+	}
+
+	directive := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:      "dir",
+		Locations: []string{graphql.DirectiveLocationField},
+		Args: graphql.FieldConfigArgument{
+			"arg": &graphql.ArgumentConfig{
+				Type:              graphql.NewNonNull(graphql.String),
+				DeprecationReason: "Unused",
+			},
+		},
+	})
+	_, err = graphql.NewSchema(graphql.SchemaConfig{
+		Query:      blogQuery,
+		Directives: []*graphql.Directive{directive},
+	})
+	expected = `Required argument @dir(arg:) cannot be deprecated.`
+	if err == nil || err.Error() != expected {
+		t.Fatalf(`expected %v , got: %v`, expected, err)
+	}
+}
@@ -1,5 +1,13 @@
 package graphql
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
 const (
 	DirectiveLocationQuery              = "QUERY"
 	DirectiveLocationMutation           = "MUTATION"
@@ -8,6 +16,20 @@ const (
 	DirectiveLocationFragmentDefinition = "FRAGMENT_DEFINITION"
 	DirectiveLocationFragmentSpread     = "FRAGMENT_SPREAD"
 	DirectiveLocationInlineFragment     = "INLINE_FRAGMENT"
+	DirectiveLocationVariableDefinition = "VARIABLE_DEFINITION"
+
+	// Type system definitions
+	DirectiveLocationSchema               = "SCHEMA"
+	DirectiveLocationScalar               = "SCALAR"
+	DirectiveLocationObject               = "OBJECT"
+	DirectiveLocationFieldDefinition      = "FIELD_DEFINITION"
+	DirectiveLocationArgumentDefinition   = "ARGUMENT_DEFINITION"
+	DirectiveLocationInterface            = "INTERFACE"
+	DirectiveLocationUnion                = "UNION"
+	DirectiveLocationEnum                 = "ENUM"
+	DirectiveLocationEnumValue            = "ENUM_VALUE"
+	DirectiveLocationInputObject          = "INPUT_OBJECT"
+	DirectiveLocationInputFieldDefinition = "INPUT_FIELD_DEFINITION"
 )
 
 // Directive structs are used by the GraphQL runtime as a way of modifying execution
@@ -18,6 +40,10 @@ type Directive struct {
 	Locations   []string    `json:"locations"`
 	Args        []*Argument `json:"args"`
 
+	// Resolve, if provided, wraps the resolution of the fields the directive
+	// is used on.
+	Resolve DirectiveResolveFn `json:"-"`
+
 	err error
 }
 
@@ -27,8 +53,33 @@ type DirectiveConfig struct {
 	Description string              `json:"description"`
 	Locations   []string            `json:"locations"`
 	Args        FieldConfigArgument `json:"args"`
+
+	// Resolve, if provided, wraps the resolution of the fields the directive
+	// is used on, such as to transform their value.
+	Resolve DirectiveResolveFn `json:"-"`
 }
 
+// DirectiveResolveParams are the parameters a DirectiveResolveFn is called with.
+type DirectiveResolveParams struct {
+	// Args are the arguments of the directive, coerced to their types, with
+	// the values of variables and default values applied.
+	Args map[string]interface{}
+
+	// Field holds the parameters the field is resolved with.
+	Field ResolveParams
+
+	// Next resolves the field, applying the directives used on it before this
+	// one. The field may be resolved with parameters other than Field.
+	Next FieldResolveFn
+}
+
+// DirectiveResolveFn resolves a field a directive is used on, usually by
+// calling p.Next and transforming the value it returns.
+//
+// When several directives are used on a field, each one wraps the directives
+// preceding it, so that the last one is called first.
+type DirectiveResolveFn func(p DirectiveResolveParams) (interface{}, error)
+
 func NewDirective(config DirectiveConfig) *Directive {
 	dir := &Directive{}
 
@@ -61,21 +112,267 @@ func NewDirective(config DirectiveConfig) *Directive {
 			dir.err = err
 			return dir
 		}
-		args = append(args, &Argument{
+		arg := &Argument{
 			PrivateName:        argName,
 			PrivateDescription: argConfig.Description,
 			Type:               argConfig.Type,
 			DefaultValue:       argConfig.DefaultValue,
-		})
+			DeprecationReason:  argConfig.DeprecationReason,
+			Directives:         argConfig.Directives,
+		}
+		if arg.DeprecationReason == "" {
+			arg.DeprecationReason = deprecationReason(argConfig.Directives)
+		}
+		err = invariant(
+			arg.DeprecationReason == "" || !isRequiredInput(arg.Type, arg.DefaultValue),
+			fmt.Sprintf(`Required argument @%v(%v:) cannot be deprecated.`, config.Name, argName),
+		)
+		if err != nil {
+			dir.err = err
+			return dir
+		}
+		args = append(args, arg)
 	}
 
 	dir.Name = config.Name
 	dir.Description = config.Description
 	dir.Locations = config.Locations
 	dir.Args = args
+	dir.Resolve = config.Resolve
 	return dir
 }
 
+// AppliedDirective is a directive used on a type system definition, such as
+// an object type or a field, to annotate it with metadata available at runtime.
+//
+// Args holds the values of the arguments of the directive, given as they
+// would be as variable values: input objects as maps, and enum values by name.
+// Directives returned by AppliedDirectivesFromAST hold the coerced values of
+// their arguments instead, as resolvers receive them.
+type AppliedDirective struct {
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args"`
+
+	// coerced reports whether Args holds coerced values, validated as
+	// literals when they were coerced.
+	coerced bool
+}
+
+// AppliedDirectivesFromAST returns the directives used on a type system
+// definition parsed from the schema definition language, with the values of
+// their arguments coerced against the given directive definitions and the
+// default values of omitted arguments filled in.
+func AppliedDirectivesFromAST(directives []*Directive, directiveASTs []*ast.Directive) ([]*AppliedDirective, error) {
+	definitions := map[string]*Directive{}
+	for _, directive := range directives {
+		if directive != nil {
+			definitions[directive.Name] = directive
+		}
+	}
+	applied := []*AppliedDirective{}
+	for _, directiveAST := range directiveASTs {
+		if directiveAST == nil || directiveAST.Name == nil {
+			continue
+		}
+		name := directiveAST.Name.Value
+		directive, ok := definitions[name]
+		if !ok {
+			return nil, directiveASTError(fmt.Sprintf(`Unknown directive "@%v".`, name), directiveAST)
+		}
+		argASTs := map[string]*ast.Argument{}
+		for _, argAST := range directiveAST.Arguments {
+			if argAST == nil || argAST.Name == nil {
+				continue
+			}
+			argASTs[argAST.Name.Value] = argAST
+		}
+		argDefs := map[string]*Argument{}
+		for _, arg := range directive.Args {
+			argDefs[arg.Name()] = arg
+		}
+		for argName, argAST := range argASTs {
+			if argDefs[argName] == nil {
+				message := fmt.Sprintf(`Unknown argument "%v" on directive "@%v".`, argName, name)
+				return nil, directiveASTError(message, argAST)
+			}
+		}
+		args := map[string]interface{}{}
+		for _, arg := range directive.Args {
+			argAST, ok := argASTs[arg.Name()]
+			if !ok {
+				if arg.DefaultValue != nil {
+					args[arg.Name()] = arg.DefaultValue
+				}
+				continue
+			}
+			if valid, messages := isValidLiteralValue(arg.Type, argAST.Value); !valid {
+				message := fmt.Sprintf(`Directive "@%v" has invalid argument "%v": %v`,
+					name, arg.Name(), strings.Join(messages, " "))
+				return nil, directiveASTError(message, argAST)
+			}
+			args[arg.Name()] = valueFromAST(argAST.Value, arg.Type, nil)
+		}
+		applied = append(applied, &AppliedDirective{
+			Name:    name,
+			Args:    args,
+			coerced: true,
+		})
+	}
+	return applied, nil
+}
+
+// directiveASTError returns an error located at the node of a directive use.
+func directiveASTError(message string, node ast.Node) error {
+	return gqlerrors.FormatError(gqlerrors.NewError(message, []ast.Node{node}, "", nil, []int{}, nil))
+}
+
+// assertValidAppliedDirectives ensures the directives used on the type system
+// definitions of a schema are directives of the schema, used at one of their
+// locations, at most once each, and with valid arguments.
+func assertValidAppliedDirectives(schema *Schema) error {
+	for _, dir := range schema.Directives() {
+		for _, arg := range dir.Args {
+			element := fmt.Sprintf("@%v(%v:)", dir.Name, arg.Name())
+			err := assertValidDirectiveUses(schema, arg.Directives, DirectiveLocationArgumentDefinition, element)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, ttype := range schema.TypeMap() {
+		var err error
+		switch ttype := ttype.(type) {
+		case *Scalar:
+			err = assertValidDirectiveUses(schema, ttype.Directives(), DirectiveLocationScalar, ttype.Name())
+		case *Object:
+			err = assertValidDirectiveUses(schema, ttype.Directives(), DirectiveLocationObject, ttype.Name())
+			if err == nil {
+				err = assertValidFieldDirectives(schema, ttype, ttype.Fields())
+			}
+		case *Interface:
+			err = assertValidDirectiveUses(schema, ttype.Directives(), DirectiveLocationInterface, ttype.Name())
+			if err == nil {
+				err = assertValidFieldDirectives(schema, ttype, ttype.Fields())
+			}
+		case *Union:
+			err = assertValidDirectiveUses(schema, ttype.Directives(), DirectiveLocationUnion, ttype.Name())
+		case *Enum:
+			err = assertValidDirectiveUses(schema, ttype.Directives(), DirectiveLocationEnum, ttype.Name())
+			for _, value := range ttype.Values() {
+				if err != nil {
+					break
+				}
+				element := fmt.Sprintf("%v.%v", ttype.Name(), value.Name)
+				err = assertValidDirectiveUses(schema, value.Directives, DirectiveLocationEnumValue, element)
+			}
+		case *InputObject:
+			err = assertValidDirectiveUses(schema, ttype.Directives(), DirectiveLocationInputObject, ttype.Name())
+			for fieldName, field := range ttype.Fields() {
+				if err != nil {
+					break
+				}
+				element := fmt.Sprintf("%v.%v", ttype.Name(), fieldName)
+				err = assertValidDirectiveUses(schema, field.Directives, DirectiveLocationInputFieldDefinition, element)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func assertValidFieldDirectives(schema *Schema, ttype Type, fields FieldDefinitionMap) error {
+	for fieldName, field := range fields {
+		element := fmt.Sprintf("%v.%v", ttype.Name(), fieldName)
+		err := assertValidDirectiveUses(schema, field.Directives, DirectiveLocationFieldDefinition, element)
+		if err != nil {
+			return err
+		}
+		for _, arg := range field.Args {
+			element := fmt.Sprintf("%v.%v(%v:)", ttype.Name(), fieldName, arg.Name())
+			err := assertValidDirectiveUses(schema, arg.Directives, DirectiveLocationArgumentDefinition, element)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func assertValidDirectiveUses(schema *Schema, directives []*AppliedDirective, location string, element string) error {
+	used := map[string]bool{}
+	for _, applied := range directives {
+		if applied == nil {
+			continue
+		}
+		directive := schema.Directive(applied.Name)
+		err := invariant(
+			directive != nil,
+			fmt.Sprintf(`%v uses unknown directive "@%v".`, element, applied.Name),
+		)
+		if err != nil {
+			return err
+		}
+		err = invariant(
+			!used[applied.Name],
+			fmt.Sprintf(`%v uses directive "@%v" more than once.`, element, applied.Name),
+		)
+		if err != nil {
+			return err
+		}
+		used[applied.Name] = true
+
+		allowed := false
+		for _, loc := range directive.Locations {
+			if loc == location {
+				allowed = true
+				break
+			}
+		}
+		err = invariant(
+			allowed,
+			fmt.Sprintf(`%v uses directive "@%v", which may not be used on %v.`, element, applied.Name, location),
+		)
+		if err != nil {
+			return err
+		}
+
+		argDefs := map[string]*Argument{}
+		for _, arg := range directive.Args {
+			argDefs[arg.Name()] = arg
+		}
+		for argName := range applied.Args {
+			err := invariant(
+				argDefs[argName] != nil,
+				fmt.Sprintf(`%v uses directive "@%v" with unknown argument "%v".`, element, applied.Name, argName),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		for _, arg := range directive.Args {
+			value, ok := applied.Args[arg.Name()]
+			if !ok && arg.DefaultValue != nil {
+				continue
+			}
+			if ok && applied.coerced {
+				continue
+			}
+			valid, messages := isValidInputValue(value, arg.Type)
+			err := invariant(
+				valid,
+				fmt.Sprintf(`%v uses directive "@%v" with invalid argument "%v": %v`,
+					element, applied.Name, arg.Name(), strings.Join(messages, " ")),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // IncludeDirective is used to conditionally include fields or fragments
 var IncludeDirective = NewDirective(DirectiveConfig{
 	Name: "include",
@@ -111,3 +408,111 @@ var SkipDirective = NewDirective(DirectiveConfig{
 		DirectiveLocationInlineFragment,
 	},
 })
+
+// DefaultDeprecationReason is the reason given by @deprecated when none is
+// provided.
+const DefaultDeprecationReason = "No longer supported"
+
+// DeprecatedDirective is used to declare elements of a GraphQL schema as
+// deprecated. Fields and enum values using it are reported as deprecated by
+// introspection, with the given reason. Arguments and input fields may only be
+// deprecated if they are not required.
+var DeprecatedDirective = NewDirective(DirectiveConfig{
+	Name:        "deprecated",
+	Description: "Marks an element of a GraphQL schema as no longer supported.",
+	Args: FieldConfigArgument{
+		"reason": &ArgumentConfig{
+			Type:         String,
+			DefaultValue: DefaultDeprecationReason,
+			Description: "Explains why this element was deprecated, usually also including a " +
+				"suggestion for how to access supported similar data. Formatted " +
+				"in [Markdown](https://daringfireball.net/projects/markdown/).",
+		},
+	},
+	Locations: []string{
+		DirectiveLocationFieldDefinition,
+		DirectiveLocationArgumentDefinition,
+		DirectiveLocationInputFieldDefinition,
+		DirectiveLocationEnumValue,
+	},
+})
+
+// SpecifiedDirectives are the directives defined by the GraphQL specification.
+// NewSchema always adds @include, @skip and @deprecated to the directives of a
+// schema, unless SchemaConfig.Directives supplies a directive of the same name.
+var SpecifiedDirectives = []*Directive{
+	IncludeDirective,
+	SkipDirective,
+	DeprecatedDirective,
+}
+
+// deprecationReason returns the reason given by the @deprecated directive
+// among the given directives, or an empty string if there is none.
+func deprecationReason(directives []*AppliedDirective) string {
+	for _, directive := range directives {
+		// DeprecatedDirective is not referred to, as it is itself built with
+		// NewDirective.
+		if directive == nil || directive.Name != "deprecated" {
+			continue
+		}
+		if reason, ok := directive.Args["reason"].(string); ok {
+			return reason
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// DeferDirective is used to defer the delivery of a fragment to a subsequent
+// payload of an incrementally delivered result. It is not provided by default,
+// and must be listed in SchemaConfig.Directives to be used.
+var DeferDirective = NewDirective(DirectiveConfig{
+	Name: "defer",
+	Description: "Directs the executor to deliver this fragment in a subsequent " +
+		"payload, unless the `if` argument is false.",
+	Locations: []string{
+		DirectiveLocationFragmentSpread,
+		DirectiveLocationInlineFragment,
+	},
+	Args: FieldConfigArgument{
+		"if": &ArgumentConfig{
+			Type:         Boolean,
+			DefaultValue: true,
+			Description:  "Deferred when true.",
+		},
+		"label": &ArgumentConfig{
+			Type:        String,
+			Description: "Unique name identifying the payload of the fragment.",
+		},
+	},
+})
+
+// StreamDirective is used to deliver the items of a list field after the
+// first initialCount ones in subsequent payloads of an incrementally delivered
+// result. It is not provided by default, and must be listed in
+// SchemaConfig.Directives to be used.
+var StreamDirective = NewDirective(DirectiveConfig{
+	Name: "stream",
+	Description: "Directs the executor to deliver the items of this list field " +
+		"after the first `initialCount` ones in subsequent payloads, unless the " +
+		"`if` argument is false.",
+	Locations: []string{
+		DirectiveLocationField,
+	},
+	Args: FieldConfigArgument{
+		"if": &ArgumentConfig{
+			Type:         Boolean,
+			DefaultValue: true,
+			Description:  "Streamed when true.",
+		},
+		"label": &ArgumentConfig{
+			Type:        String,
+			Description: "Unique name identifying the payloads of the field.",
+		},
+		"initialCount": &ArgumentConfig{
+			Type:         Int,
+			DefaultValue: 0,
+			Description:  "Number of items delivered in the initial payload.",
+		},
+	},
+})
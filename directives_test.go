@@ -1,11 +1,15 @@
 package graphql_test
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/location"
 	"github.com/graphql-go/graphql/testutil"
 )
@@ -598,3 +602,473 @@ func TestDirectivesWorksWithSkipAndIncludeDirectives_NoIncludeOrSkip(t *testing.
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+
+var uppercaseDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "uppercase",
+	Locations: []string{graphql.DirectiveLocationField},
+	Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+		value, err := p.Next(p.Field)
+		if s, ok := value.(string); ok {
+			return strings.ToUpper(s), err
+		}
+		return value, err
+	},
+})
+
+var suffixDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "suffix",
+	Locations: []string{graphql.DirectiveLocationField},
+	Args: graphql.FieldConfigArgument{
+		"with": &graphql.ArgumentConfig{
+			Type:         graphql.String,
+			DefaultValue: "!",
+		},
+	},
+	Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+		value, err := p.Next(p.Field)
+		if s, ok := value.(string); ok {
+			return s + p.Args["with"].(string), err
+		}
+		return value, err
+	},
+})
+
+var forbiddenDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "forbidden",
+	Locations: []string{graphql.DirectiveLocationField},
+	Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+		return nil, errors.New("forbidden field " + p.Field.Info.FieldName)
+	},
+})
+
+var resolvingDirectivesTestSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "TestType",
+		Fields: graphql.Fields{
+			"a": &graphql.Field{
+				Type: graphql.String,
+			},
+			"b": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	}),
+	Directives: []*graphql.Directive{
+		graphql.IncludeDirective,
+		graphql.SkipDirective,
+		uppercaseDirective,
+		suffixDirective,
+		forbiddenDirective,
+	},
+})
+
+func executeResolvingDirectivesTestQuery(t *testing.T, doc string, variables map[string]interface{}) *graphql.Result {
+	return testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: resolvingDirectivesTestSchema,
+		AST:    testutil.TestParse(t, doc),
+		Root:   directivesTestData,
+		Args:   variables,
+	})
+}
+
+func TestDirectivesWorksWithResolvingDirectives_TransformsFieldValue(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "A",
+			"b": "b",
+		},
+	}
+	result := executeResolvingDirectivesTestQuery(t, `{ a @uppercase, b }`, nil)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesWorksWithResolvingDirectives_CoercesDirectiveArguments(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "a!",
+			"b": "b?",
+		},
+	}
+	result := executeResolvingDirectivesTestQuery(t, `
+		query Q($with: String) { a @suffix, b @suffix(with: $with) }
+	`, map[string]interface{}{"with": "?"})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesWorksWithResolvingDirectives_AppliesDirectivesInOrder(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "A?",
+			"b": "B?",
+		},
+	}
+	result := executeResolvingDirectivesTestQuery(t, `{
+		a @uppercase @suffix(with: "?")
+		b @suffix(with: "?") @uppercase
+	}`, nil)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesWorksWithResolvingDirectives_ReportsDirectiveErrors(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": nil,
+			"b": "b",
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message:   "forbidden field a",
+				Locations: []location.SourceLocation{},
+			},
+		},
+	}
+	result := executeResolvingDirectivesTestQuery(t, `{ a @forbidden @uppercase, b @include(if: true) }`, nil)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesWorksWithResolvingDirectives_MergesDirectivesOfMergedFields(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"a": "A!",
+			"b": "b?",
+		},
+	}
+	result := executeResolvingDirectivesTestQuery(t, `{
+		a
+		a @uppercase
+		... on TestType { a @suffix a @uppercase }
+		b @suffix(with: "?")
+		b @suffix(with: "#")
+	}`, nil)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectivesWorksWithResolvingDirectives_CoercesDirectiveArgumentsOnce(t *testing.T) {
+	coercions := 0
+	countedString := graphql.NewScalar(graphql.ScalarConfig{
+		Name:       "CountedString",
+		Serialize:  graphql.String.Serialize,
+		ParseValue: graphql.String.ParseValue,
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			coercions++
+			return graphql.String.ParseLiteral(valueAST)
+		},
+	})
+	prefixDirective := graphql.NewDirective(graphql.DirectiveConfig{
+		Name:      "prefix",
+		Locations: []string{graphql.DirectiveLocationField},
+		Args: graphql.FieldConfigArgument{
+			"with": &graphql.ArgumentConfig{
+				Type: countedString,
+			},
+		},
+		Resolve: func(p graphql.DirectiveResolveParams) (interface{}, error) {
+			value, err := p.Next(p.Field)
+			if s, ok := value.(string); ok {
+				return p.Args["with"].(string) + s, err
+			}
+			return value, err
+		},
+	})
+	item := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Item",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"items": &graphql.Field{
+					Type: graphql.NewList(item),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []interface{}{
+							map[string]interface{}{"name": "a"},
+							map[string]interface{}{"name": "b"},
+							map[string]interface{}{"name": "c"},
+						}, nil
+					},
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{prefixDirective},
+	})
+	if err != nil {
+		t.Fatalf("Error in schema %v", err.Error())
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"name": "-a"},
+				map[string]interface{}{"name": "-b"},
+				map[string]interface{}{"name": "-c"},
+			},
+		},
+	}
+	result := testutil.TestExecute(t, graphql.ExecuteParams{
+		Schema: schema,
+		AST:    testutil.TestParse(t, `{ items { name @prefix(with: "-") } }`),
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	if coercions != 1 {
+		t.Fatalf("Expected directive arguments to be coerced once, got %v", coercions)
+	}
+}
+
+var roleEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "Role",
+	Values: graphql.EnumValueConfigMap{
+		"ADMIN": &graphql.EnumValueConfig{},
+		"USER":  &graphql.EnumValueConfig{},
+	},
+})
+
+var authDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name: "auth",
+	Locations: []string{
+		graphql.DirectiveLocationObject,
+		graphql.DirectiveLocationFieldDefinition,
+	},
+	Args: graphql.FieldConfigArgument{
+		"role": &graphql.ArgumentConfig{
+			Type: graphql.NewNonNull(roleEnum),
+		},
+	},
+})
+
+func schemaWithAppliedDirectives(objectDirectives []*graphql.AppliedDirective) (graphql.Schema, error) {
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:       "Query",
+			Directives: objectDirectives,
+			Fields: graphql.Fields{
+				"secret": &graphql.Field{
+					Type: graphql.String,
+					Directives: []*graphql.AppliedDirective{
+						{Name: "auth", Args: map[string]interface{}{"role": "ADMIN"}},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						fieldRole := p.Info.FieldDefinition.Directives[0].Args["role"]
+						typeRole := p.Info.ParentType.(*graphql.Object).Directives()[0].Args["role"]
+						return fmt.Sprintf("%v %v", typeRole, fieldRole), nil
+					},
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{
+			graphql.IncludeDirective,
+			graphql.SkipDirective,
+			authDirective,
+		},
+	})
+}
+
+func TestDirectives_SchemaDirectivesAreAccessibleAtRuntime(t *testing.T) {
+	schema, err := schemaWithAppliedDirectives([]*graphql.AppliedDirective{
+		{Name: "auth", Args: map[string]interface{}{"role": "USER"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"secret": "USER ADMIN",
+		},
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ secret }`,
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDirectives_SchemaDirectivesMustBeValid(t *testing.T) {
+	tests := []struct {
+		directives []*graphql.AppliedDirective
+		message    string
+	}{
+		{
+			[]*graphql.AppliedDirective{{Name: "unknown"}},
+			`Query uses unknown directive "@unknown".`,
+		},
+		{
+			[]*graphql.AppliedDirective{{Name: "include", Args: map[string]interface{}{"if": true}}},
+			`Query uses directive "@include", which may not be used on OBJECT.`,
+		},
+		{
+			[]*graphql.AppliedDirective{{Name: "auth"}},
+			`Query uses directive "@auth" with invalid argument "role": Expected "Role!", found null.`,
+		},
+		{
+			[]*graphql.AppliedDirective{{Name: "auth", Args: map[string]interface{}{"role": "OWNER"}}},
+			`Query uses directive "@auth" with invalid argument "role": Expected type "Role", found "OWNER".`,
+		},
+		{
+			[]*graphql.AppliedDirective{{Name: "auth", Args: map[string]interface{}{"role": "USER", "level": 1}}},
+			`Query uses directive "@auth" with unknown argument "level".`,
+		},
+		{
+			[]*graphql.AppliedDirective{
+				{Name: "auth", Args: map[string]interface{}{"role": "USER"}},
+				{Name: "auth", Args: map[string]interface{}{"role": "ADMIN"}},
+			},
+			`Query uses directive "@auth" more than once.`,
+		},
+	}
+	for _, test := range tests {
+		_, err := schemaWithAppliedDirectives(test.directives)
+		expectedErr := gqlerrors.FormattedError{
+			Message:   test.message,
+			Locations: []location.SourceLocation{},
+		}
+		if !reflect.DeepEqual(expectedErr, err) {
+			t.Fatalf("Expected error to be equal, got: %v", testutil.Diff(expectedErr, err))
+		}
+	}
+}
+
+func TestDirectives_SpecifiedDirectivesAreAlwaysProvided(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"old": &graphql.Field{
+					Type: graphql.String,
+					Directives: []*graphql.AppliedDirective{
+						{Name: "deprecated"},
+					},
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{authDirective},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []*graphql.Directive{
+		authDirective,
+		graphql.IncludeDirective,
+		graphql.SkipDirective,
+		graphql.DeprecatedDirective,
+	}
+	if !reflect.DeepEqual(expected, schema.Directives()) {
+		t.Fatalf("Unexpected directives, Diff: %v", testutil.Diff(expected, schema.Directives()))
+	}
+	reason := schema.QueryType().Fields()["old"].DeprecationReason
+	if reason != graphql.DefaultDeprecationReason {
+		t.Fatalf("Unexpected deprecation reason: %v", reason)
+	}
+}
+
+var cachedDirective = graphql.NewDirective(graphql.DirectiveConfig{
+	Name:      "cached",
+	Locations: []string{graphql.DirectiveLocationObject},
+	Args: graphql.FieldConfigArgument{
+		"ttl": &graphql.ArgumentConfig{
+			Type: graphql.Int,
+		},
+		"scope": &graphql.ArgumentConfig{
+			Type: graphql.NewInputObject(graphql.InputObjectConfig{
+				Name: "CacheScope",
+				Fields: graphql.InputObjectConfigFieldMap{
+					"public": &graphql.InputObjectFieldConfig{
+						Type: graphql.Boolean,
+					},
+				},
+			}),
+		},
+		"tags": &graphql.ArgumentConfig{
+			Type:         graphql.NewList(graphql.String),
+			DefaultValue: []interface{}{"default"},
+		},
+	},
+})
+
+func TestDirectives_AppliedDirectivesFromAST(t *testing.T) {
+	doc := testutil.TestParse(t, `
+		type Query @auth(role: ADMIN) @cached(ttl: 60, scope: {public: true}, tags: "a") @cached {
+			hello: String
+		}
+	`)
+	object := doc.Definitions[0].(*ast.ObjectDefinition)
+	expected := []graphql.AppliedDirective{
+		{Name: "auth", Args: map[string]interface{}{"role": "ADMIN"}},
+		{Name: "cached", Args: map[string]interface{}{
+			"ttl":   60,
+			"scope": map[string]interface{}{"public": true},
+			"tags":  []interface{}{"a"},
+		}},
+		{Name: "cached", Args: map[string]interface{}{
+			"tags": []interface{}{"default"},
+		}},
+	}
+	directives, err := graphql.AppliedDirectivesFromAST([]*graphql.Directive{authDirective, cachedDirective}, object.Directives)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(directives) != len(expected) {
+		t.Fatalf("Expected %v directives, got %v", len(expected), len(directives))
+	}
+	for i, directive := range directives {
+		if directive.Name != expected[i].Name || !reflect.DeepEqual(expected[i].Args, directive.Args) {
+			t.Fatalf("Unexpected directive, Diff: %v", testutil.Diff(expected[i], *directive))
+		}
+	}
+}
+
+func TestDirectives_AppliedDirectivesFromASTMustBeValid(t *testing.T) {
+	tests := []struct {
+		definition string
+		message    string
+		location   location.SourceLocation
+	}{
+		{
+			`type Query @unknown { hello: String }`,
+			`Unknown directive "@unknown".`,
+			location.SourceLocation{Line: 1, Column: 12},
+		},
+		{
+			`type Query @auth(role: ADMIN, level: 1) { hello: String }`,
+			`Unknown argument "level" on directive "@auth".`,
+			location.SourceLocation{Line: 1, Column: 31},
+		},
+		{
+			`type Query @auth(role: OWNER) { hello: String }`,
+			`Directive "@auth" has invalid argument "role": Expected type "Role", found OWNER.`,
+			location.SourceLocation{Line: 1, Column: 18},
+		},
+		{
+			`type Query @cached(ttl: "60") { hello: String }`,
+			`Directive "@cached" has invalid argument "ttl": Expected type "Int", found "60".`,
+			location.SourceLocation{Line: 1, Column: 20},
+		},
+	}
+	for _, test := range tests {
+		object := testutil.TestParse(t, test.definition).Definitions[0].(*ast.ObjectDefinition)
+		_, err := graphql.AppliedDirectivesFromAST([]*graphql.Directive{authDirective, cachedDirective}, object.Directives)
+		expectedErr := gqlerrors.FormattedError{
+			Message:   test.message,
+			Locations: []location.SourceLocation{test.location},
+		}
+		if !reflect.DeepEqual(expectedErr, err) {
+			t.Fatalf("Expected error to be equal, got: %v", testutil.Diff(expectedErr, err))
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DocumentCache is a least recently used cache of parsed and validated
+// documents, keyed by schema, request string and parse limits. It may be
+// shared by
+// concurrent calls to Do through Params.DocumentCache.
+//
+// Only valid documents are cached, so that requests failing to parse or
+// validate cannot evict them.
+type DocumentCache struct {
+	size int
+
+	entries map[documentCacheKey]*list.Element
+	order   *list.List
+	mutex   sync.Mutex
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+// DocumentCacheStats reports the activity of a DocumentCache.
+type DocumentCacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Len       int
+}
+
+// documentCacheKey identifies a request string parsed within limits and
+// validated against a schema.
+type documentCacheKey struct {
+	schema        uint64
+	requestString string
+	limits        ParseLimits
+}
+
+type documentCacheEntry struct {
+	key documentCacheKey
+	AST *ast.Document
+}
+
+// NewDocumentCache returns a DocumentCache holding at most size documents.
+func NewDocumentCache(size int) *DocumentCache {
+	return &DocumentCache{
+		size:    size,
+		entries: map[documentCacheKey]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Get returns the valid document cached for the request string and schema,
+// parsed without limits, if any.
+func (c *DocumentCache) Get(schema *Schema, requestString string) (*ast.Document, bool) {
+	return c.get(documentCacheKey{schema.id, requestString, ParseLimits{}})
+}
+
+func (c *DocumentCache) get(key documentCacheKey) (*ast.Document, bool) {
+	c.mutex.Lock()
+	elem, ok := c.entries[key]
+	if ok {
+		c.order.MoveToFront(elem)
+	}
+	c.mutex.Unlock()
+
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return nil, false
+	}
+	atomic.AddUint64(&c.hits, 1)
+	return elem.Value.(*documentCacheEntry).AST, true
+}
+
+// Add caches the valid document of the request string and schema, parsed
+// without limits, evicting the least recently used document if the cache is
+// full.
+func (c *DocumentCache) Add(schema *Schema, requestString string, AST *ast.Document) {
+	c.add(documentCacheKey{schema.id, requestString, ParseLimits{}}, AST)
+}
+
+func (c *DocumentCache) add(key documentCacheKey, AST *ast.Document) {
+	entry := &documentCacheEntry{
+		key: key,
+		AST: AST,
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*documentCacheEntry).key)
+		atomic.AddUint64(&c.evictions, 1)
+	}
+}
+
+// Stats returns the number of hits, misses and evictions since the cache was
+// created, along with the number of cached documents.
+func (c *DocumentCache) Stats() DocumentCacheStats {
+	c.mutex.Lock()
+	length := c.order.Len()
+	c.mutex.Unlock()
+	return DocumentCacheStats{
+		Hits:      atomic.LoadUint64(&c.hits),
+		Misses:    atomic.LoadUint64(&c.misses),
+		Evictions: atomic.LoadUint64(&c.evictions),
+		Len:       length,
+	}
+}
@@ -0,0 +1,154 @@
+package graphql_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestDocumentCache_ReusesValidatedDocuments(t *testing.T) {
+	cache := graphql.NewDocumentCache(10)
+	query := `{ hero { name } }`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+			},
+		},
+	}
+	for i := 0; i < 3; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        testutil.StarWarsSchema,
+			RequestString: query,
+			DocumentCache: cache,
+		})
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+		}
+	}
+	expectedStats := graphql.DocumentCacheStats{Hits: 2, Misses: 1, Len: 1}
+	if stats := cache.Stats(); !reflect.DeepEqual(expectedStats, stats) {
+		t.Fatalf("unexpected stats, got: %+v, expected: %+v", stats, expectedStats)
+	}
+}
+
+func TestDocumentCache_DoesNotCacheInvalidDocuments(t *testing.T) {
+	cache := graphql.NewDocumentCache(10)
+	for i := 0; i < 2; i++ {
+		result := graphql.Do(graphql.Params{
+			Schema:        testutil.StarWarsSchema,
+			RequestString: `{ unknown }`,
+			DocumentCache: cache,
+		})
+		if len(result.Errors) != 1 {
+			t.Fatalf("expected one error, got: %v", result.Errors)
+		}
+	}
+	if stats := cache.Stats(); stats.Hits != 0 || stats.Len != 0 {
+		t.Fatalf("expected no cached documents, got: %+v", stats)
+	}
+}
+
+func TestDocumentCache_DoesNotCacheSyntaxErrors(t *testing.T) {
+	cache := graphql.NewDocumentCache(10)
+	result := graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `{ hero `,
+		DocumentCache: cache,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got: %v", result.Errors)
+	}
+	if stats := cache.Stats(); stats.Len != 0 {
+		t.Fatalf("expected no cached documents, got: %+v", stats)
+	}
+}
+
+func TestDocumentCache_KeysBySchema(t *testing.T) {
+	cache := graphql.NewDocumentCache(10)
+	otherSchema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"other": &graphql.Field{Type: graphql.String},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := `{ __typename }`
+	for _, schema := range []graphql.Schema{testutil.StarWarsSchema, otherSchema, testutil.StarWarsSchema} {
+		result := graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: query,
+			DocumentCache: cache,
+		})
+		if result.HasErrors() {
+			t.Fatalf("unexpected errors: %v", result.Errors)
+		}
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Len != 2 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestDocumentCache_KeysByParseLimits(t *testing.T) {
+	cache := graphql.NewDocumentCache(10)
+	query := `{ hero { name } }`
+	for _, limits := range []graphql.ParseLimits{{}, {MaxDepth: 1}} {
+		graphql.Do(graphql.Params{
+			Schema:        testutil.StarWarsSchema,
+			RequestString: query,
+			DocumentCache: cache,
+			ParseLimits:   limits,
+		})
+	}
+	expectedStats := graphql.DocumentCacheStats{Misses: 2, Len: 1}
+	if stats := cache.Stats(); !reflect.DeepEqual(expectedStats, stats) {
+		t.Fatalf("unexpected stats, got: %+v, expected: %+v", stats, expectedStats)
+	}
+}
+
+func TestDocumentCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := graphql.NewDocumentCache(2)
+	queries := []string{`{ hero { id } }`, `{ hero { name } }`, `{ hero { id } }`, `{ hero { appearsIn } }`, `{ hero { name } }`}
+	for _, query := range queries {
+		graphql.Do(graphql.Params{
+			Schema:        testutil.StarWarsSchema,
+			RequestString: query,
+			DocumentCache: cache,
+		})
+	}
+	expectedStats := graphql.DocumentCacheStats{Hits: 1, Misses: 4, Evictions: 2, Len: 2}
+	if stats := cache.Stats(); !reflect.DeepEqual(expectedStats, stats) {
+		t.Fatalf("unexpected stats, got: %+v, expected: %+v", stats, expectedStats)
+	}
+}
+
+func TestDocumentCache_ConcurrentAccess(t *testing.T) {
+	cache := graphql.NewDocumentCache(1)
+	queries := []string{`{ hero { id } }`, `{ hero { name } }`}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result := graphql.Do(graphql.Params{
+				Schema:        testutil.StarWarsSchema,
+				RequestString: queries[i%2],
+				DocumentCache: cache,
+			})
+			if result.HasErrors() {
+				t.Errorf("unexpected errors: %v", result.Errors)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if stats := cache.Stats(); stats.Hits+stats.Misses != 20 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/handler"
 )
 
 type user struct {
@@ -73,27 +74,19 @@ var schema, _ = graphql.NewSchema(
 	},
 )
 
-func executeQuery(query string, schema graphql.Schema) *graphql.Result {
-	result := graphql.Do(graphql.Params{
-		Schema:        schema,
-		RequestString: query,
-	})
-	if len(result.Errors) > 0 {
-		fmt.Printf("wrong result, unexpected errors: %v", result.Errors)
-	}
-	return result
-}
-
 func main() {
 	_ = importJSONDataFromFile("data.json", &data)
 
-	http.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
-		result := executeQuery(r.URL.Query()["query"][0], schema)
-		json.NewEncoder(w).Encode(result)
-	})
+	http.Handle("/graphql", handler.New(&handler.Config{
+		Schema:   &schema,
+		Pretty:   true,
+		Explorer: &handler.ExplorerConfig{},
+	}))
 
 	fmt.Println("Now server is running on port 8080")
 	fmt.Println("Test with Get      : curl -g 'http://localhost:8080/graphql?query={user(id:\"1\"){name}}'")
+	fmt.Println("Test with Post     : curl -H 'Content-Type: application/graphql' -d '{user(id:\"1\"){name}}' http://localhost:8080/graphql")
+	fmt.Println("Explore in browser : http://localhost:8080/graphql")
 	http.ListenAndServe(":8080", nil)
 }
 
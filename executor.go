@@ -43,6 +43,14 @@ func Execute(p ExecuteParams) (result *Result) {
 		return
 	}
 
+	return executeRecoveringPanics(exeContext, p.Root)
+}
+
+// executeRecoveringPanics executes the operation of the execution context,
+// reporting a panic that escapes field resolution as an error of the result.
+func executeRecoveringPanics(exeContext *ExecutionContext, root interface{}) (result *Result) {
+	result = &Result{}
+
 	defer func() {
 		if r := recover(); r != nil {
 			var err error
@@ -56,7 +64,7 @@ func Execute(p ExecuteParams) (result *Result) {
 
 	return executeOperation(ExecuteOperationParams{
 		ExecutionContext: exeContext,
-		Root:             p.Root,
+		Root:             root,
 		Operation:        exeContext.Operation,
 	})
 }
@@ -81,6 +89,45 @@ type ExecutionContext struct {
 
 	errors   []gqlerrors.FormattedError
 	errMutex sync.RWMutex
+
+	// plan memoizes field collection and lookups when executing a PreparedQuery.
+	plan *executionPlan
+
+	// directiveArgs holds the arguments of the directives used on fields,
+	// coerced once per execution.
+	directiveArgs *directiveArgsCache
+
+	// incremental delivers deferred fragments and streamed list items in
+	// subsequent payloads when executing with ExecuteIncremental.
+	incremental *incrementalPublisher
+
+	// published, when executing incrementally, is closed once the payload
+	// executed with the context has been handed over to be sent.
+	published chan struct{}
+
+	// nulledPaths, when executing incrementally, are the paths of the values
+	// nulled by errors in the payload, under which deferred fragments and
+	// streamed list items are dropped. They are protected by errMutex.
+	// dropped tells whether the payload was dropped itself.
+	nulledPaths [][]interface{}
+	dropped     bool
+}
+
+// fork returns a context to execute a part of the operation with, collecting
+// its own errors.
+func (eCtx *ExecutionContext) fork() *ExecutionContext {
+	return &ExecutionContext{
+		Schema:         eCtx.Schema,
+		Fragments:      eCtx.Fragments,
+		Root:           eCtx.Root,
+		Operation:      eCtx.Operation,
+		VariableValues: eCtx.VariableValues,
+		Context:        eCtx.Context,
+		errMutex:       sync.RWMutex{},
+		plan:           eCtx.plan,
+		directiveArgs:  eCtx.directiveArgs,
+		incremental:    eCtx.incremental,
+	}
 }
 
 func (eCtx *ExecutionContext) AppendError(errs ...error) {
@@ -107,18 +154,42 @@ func (eCtx *ExecutionContext) SetErrors(errors []gqlerrors.FormattedError) {
 
 func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error) {
 	eCtx := &ExecutionContext{
-		errMutex: sync.RWMutex{},
+		errMutex:      sync.RWMutex{},
+		directiveArgs: &directiveArgsCache{},
+	}
+	operation, fragments, err := getOperationAndFragments(p.AST, p.OperationName)
+	if err != nil {
+		return nil, err
 	}
+
+	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	eCtx.Schema = p.Schema
+	eCtx.Fragments = fragments
+	eCtx.Root = p.Root
+	eCtx.Operation = operation
+	eCtx.VariableValues = variableValues
+	eCtx.SetErrors(p.Errors)
+	eCtx.Context = p.Context
+	return eCtx, nil
+}
+
+// Selects the operation to execute from the document, along with all the
+// fragments it may refer to.
+func getOperationAndFragments(AST *ast.Document, operationName string) (*ast.OperationDefinition, map[string]ast.Definition, error) {
 	var operation *ast.OperationDefinition
 	fragments := map[string]ast.Definition{}
 
-	for _, definition := range p.AST.Definitions {
+	for _, definition := range AST.Definitions {
 		switch definition := definition.(type) {
 		case *ast.OperationDefinition:
-			if (p.OperationName == "") && operation != nil {
-				return nil, errors.New("Must provide operation name if query contains multiple operations.")
+			if (operationName == "") && operation != nil {
+				return nil, nil, errors.New("Must provide operation name if query contains multiple operations.")
 			}
-			if p.OperationName == "" || definition.GetName() != nil && definition.GetName().Value == p.OperationName {
+			if operationName == "" || definition.GetName() != nil && definition.GetName().Value == operationName {
 				operation = definition
 			}
 		case *ast.FragmentDefinition:
@@ -128,30 +199,17 @@ func buildExecutionContext(p BuildExecutionCtxParams) (*ExecutionContext, error)
 			}
 			fragments[key] = definition
 		default:
-			return nil, fmt.Errorf("GraphQL cannot execute a request containing a %v", definition.GetKind())
+			return nil, nil, fmt.Errorf("GraphQL cannot execute a request containing a %v", definition.GetKind())
 		}
 	}
 
 	if operation == nil {
-		if p.OperationName != "" {
-			return nil, fmt.Errorf(`Unknown operation named "%v".`, p.OperationName)
+		if operationName != "" {
+			return nil, nil, fmt.Errorf(`Unknown operation named "%v".`, operationName)
 		}
-		return nil, fmt.Errorf(`Must provide an operation.`)
+		return nil, nil, fmt.Errorf(`Must provide an operation.`)
 	}
-
-	variableValues, err := getVariableValues(p.Schema, operation.GetVariableDefinitions(), p.Args)
-	if err != nil {
-		return nil, err
-	}
-
-	eCtx.Schema = p.Schema
-	eCtx.Fragments = fragments
-	eCtx.Root = p.Root
-	eCtx.Operation = operation
-	eCtx.VariableValues = variableValues
-	eCtx.SetErrors(p.Errors)
-	eCtx.Context = p.Context
-	return eCtx, nil
+	return operation, fragments, nil
 }
 
 type ExecuteOperationParams struct {
@@ -166,11 +224,9 @@ func executeOperation(p ExecuteOperationParams) *Result {
 		return &Result{Errors: gqlerrors.FormatErrors(err)}
 	}
 
-	fields := collectFields(CollectFieldsParams{
-		ExeContext:   p.ExecutionContext,
-		RuntimeType:  operationType,
-		SelectionSet: p.Operation.GetSelectionSet(),
-	})
+	deferred := []*deferredFragment{}
+	fields := collectRootFields(p.ExecutionContext, operationType, p.Operation.GetSelectionSet(), &deferred)
+	p.ExecutionContext.incremental.deferFragments(p.ExecutionContext, operationType, p.Root, nil, deferred)
 
 	executeFieldsParams := ExecuteFieldsParams{
 		ExecutionContext: p.ExecutionContext,
@@ -238,6 +294,9 @@ type ExecuteFieldsParams struct {
 	ParentType       *Object
 	Source           interface{}
 	Fields           map[string][]*ast.Field
+
+	// Path is the path of the object the fields are executed on.
+	Path *ResponsePath
 }
 
 // Implements the "Evaluating selection sets" section of the spec for "write" mode.
@@ -255,7 +314,7 @@ func executeFieldsSerially(p ExecuteFieldsParams) *Result {
 		if fieldDef == nil {
 			continue
 		}
-		resolved := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldDef, fieldASTs)
+		resolved := resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldDef, fieldASTs, p.Path.WithKey(responseName))
 		finalResults[responseName] = resolved
 	}
 
@@ -302,12 +361,12 @@ func executeFields(p ExecuteFieldsParams) *Result {
 					wg.Done()
 				}()
 				parallelResults <- parallelFieldResult{
-					Value:        resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldDef, fieldASTs),
+					Value:        resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldDef, fieldASTs, p.Path.WithKey(responseName)),
 					ResponseName: responseName,
 				}
 			}(responseName, fieldASTs)
 		} else {
-			finalResults[responseName] = resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldDef, fieldASTs)
+			finalResults[responseName] = resolveField(p.ExecutionContext, p.ParentType, p.Source, fieldDef, fieldASTs, p.Path.WithKey(responseName))
 		}
 	}
 
@@ -337,6 +396,10 @@ type CollectFieldsParams struct {
 	Fields               map[string][]*ast.Field
 	FieldOrder           []string
 	VisitedFragmentNames map[string]bool
+
+	// deferred, if provided, collects the fragments using @defer rather than
+	// their fields.
+	deferred *[]*deferredFragment
 }
 
 // Given a selectionSet, adds all of the fields in that selection to
@@ -373,12 +436,19 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				!doesFragmentConditionMatch(p.ExeContext, selection, p.RuntimeType) {
 				continue
 			}
+			if p.deferred != nil {
+				if label, ok := deferDirective(p.ExeContext, selection.Directives); ok {
+					*p.deferred = append(*p.deferred, &deferredFragment{label, selection.SelectionSet})
+					continue
+				}
+			}
 			innerParams := CollectFieldsParams{
 				ExeContext:           p.ExeContext,
 				RuntimeType:          p.RuntimeType,
 				SelectionSet:         selection.SelectionSet,
 				Fields:               fields,
 				VisitedFragmentNames: p.VisitedFragmentNames,
+				deferred:             p.deferred,
 			}
 			collectFields(innerParams)
 		case *ast.FragmentSpread:
@@ -400,12 +470,19 @@ func collectFields(p CollectFieldsParams) map[string][]*ast.Field {
 				if !doesFragmentConditionMatch(p.ExeContext, fragment, p.RuntimeType) {
 					continue
 				}
+				if p.deferred != nil {
+					if label, ok := deferDirective(p.ExeContext, selection.Directives); ok {
+						*p.deferred = append(*p.deferred, &deferredFragment{label, fragment.GetSelectionSet()})
+						continue
+					}
+				}
 				innerParams := CollectFieldsParams{
 					ExeContext:           p.ExeContext,
 					RuntimeType:          p.RuntimeType,
 					SelectionSet:         fragment.GetSelectionSet(),
 					Fields:               fields,
 					VisitedFragmentNames: p.VisitedFragmentNames,
+					deferred:             p.deferred,
 				}
 				collectFields(innerParams)
 			}
@@ -475,6 +552,13 @@ func shouldIncludeNode(eCtx *ExecutionContext, directives []*ast.Directive) bool
 
 // Determines if a fragment is applicable to the given type.
 func doesFragmentConditionMatch(eCtx *ExecutionContext, fragment ast.Node, ttype *Object) bool {
+	if eCtx.plan != nil {
+		return eCtx.plan.fragmentConditionMatches(eCtx, fragment, ttype)
+	}
+	return fragmentConditionMatches(eCtx, fragment, ttype)
+}
+
+func fragmentConditionMatches(eCtx *ExecutionContext, fragment ast.Node, ttype *Object) bool {
 
 	switch fragment := fragment.(type) {
 	case *ast.FragmentDefinition:
@@ -543,6 +627,9 @@ func fieldDefFromASTs(eCtx *ExecutionContext, parentType *Object, fieldASTs []*a
 		fieldName = fieldAST.Name.Value
 	}
 
+	if eCtx.plan != nil {
+		return eCtx.plan.fieldDef(eCtx.Schema, parentType, fieldName)
+	}
 	return getFieldDef(eCtx.Schema, parentType, fieldName)
 }
 
@@ -550,7 +637,7 @@ func fieldDefFromASTs(eCtx *ExecutionContext, parentType *Object, fieldASTs []*a
 // figures out the value that the field returns by calling its resolve function,
 // then calls completeValue to complete promises, serialize scalars, or execute
 // the sub-selection-set for objects.
-func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}, fieldDef *FieldDefinition, fieldASTs []*ast.Field) interface{} {
+func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}, fieldDef *FieldDefinition, fieldASTs []*ast.Field, path *ResponsePath) interface{} {
 	var returnType Output
 	// catch panic from resolveFn
 	defer func() {
@@ -584,22 +671,30 @@ func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}
 	if resolveFn == nil {
 		resolveFn = defaultResolveFn
 	}
+	resolveFn = directiveResolveFn(eCtx, fieldASTs, resolveFn)
 
 	// Build a map of arguments from the field.arguments AST, using the
 	// variables scope to fulfill any variable references.
 	// TODO: find a way to memoize, in case this field is within a List type.
-	args, _ := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	var args map[string]interface{}
+	if eCtx.plan != nil {
+		args = eCtx.plan.argumentValues(eCtx, fieldDef, fieldAST)
+	} else {
+		args, _ = getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	}
 
 	info := ResolveInfo{
-		FieldName:      fieldName,
-		FieldASTs:      fieldASTs,
-		ReturnType:     returnType,
-		ParentType:     parentType,
-		Schema:         eCtx.Schema,
-		Fragments:      eCtx.Fragments,
-		RootValue:      eCtx.Root,
-		Operation:      eCtx.Operation,
-		VariableValues: eCtx.VariableValues,
+		FieldName:       fieldName,
+		FieldASTs:       fieldASTs,
+		ReturnType:      returnType,
+		ParentType:      parentType,
+		Schema:          eCtx.Schema,
+		Fragments:       eCtx.Fragments,
+		RootValue:       eCtx.Root,
+		Operation:       eCtx.Operation,
+		VariableValues:  eCtx.VariableValues,
+		Path:            path,
+		FieldDefinition: fieldDef,
 	}
 
 	var resolveFnError error
@@ -620,6 +715,82 @@ func resolveField(eCtx *ExecutionContext, parentType *Object, source interface{}
 	return completed
 }
 
+// directiveResolveFn wraps the resolve function of a field with the
+// handlers of the directives used on it, in the order they are used. The
+// directives of every occurrence of the field merged under a response name
+// are applied, each directive once.
+func directiveResolveFn(eCtx *ExecutionContext, fieldASTs []*ast.Field, resolveFn FieldResolveFn) FieldResolveFn {
+	var applied map[string]bool
+	for _, fieldAST := range fieldASTs {
+		if fieldAST == nil {
+			continue
+		}
+		for _, directiveAST := range fieldAST.Directives {
+			if directiveAST == nil || directiveAST.Name == nil || applied[directiveAST.Name.Value] {
+				continue
+			}
+			directive := eCtx.Schema.Directive(directiveAST.Name.Value)
+			if directive == nil || directive.Resolve == nil {
+				continue
+			}
+			if applied == nil {
+				applied = map[string]bool{}
+			}
+			applied[directive.Name] = true
+			resolveFn = wrapResolveFn(directive.Resolve, eCtx.directiveArgValues(directive, directiveAST), resolveFn)
+		}
+	}
+	return resolveFn
+}
+
+// directiveArgValues returns the arguments of a directive used on a field,
+// coerced once per execution, or once per PreparedQuery when they do not
+// depend on variables.
+func (eCtx *ExecutionContext) directiveArgValues(directive *Directive, directiveAST *ast.Directive) map[string]interface{} {
+	if eCtx.plan != nil && !argumentsHaveVariables(directiveAST.Arguments) {
+		return eCtx.plan.directiveArgs.get(directive, directiveAST, nil)
+	}
+	if eCtx.directiveArgs == nil {
+		args, _ := getArgumentValues(directive.Args, directiveAST.Arguments, eCtx.VariableValues)
+		return args
+	}
+	return eCtx.directiveArgs.get(directive, directiveAST, eCtx.VariableValues)
+}
+
+// directiveArgsCache holds the coerced arguments of directives by their use.
+type directiveArgsCache struct {
+	mutex sync.RWMutex
+	args  map[*ast.Directive]map[string]interface{}
+}
+
+// get returns the arguments of the directive, coercing them on first use.
+// Each call returns a fresh copy of them, as handlers may modify it.
+func (c *directiveArgsCache) get(directive *Directive, directiveAST *ast.Directive, variables map[string]interface{}) map[string]interface{} {
+	c.mutex.RLock()
+	args, ok := c.args[directiveAST]
+	c.mutex.RUnlock()
+	if !ok {
+		args, _ = getArgumentValues(directive.Args, directiveAST.Arguments, variables)
+		c.mutex.Lock()
+		if c.args == nil {
+			c.args = map[*ast.Directive]map[string]interface{}{}
+		}
+		c.args[directiveAST] = args
+		c.mutex.Unlock()
+	}
+	return copyValue(args).(map[string]interface{})
+}
+
+func wrapResolveFn(directiveResolve DirectiveResolveFn, args map[string]interface{}, next FieldResolveFn) FieldResolveFn {
+	return func(p ResolveParams) (interface{}, error) {
+		return directiveResolve(DirectiveResolveParams{
+			Args:  args,
+			Field: p,
+			Next:  next,
+		})
+	}
+}
+
 func completeValueCatchingError(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Field, info ResolveInfo, result interface{}) (completed interface{}) {
 	// catch panic
 	defer func() interface{} {
@@ -631,6 +802,7 @@ func completeValueCatchingError(eCtx *ExecutionContext, returnType Type, fieldAS
 			if err, ok := r.(gqlerrors.FormattedError); ok {
 				eCtx.AppendError(err)
 			}
+			eCtx.nullify(info.Path)
 			return completed
 		}
 		return completed
@@ -682,7 +854,7 @@ func completeValue(eCtx *ExecutionContext, returnType Type, fieldASTs []*ast.Fie
 	// If field type is a leaf type, Scalar or Enum, serialize to a valid value,
 	// returning null if serialization is not possible.
 	if returnType, ok := returnType.(*Scalar); ok {
-		return completeLeafValue(returnType, result)
+		return completeScalarValue(returnType, fieldASTs, result)
 	}
 	if returnType, ok := returnType.(*Enum); ok {
 		return completeLeafValue(returnType, result)
@@ -768,6 +940,52 @@ func completeObjectValue(eCtx *ExecutionContext, returnType *Object, fieldASTs [
 	}
 
 	// Collect sub-fields to execute to complete this value.
+	var subFieldASTs map[string][]*ast.Field
+	if eCtx.plan != nil && eCtx.incremental == nil {
+		subFieldASTs = eCtx.plan.subFields(eCtx, returnType, fieldASTs)
+	} else {
+		deferred := []*deferredFragment{}
+		subFieldASTs = collectSubFields(eCtx, returnType, fieldASTs, &deferred)
+		eCtx.incremental.deferFragments(eCtx, returnType, result, info.Path, deferred)
+	}
+	executeFieldsParams := ExecuteFieldsParams{
+		ExecutionContext: eCtx,
+		ParentType:       returnType,
+		Source:           result,
+		Fields:           subFieldASTs,
+		Path:             info.Path,
+	}
+	results := executeFields(executeFieldsParams)
+
+	return results.Data
+
+}
+
+// collectRootFields collects the fields of the operation's selection set.
+// When executing incrementally, deferred fragments are added to deferred
+// rather than collected.
+func collectRootFields(eCtx *ExecutionContext, operationType *Object, selectionSet *ast.SelectionSet, deferred *[]*deferredFragment) map[string][]*ast.Field {
+	if eCtx.plan != nil && eCtx.incremental == nil {
+		return eCtx.plan.rootFields(eCtx, operationType, selectionSet)
+	}
+	if eCtx.incremental == nil {
+		deferred = nil
+	}
+	return collectFields(CollectFieldsParams{
+		ExeContext:   eCtx,
+		RuntimeType:  operationType,
+		SelectionSet: selectionSet,
+		deferred:     deferred,
+	})
+}
+
+// collectSubFields collects the sub-fields selected on all the given fields for the runtime type.
+// When executing incrementally, deferred fragments are added to deferred
+// rather than collected.
+func collectSubFields(eCtx *ExecutionContext, returnType *Object, fieldASTs []*ast.Field, deferred *[]*deferredFragment) map[string][]*ast.Field {
+	if eCtx.incremental == nil {
+		deferred = nil
+	}
 	subFieldASTs := map[string][]*ast.Field{}
 	visitedFragmentNames := map[string]bool{}
 	for _, fieldAST := range fieldASTs {
@@ -782,20 +1000,12 @@ func completeObjectValue(eCtx *ExecutionContext, returnType *Object, fieldASTs [
 				SelectionSet:         selectionSet,
 				Fields:               subFieldASTs,
 				VisitedFragmentNames: visitedFragmentNames,
+				deferred:             deferred,
 			}
 			subFieldASTs = collectFields(innerParams)
 		}
 	}
-	executeFieldsParams := ExecuteFieldsParams{
-		ExecutionContext: eCtx,
-		ParentType:       returnType,
-		Source:           result,
-		Fields:           subFieldASTs,
-	}
-	results := executeFields(executeFieldsParams)
-
-	return results.Data
-
+	return subFieldASTs
 }
 
 // completeLeafValue complete a leaf value (Scalar / Enum) by serializing to a valid value, returning nil if serialization is not possible.
@@ -807,6 +1017,19 @@ func completeLeafValue(returnType Leaf, result interface{}) interface{} {
 	return serializedResult
 }
 
+// completeScalarValue complete a Scalar value by serializing to a valid value, raising a field error
+// with the scalar's own message if serialization fails.
+func completeScalarValue(returnType *Scalar, fieldASTs []*ast.Field, result interface{}) interface{} {
+	serializedResult, err := returnType.SerializeWithError(result)
+	if err != nil {
+		panic(gqlerrors.FormatError(NewLocatedError(err, FieldASTsToNodeASTs(fieldASTs))))
+	}
+	if isNullish(serializedResult) {
+		return nil
+	}
+	return serializedResult
+}
+
 // completeListValue complete a list value by completing each item in the list with the inner type
 func completeListValue(eCtx *ExecutionContext, returnType *List, fieldASTs []*ast.Field, info ResolveInfo, result interface{}) interface{} {
 	resultVal := reflect.ValueOf(result)
@@ -824,6 +1047,22 @@ func completeListValue(eCtx *ExecutionContext, returnType *List, fieldASTs []*as
 	}
 
 	itemType := returnType.OfType
+
+	// Stream the items after the initial ones, unless the list is an item of
+	// an outer list, the directive applying to the outermost list only.
+	if eCtx.incremental != nil && info.Path != nil && !isListItemPath(info.Path) {
+		if label, initialCount, ok := streamDirective(eCtx, fieldASTs[0]); ok && initialCount < resultVal.Len() {
+			completedResults := make([]interface{}, initialCount)
+			for i := 0; i < initialCount; i++ {
+				itemInfo := info
+				itemInfo.Path = info.Path.WithKey(i)
+				completedResults[i] = completeValueCatchingError(eCtx, itemType, fieldASTs, itemInfo, resultVal.Index(i).Interface())
+			}
+			eCtx.incremental.streamItems(eCtx, itemType, fieldASTs, info, resultVal, initialCount, label)
+			return completedResults
+		}
+	}
+
 	completedResults := make([]interface{}, resultVal.Len())
 
 	if returnType.Parallel {
@@ -840,7 +1079,9 @@ func completeListValue(eCtx *ExecutionContext, returnType *List, fieldASTs []*as
 					wg.Done()
 				}()
 				val := resultVal.Index(j).Interface()
-				completedResults[j] = completeValueCatchingError(eCtx, itemType, fieldASTs, info, val)
+				itemInfo := info
+				itemInfo.Path = info.Path.WithKey(j)
+				completedResults[j] = completeValueCatchingError(eCtx, itemType, fieldASTs, itemInfo, val)
 			}(i)
 		}
 
@@ -856,7 +1097,9 @@ func completeListValue(eCtx *ExecutionContext, returnType *List, fieldASTs []*as
 		// resolve list elements serially
 		for i := 0; i < resultVal.Len(); i++ {
 			val := resultVal.Index(i).Interface()
-			completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, info, val)
+			itemInfo := info
+			itemInfo.Path = info.Path.WithKey(i)
+			completedItem := completeValueCatchingError(eCtx, itemType, fieldASTs, itemInfo, val)
 			completedResults[i] = completedItem
 		}
 	}
@@ -864,6 +1107,12 @@ func completeListValue(eCtx *ExecutionContext, returnType *List, fieldASTs []*as
 	return completedResults
 }
 
+// isListItemPath determines if the path is the path of an item of a list.
+func isListItemPath(path *ResponsePath) bool {
+	_, ok := path.Key.(int)
+	return ok
+}
+
 // defaultResolveTypeFn If a resolveType function is not given, then a default resolve behavior is
 // used which tests each possible type for the abstract type by calling
 // isTypeOf for the object being coerced, returning the first type that matches.
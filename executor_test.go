@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/graphql-go/graphql"
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/printer"
 	"github.com/graphql-go/graphql/testutil"
 	"golang.org/x/net/context"
 )
@@ -1592,3 +1595,128 @@ func TestMutation_ExecutionDoesNotAddErrorsFromFieldResolveFn(t *testing.T) {
 		t.Fatalf("wrong result, unexpected errors: %+v", result.Errors)
 	}
 }
+
+var evenScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name: "Even",
+	SerializeWithError: func(value interface{}) (interface{}, error) {
+		if v, ok := value.(int); ok && v%2 == 0 {
+			return v, nil
+		}
+		return nil, fmt.Errorf("Even cannot represent value: %v", value)
+	},
+	ParseValueWithError: func(value interface{}) (interface{}, error) {
+		if v, ok := value.(float64); ok && int(v)%2 == 0 {
+			return int(v), nil
+		}
+		return nil, fmt.Errorf("Even cannot represent value: %v", value)
+	},
+	ParseLiteralWithError: func(valueAST ast.Value) (interface{}, error) {
+		if v, ok := valueAST.(*ast.IntValue); ok {
+			if i, err := strconv.Atoi(v.Value); err == nil && i%2 == 0 {
+				return i, nil
+			}
+		}
+		return nil, fmt.Errorf("Even cannot represent literal: %v", printer.Print(valueAST))
+	},
+})
+
+func evenSchema(t *testing.T) graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"odd": &graphql.Field{
+					Type: evenScalar,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return 3, nil
+					},
+				},
+				"echo": &graphql.Field{
+					Type: evenScalar,
+					Args: graphql.FieldConfigArgument{
+						"value": &graphql.ArgumentConfig{
+							Type: evenScalar,
+						},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Args["value"], nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+	return schema
+}
+
+func TestQuery_ExecutionAddsErrorsFromScalarSerialize(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        evenSchema(t),
+		RequestString: "{ odd }",
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"odd": nil,
+		},
+		Errors: []gqlerrors.FormattedError{
+			{
+				Message: "Even cannot represent value: 3",
+				Locations: []location.SourceLocation{
+					{Line: 1, Column: 3},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestQuery_ValidationAddsErrorsFromScalarParseLiteral(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        evenSchema(t),
+		RequestString: "{ echo(value: 3) }",
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("wrong result, expected one error, got: %v", result.Errors)
+	}
+	expected := "Argument \"value\" has invalid value 3.\nEven cannot represent literal: 3"
+	if result.Errors[0].Message != expected {
+		t.Fatalf("wrong result, unexpected error, got: %v, expected: %v", result.Errors[0].Message, expected)
+	}
+}
+
+func TestQuery_VariablesAddErrorsFromScalarParseValue(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        evenSchema(t),
+		RequestString: "query q($v: Even) { echo(value: $v) }",
+		VariableValues: map[string]interface{}{
+			"v": float64(5),
+		},
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("wrong result, expected one error, got: %v", result.Errors)
+	}
+	expected := "Variable \"$v\" got invalid value 5.\nEven cannot represent value: 5"
+	if result.Errors[0].Message != expected {
+		t.Fatalf("wrong result, unexpected error, got: %v, expected: %v", result.Errors[0].Message, expected)
+	}
+
+	result = graphql.Do(graphql.Params{
+		Schema:        evenSchema(t),
+		RequestString: "query q($v: Even) { echo(value: $v) }",
+		VariableValues: map[string]interface{}{
+			"v": float64(4),
+		},
+	})
+	expectedResult := &graphql.Result{
+		Data: map[string]interface{}{
+			"echo": 4,
+		},
+	}
+	if !reflect.DeepEqual(expectedResult, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expectedResult, result))
+	}
+}
@@ -7,8 +7,16 @@ import (
 )
 
 type FormattedError struct {
-	Message   string                    `json:"message"`
-	Locations []location.SourceLocation `json:"locations"`
+	Message    string                    `json:"message"`
+	Locations  []location.SourceLocation `json:"locations"`
+	Extensions map[string]interface{}    `json:"extensions,omitempty"`
+}
+
+// ExtendedError is implemented by errors providing additional information,
+// such as an error code, reported in the extensions of their formatted error.
+type ExtendedError interface {
+	error
+	Extensions() map[string]interface{}
 }
 
 func (g FormattedError) Error() string {
@@ -26,22 +34,33 @@ func FormatError(err error) FormattedError {
 		return err
 	case *Error:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: err.Locations,
+			Message:    err.Error(),
+			Locations:  err.Locations,
+			Extensions: extensions(err.OriginalError),
 		}
 	case Error:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: err.Locations,
+			Message:    err.Error(),
+			Locations:  err.Locations,
+			Extensions: extensions(err.OriginalError),
 		}
 	default:
 		return FormattedError{
-			Message:   err.Error(),
-			Locations: []location.SourceLocation{},
+			Message:    err.Error(),
+			Locations:  []location.SourceLocation{},
+			Extensions: extensions(err),
 		}
 	}
 }
 
+// extensions returns the extensions of err if it is an ExtendedError.
+func extensions(err error) map[string]interface{} {
+	if err, ok := err.(ExtendedError); ok {
+		return err.Extensions()
+	}
+	return nil
+}
+
 func FormatErrors(errs ...error) []FormattedError {
 	formattedErrors := []FormattedError{}
 	for _, err := range errs {
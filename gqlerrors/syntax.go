@@ -2,7 +2,6 @@ package gqlerrors
 
 import (
 	"fmt"
-	"regexp"
 
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/location"
@@ -13,7 +12,7 @@ import (
 func NewSyntaxError(s *source.Source, position int, description string) *Error {
 	l := location.GetLocation(s, position)
 	return NewError(
-		fmt.Sprintf("Syntax Error %s (%d:%d) %s\n\n%s", s.Name, l.Line, l.Column, description, highlightSourceAtLocation(s, l)),
+		fmt.Sprintf("Syntax Error %s (%d:%d) %s\n\n%s", s.Name, l.Line, l.Column, description, highlightSourceAtLocation(s, position)),
 		[]ast.Node{},
 		"",
 		s,
@@ -38,24 +37,25 @@ func printLine(str string) string {
 	}
 	return fmt.Sprintf(`%s`, strings.Join(strSlice, ""))
 }
-func highlightSourceAtLocation(s *source.Source, l location.SourceLocation) string {
-	line := l.Line
+
+// The caret is placed by characters, whatever the column unit of the source.
+func highlightSourceAtLocation(s *source.Source, position int) string {
+	line, column := s.LocationIn(position, source.Characters)
 	prevLineNum := fmt.Sprintf("%d", (line - 1))
 	lineNum := fmt.Sprintf("%d", line)
 	nextLineNum := fmt.Sprintf("%d", (line + 1))
 	padLen := len(nextLineNum)
-	lines := regexp.MustCompile("\r\n|[\n\r]").Split(s.Body, -1)
 	var highlight string
 	if line >= 2 {
-		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, prevLineNum), printLine(lines[line-2]))
+		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, prevLineNum), printLine(s.Line(line-1)))
 	}
-	highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, lineNum), printLine(lines[line-1]))
-	for i := 1; i < (2 + padLen + l.Column); i++ {
+	highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, lineNum), printLine(s.Line(line)))
+	for i := 1; i < (2 + padLen + column); i++ {
 		highlight += " "
 	}
 	highlight += "^\n"
-	if line < len(lines) {
-		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, nextLineNum), printLine(lines[line]))
+	if line < s.LineCount() {
+		highlight += fmt.Sprintf("%s: %s\n", lpad(padLen, nextLineNum), printLine(s.Line(line+1)))
 	}
 	return highlight
 }
@@ -2,6 +2,7 @@ package graphql
 
 import (
 	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/source"
 	"golang.org/x/net/context"
@@ -30,33 +31,80 @@ type Params struct {
 	// Context may be provided to pass application-specific per-request
 	// information to resolve functions.
 	Context context.Context
+
+	// DocumentCache may be provided to reuse the parsed and validated document
+	// of a requestString seen before, skipping parsing and validation.
+	DocumentCache *DocumentCache
+
+	// ParseLimits may be provided to reject request strings too large or too
+	// deeply nested before they are parsed in full.
+	ParseLimits ParseLimits
+}
+
+// ParseLimits guards against malicious request strings; zero means no limit.
+type ParseLimits struct {
+	// MaxTokens is the maximum number of tokens of a request string.
+	MaxTokens int
+	// MaxDepth is the maximum nesting of its selection sets, lists, objects
+	// and list types.
+	MaxDepth int
+	// MaxBodySize is the maximum size of a request string in bytes.
+	MaxBodySize int
 }
 
 func Do(p Params) *Result {
+	AST, result := ParseAndValidate(p)
+	if result != nil {
+		return result
+	}
+
+	return Execute(ExecuteParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           AST,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		Context:       p.Context,
+	})
+}
+
+// ParseAndValidate returns the validated document of the request string,
+// through the document cache if any, or a result holding the errors
+// preventing its execution. Its document may then be executed by Execute,
+// ExecuteSubscription or ExecuteIncremental with the same parameters.
+func ParseAndValidate(p Params) (*ast.Document, *Result) {
+	key := documentCacheKey{p.Schema.id, p.RequestString, p.ParseLimits}
+	if p.DocumentCache != nil {
+		if AST, ok := p.DocumentCache.get(key); ok {
+			return AST, nil
+		}
+	}
+
 	source := source.NewSource(&source.Source{
 		Body: p.RequestString,
 		Name: "GraphQL request",
 	})
-	AST, err := parser.Parse(parser.ParseParams{Source: source})
+	AST, err := parser.Parse(parser.ParseParams{
+		Source: source,
+		Options: parser.ParseOptions{
+			MaxTokens:   p.ParseLimits.MaxTokens,
+			MaxDepth:    p.ParseLimits.MaxDepth,
+			MaxBodySize: p.ParseLimits.MaxBodySize,
+		},
+	})
 	if err != nil {
-		return &Result{
+		return nil, &Result{
 			Errors: gqlerrors.FormatErrors(err),
 		}
 	}
 	validationResult := ValidateDocument(&p.Schema, AST, nil)
-
 	if !validationResult.IsValid {
-		return &Result{
+		return nil, &Result{
 			Errors: validationResult.Errors,
 		}
 	}
-
-	return Execute(ExecuteParams{
-		Schema:        p.Schema,
-		Root:          p.RootObject,
-		AST:           AST,
-		OperationName: p.OperationName,
-		Args:          p.VariableValues,
-		Context:       p.Context,
-	})
+	if p.DocumentCache != nil {
+		p.DocumentCache.add(key, AST)
+	}
+	return AST, nil
 }
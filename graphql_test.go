@@ -2,6 +2,7 @@ package graphql_test
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/graphql-go/graphql"
@@ -335,3 +336,32 @@ func TestThreadsContextFromParamsThrough(t *testing.T) {
 	}
 
 }
+
+func TestRejectsRequestStringsExceedingParseLimits(t *testing.T) {
+	tests := []struct {
+		limits   graphql.ParseLimits
+		expected string
+	}{
+		{graphql.ParseLimits{MaxTokens: 4}, "Document contains more than 4 tokens. Parsing aborted."},
+		{graphql.ParseLimits{MaxDepth: 1}, "Document exceeds the maximum nesting depth of 1."},
+		{graphql.ParseLimits{MaxBodySize: 8}, "Document exceeds the maximum size of 8 bytes."},
+	}
+	for _, test := range tests {
+		result := graphql.Do(graphql.Params{
+			Schema:        testutil.StarWarsSchema,
+			RequestString: `{ hero { name } }`,
+			ParseLimits:   test.limits,
+		})
+		if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, test.expected) {
+			t.Fatalf("wrong result, expected error %q, got: %v", test.expected, result.Errors)
+		}
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        testutil.StarWarsSchema,
+		RequestString: `{ hero { name } }`,
+		ParseLimits:   graphql.ParseLimits{MaxTokens: 6, MaxDepth: 2, MaxBodySize: 17},
+	})
+	if result.HasErrors() {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+}
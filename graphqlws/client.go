@@ -0,0 +1,99 @@
+package graphqlws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
+
+// Client is a minimal graphql-transport-ws client, suited to testing a
+// Server, such as one started with net/http/httptest.
+//
+// A Client is not safe for concurrent use.
+type Client struct {
+	ws *websocket.Conn
+}
+
+// Dial connects to the server at the given ws:// or wss:// URL.
+func Dial(url string, header http.Header) (*Client, error) {
+	dialer := websocket.Dialer{
+		Subprotocols: []string{Protocol},
+	}
+	ws, _, err := dialer.Dial(url, header)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{ws}, nil
+}
+
+// Init sends the connection_init message with the given payload and waits
+// for the server to acknowledge it.
+func (c *Client) Init(payload interface{}) error {
+	if err := c.Send("", MessageTypeConnectionInit, payload); err != nil {
+		return err
+	}
+	for {
+		msg, err := c.ReadMessage()
+		if err != nil {
+			return err
+		}
+		switch msg.Type {
+		case MessageTypeConnectionAck:
+			return nil
+		case MessageTypePing, MessageTypePong:
+		default:
+			return fmt.Errorf("Unexpected message of type %v received", msg.Type)
+		}
+	}
+}
+
+// Subscribe starts an operation identified by id. Its results are read with
+// ReadMessage.
+func (c *Client) Subscribe(id string, payload SubscribePayload) error {
+	return c.Send(id, MessageTypeSubscribe, payload)
+}
+
+// Complete stops the operation identified by id.
+func (c *Client) Complete(id string) error {
+	return c.Send(id, MessageTypeComplete, nil)
+}
+
+// Send sends a message of the given type, carrying the JSON encoding of payload.
+func (c *Client) Send(id string, messageType string, payload interface{}) error {
+	msg, err := newMessage(id, messageType, payload)
+	if err != nil {
+		return err
+	}
+	return c.ws.WriteJSON(msg)
+}
+
+// ReadMessage returns the next message sent by the server, answering ping
+// messages on the way. Once the server closes the connection, the error is a
+// *websocket.CloseError holding the close code.
+func (c *Client) ReadMessage() (*Message, error) {
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+		var msg Message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, err
+		}
+		if msg.Type == MessageTypePing {
+			if err := c.Send("", MessageTypePong, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return &msg, nil
+	}
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	c.ws.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	return c.ws.Close()
+}
@@ -0,0 +1,68 @@
+// Package graphqlws serves GraphQL operations, subscriptions in particular,
+// over WebSocket using the graphql-transport-ws protocol.
+//
+// See https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+package graphqlws
+
+import (
+	"encoding/json"
+)
+
+// Protocol is the WebSocket subprotocol implemented by the package.
+const Protocol = "graphql-transport-ws"
+
+// Message types of the graphql-transport-ws protocol.
+const (
+	MessageTypeConnectionInit = "connection_init"
+	MessageTypeConnectionAck  = "connection_ack"
+	MessageTypePing           = "ping"
+	MessageTypePong           = "pong"
+	MessageTypeSubscribe      = "subscribe"
+	MessageTypeNext           = "next"
+	MessageTypeError          = "error"
+	MessageTypeComplete       = "complete"
+)
+
+// Close codes of the graphql-transport-ws protocol.
+const (
+	CloseInternalServerError          = 4500
+	CloseBadRequest                   = 4400
+	CloseUnauthorized                 = 4401
+	CloseForbidden                    = 4403
+	CloseSubprotocolNotAcceptable     = 4406
+	CloseConnectionInitTimeout        = 4408
+	CloseSubscriberAlreadyExists      = 4409
+	CloseTooManyInitialisationRequest = 4429
+)
+
+// Message is a message exchanged by the client and the server.
+type Message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// SubscribePayload is the payload of a subscribe message, describing the
+// operation to execute.
+type SubscribePayload struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Extensions    map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// newMessage returns a message carrying the JSON encoding of payload, if any.
+func newMessage(id string, messageType string, payload interface{}) (*Message, error) {
+	msg := &Message{
+		ID:   id,
+		Type: messageType,
+	}
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		msg.Payload = raw
+	}
+	return msg, nil
+}
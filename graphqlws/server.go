@@ -0,0 +1,397 @@
+package graphqlws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"golang.org/x/net/context"
+)
+
+// DefaultConnectionInitTimeout is the time a client is given to send its
+// connection_init message when none is configured.
+const DefaultConnectionInitTimeout = 3 * time.Second
+
+// DefaultMaxMessageSize is the maximum size of the messages of clients when
+// none is configured.
+const DefaultMaxMessageSize = 1 << 20
+
+// ContextFn returns the context of the connection opened by a request.
+type ContextFn func(r *http.Request) context.Context
+
+// ConnectionInitFn is called with the payload of the connection_init message
+// of a client, and returns the context its operations are executed with.
+// Returning an error refuses the connection with the Forbidden close code.
+type ConnectionInitFn func(ctx context.Context, payload map[string]interface{}) (context.Context, error)
+
+// RootObjectFn returns the root object an operation is executed with.
+type RootObjectFn func(ctx context.Context, r *http.Request) map[string]interface{}
+
+// Config holds the settings of a Server.
+type Config struct {
+	Schema *graphql.Schema
+
+	// ContextFn, if provided, returns the context of each connection.
+	// Defaults to context.Background().
+	ContextFn ContextFn
+
+	// OnConnectionInit, if provided, authorizes connections, typically using
+	// credentials sent by the client in the connection_init payload.
+	OnConnectionInit ConnectionInitFn
+
+	// RootObjectFn, if provided, returns the root object of each operation.
+	RootObjectFn RootObjectFn
+
+	// ConnectionInitTimeout is the time a client is given to send its
+	// connection_init message. Defaults to DefaultConnectionInitTimeout.
+	ConnectionInitTimeout time.Duration
+
+	// KeepAlive, if positive, is the interval at which ping messages are sent
+	// to clients.
+	KeepAlive time.Duration
+
+	// CheckOrigin, if provided, determines if a request may be upgraded.
+	// Defaults to rejecting cross-origin requests.
+	CheckOrigin func(r *http.Request) bool
+
+	// DocumentCache, if provided, caches the parsed and validated documents of
+	// the operations executed by the server.
+	DocumentCache *graphql.DocumentCache
+
+	// ParseLimits, if provided, limits the size and nesting of the operations
+	// parsed by the server.
+	ParseLimits graphql.ParseLimits
+
+	// MaxMessageSize is the maximum size in bytes of a message of a client,
+	// the connection being closed once a message exceeds it.
+	// Defaults to DefaultMaxMessageSize.
+	MaxMessageSize int64
+}
+
+// Server serves GraphQL operations over WebSocket connections.
+type Server struct {
+	Schema                *graphql.Schema
+	contextFn             ContextFn
+	onConnectionInit      ConnectionInitFn
+	rootObjectFn          RootObjectFn
+	connectionInitTimeout time.Duration
+	keepAlive             time.Duration
+	documentCache         *graphql.DocumentCache
+	parseLimits           graphql.ParseLimits
+	maxMessageSize        int64
+	upgrader              websocket.Upgrader
+}
+
+// New returns a Server for the given configuration.
+func New(p *Config) *Server {
+	if p == nil || p.Schema == nil {
+		panic("undefined GraphQL schema")
+	}
+	connectionInitTimeout := p.ConnectionInitTimeout
+	if connectionInitTimeout <= 0 {
+		connectionInitTimeout = DefaultConnectionInitTimeout
+	}
+	maxMessageSize := p.MaxMessageSize
+	if maxMessageSize <= 0 {
+		maxMessageSize = DefaultMaxMessageSize
+	}
+	return &Server{
+		Schema:                p.Schema,
+		contextFn:             p.ContextFn,
+		onConnectionInit:      p.OnConnectionInit,
+		rootObjectFn:          p.RootObjectFn,
+		connectionInitTimeout: connectionInitTimeout,
+		keepAlive:             p.KeepAlive,
+		documentCache:         p.DocumentCache,
+		parseLimits:           p.ParseLimits,
+		maxMessageSize:        maxMessageSize,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{Protocol},
+			CheckOrigin:  p.CheckOrigin,
+		},
+	}
+}
+
+// ServeHTTP implements http.Handler, upgrading the request to a WebSocket
+// connection and serving it until either side closes it.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has already answered the request
+		return
+	}
+	ws.SetReadLimit(s.maxMessageSize)
+
+	ctx := context.Background()
+	if s.contextFn != nil {
+		ctx = s.contextFn(r)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	c := &conn{
+		server:     s,
+		ws:         ws,
+		request:    r,
+		ctx:        ctx,
+		operations: map[string]context.CancelFunc{},
+	}
+	if ws.Subprotocol() != Protocol {
+		c.close(CloseSubprotocolNotAcceptable, "Subprotocol not acceptable")
+		return
+	}
+	c.serve()
+}
+
+// conn is a connection of a client.
+type conn struct {
+	server  *Server
+	ws      *websocket.Conn
+	request *http.Request
+
+	// writeMutex serializes writes, the connection supporting a single writer.
+	writeMutex sync.Mutex
+
+	// mutex protects the fields below.
+	mutex        sync.Mutex
+	ctx          context.Context
+	initReceived bool
+	acknowledged bool
+	closed       bool
+	operations   map[string]context.CancelFunc
+
+	// goroutines tracks the goroutines of the connection, which serve waits
+	// for before returning.
+	goroutines sync.WaitGroup
+}
+
+var errInvalidMessage = errors.New("Invalid message received")
+
+// serve reads the messages of the client until the connection is closed,
+// and returns once its operations are done.
+func (c *conn) serve() {
+	defer c.goroutines.Wait()
+
+	initTimer := time.AfterFunc(c.server.connectionInitTimeout, func() {
+		c.mutex.Lock()
+		acknowledged := c.acknowledged
+		c.mutex.Unlock()
+		if !acknowledged {
+			c.close(CloseConnectionInitTimeout, "Connection initialisation timeout")
+		}
+	})
+	defer initTimer.Stop()
+
+	if c.server.keepAlive > 0 {
+		ticker := time.NewTicker(c.server.keepAlive)
+		defer ticker.Stop()
+		done := make(chan struct{})
+		defer close(done)
+		c.goroutines.Add(1)
+		go func() {
+			defer c.goroutines.Done()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					if c.send("", MessageTypePing, nil) != nil {
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	defer c.cancelOperations()
+	for {
+		_, data, err := c.ws.ReadMessage()
+		if err != nil {
+			c.ws.Close()
+			return
+		}
+		var msg Message
+		if err := json.Unmarshal(data, &msg); err != nil || msg.Type == "" {
+			c.close(CloseBadRequest, errInvalidMessage.Error())
+			return
+		}
+		if code, reason := c.handle(&msg); code != 0 {
+			c.close(code, reason)
+			return
+		}
+	}
+}
+
+// handle processes a message, returning the code and reason to close the
+// connection with if the message violates the protocol.
+func (c *conn) handle(msg *Message) (int, string) {
+	switch msg.Type {
+	case MessageTypeConnectionInit:
+		return c.handleConnectionInit(msg)
+	case MessageTypePing:
+		c.send("", MessageTypePong, nil)
+	case MessageTypePong:
+	case MessageTypeSubscribe:
+		return c.handleSubscribe(msg)
+	case MessageTypeComplete:
+		c.mutex.Lock()
+		if cancel, ok := c.operations[msg.ID]; ok {
+			delete(c.operations, msg.ID)
+			cancel()
+		}
+		c.mutex.Unlock()
+	default:
+		return CloseBadRequest, fmt.Sprintf("Unexpected message of type %v received", msg.Type)
+	}
+	return 0, ""
+}
+
+func (c *conn) handleConnectionInit(msg *Message) (int, string) {
+	c.mutex.Lock()
+	initReceived := c.initReceived
+	c.initReceived = true
+	ctx := c.ctx
+	c.mutex.Unlock()
+	if initReceived {
+		return CloseTooManyInitialisationRequest, "Too many initialisation requests"
+	}
+
+	if c.server.onConnectionInit != nil {
+		payload := map[string]interface{}{}
+		if len(msg.Payload) > 0 && string(msg.Payload) != "null" {
+			if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+				return CloseBadRequest, errInvalidMessage.Error()
+			}
+		}
+		var err error
+		ctx, err = c.server.onConnectionInit(ctx, payload)
+		if err != nil {
+			return CloseForbidden, "Forbidden"
+		}
+	}
+
+	c.mutex.Lock()
+	c.ctx = ctx
+	c.acknowledged = true
+	c.mutex.Unlock()
+	c.send("", MessageTypeConnectionAck, nil)
+	return 0, ""
+}
+
+func (c *conn) handleSubscribe(msg *Message) (int, string) {
+	var payload SubscribePayload
+	if msg.ID == "" || json.Unmarshal(msg.Payload, &payload) != nil {
+		return CloseBadRequest, errInvalidMessage.Error()
+	}
+
+	c.mutex.Lock()
+	if !c.acknowledged {
+		c.mutex.Unlock()
+		return CloseUnauthorized, "Unauthorized"
+	}
+	if _, ok := c.operations[msg.ID]; ok {
+		c.mutex.Unlock()
+		return CloseSubscriberAlreadyExists, fmt.Sprintf("Subscriber for %v already exists", msg.ID)
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.operations[msg.ID] = cancel
+	c.mutex.Unlock()
+
+	c.goroutines.Add(1)
+	go func() {
+		defer c.goroutines.Done()
+		c.execute(ctx, msg.ID, &payload)
+	}()
+	return 0, ""
+}
+
+// execute runs an operation, sending its results until it completes or is
+// completed by the client.
+func (c *conn) execute(ctx context.Context, id string, payload *SubscribePayload) {
+	var rootObject map[string]interface{}
+	if c.server.rootObjectFn != nil {
+		rootObject = c.server.rootObjectFn(ctx, c.request)
+	}
+	results, result := graphql.Subscribe(graphql.Params{
+		Schema:         *c.server.Schema,
+		RequestString:  payload.Query,
+		RootObject:     rootObject,
+		VariableValues: payload.Variables,
+		OperationName:  payload.OperationName,
+		Context:        ctx,
+		DocumentCache:  c.server.documentCache,
+		ParseLimits:    c.server.parseLimits,
+	})
+
+	if result != nil {
+		// the operation could not be executed
+		if c.finish(id) {
+			c.send(id, MessageTypeError, result.Errors)
+		}
+		return
+	}
+
+	for result := range results {
+		if ctx.Err() != nil {
+			break
+		}
+		c.send(id, MessageTypeNext, result)
+	}
+	if c.finish(id) {
+		c.send(id, MessageTypeComplete, nil)
+	}
+}
+
+// finish forgets an operation, reporting whether it was still running,
+// that is, not completed by the client.
+func (c *conn) finish(id string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cancel, ok := c.operations[id]
+	if ok {
+		delete(c.operations, id)
+		cancel()
+	}
+	return ok
+}
+
+func (c *conn) cancelOperations() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for id, cancel := range c.operations {
+		delete(c.operations, id)
+		cancel()
+	}
+}
+
+func (c *conn) send(id string, messageType string, payload interface{}) error {
+	msg, err := newMessage(id, messageType, payload)
+	if err != nil {
+		return err
+	}
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	return c.ws.WriteJSON(msg)
+}
+
+// close closes the connection with the given code and reason.
+func (c *conn) close(code int, reason string) {
+	c.mutex.Lock()
+	closed := c.closed
+	c.closed = true
+	c.mutex.Unlock()
+	if closed {
+		return
+	}
+
+	c.writeMutex.Lock()
+	c.ws.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	c.writeMutex.Unlock()
+	c.ws.Close()
+}
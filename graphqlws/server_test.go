@@ -0,0 +1,324 @@
+package graphqlws_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/graphqlws"
+	"golang.org/x/net/context"
+)
+
+type ctxKey string
+
+var schema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Context.Value(ctxKey("user")), nil
+				},
+			},
+		},
+	}),
+	Subscription: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"counter": &graphql.Field{
+				Type: graphql.Int,
+				Args: graphql.FieldConfigArgument{
+					"to": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+				},
+				Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+					events := make(chan interface{})
+					go func() {
+						defer close(events)
+						for i := 1; i <= p.Args["to"].(int); i++ {
+							select {
+							case events <- i:
+							case <-p.Context.Done():
+								return
+							}
+						}
+					}()
+					return events, nil
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	}),
+})
+
+func newServer(t *testing.T, config *graphqlws.Config) (*httptest.Server, string) {
+	if config.Schema == nil {
+		config.Schema = &schema
+	}
+	server := httptest.NewServer(graphqlws.New(config))
+	return server, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dial(t *testing.T, url string) *graphqlws.Client {
+	client, err := graphqlws.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("unexpected error dialing server: %v", err)
+	}
+	return client
+}
+
+func readMessage(t *testing.T, client *graphqlws.Client) *graphqlws.Message {
+	msg, err := client.ReadMessage()
+	if err != nil {
+		t.Fatalf("unexpected error reading message: %v", err)
+	}
+	return msg
+}
+
+func expectMessage(t *testing.T, client *graphqlws.Client, id string, messageType string, payload string) {
+	msg := readMessage(t, client)
+	if msg.ID != id || msg.Type != messageType {
+		t.Fatalf("expected %v message for %q, got %v message for %q: %s", messageType, id, msg.Type, msg.ID, msg.Payload)
+	}
+	if payload == "" {
+		return
+	}
+	var expected, actual interface{}
+	json.Unmarshal([]byte(payload), &expected)
+	json.Unmarshal(msg.Payload, &actual)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected payload %v, got %s", payload, msg.Payload)
+	}
+}
+
+func expectClose(t *testing.T, client *graphqlws.Client, code int) {
+	for {
+		_, err := client.ReadMessage()
+		if err == nil {
+			continue
+		}
+		closeErr, ok := err.(*websocket.CloseError)
+		if !ok || closeErr.Code != code {
+			t.Fatalf("expected connection to be closed with code %v, got: %v", code, err)
+		}
+		return
+	}
+}
+
+func TestServer_ExecutesQuery(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{})
+	defer server.Close()
+	client := dial(t, url)
+	defer client.Close()
+
+	if err := client.Init(nil); err != nil {
+		t.Fatalf("unexpected error initializing connection: %v", err)
+	}
+	client.Subscribe("1", graphqlws.SubscribePayload{Query: "{ user }"})
+	expectMessage(t, client, "1", graphqlws.MessageTypeNext, `{"data": {"user": null}}`)
+	expectMessage(t, client, "1", graphqlws.MessageTypeComplete, "")
+}
+
+func TestServer_StreamsSubscriptionEvents(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{})
+	defer server.Close()
+	client := dial(t, url)
+	defer client.Close()
+
+	client.Init(nil)
+	client.Subscribe("counter", graphqlws.SubscribePayload{
+		Query:     "subscription Count($to: Int!) { counter(to: $to) }",
+		Variables: map[string]interface{}{"to": 3},
+	})
+	expectMessage(t, client, "counter", graphqlws.MessageTypeNext, `{"data": {"counter": 1}}`)
+	expectMessage(t, client, "counter", graphqlws.MessageTypeNext, `{"data": {"counter": 2}}`)
+	expectMessage(t, client, "counter", graphqlws.MessageTypeNext, `{"data": {"counter": 3}}`)
+	expectMessage(t, client, "counter", graphqlws.MessageTypeComplete, "")
+}
+
+func TestServer_StopsSubscriptionCompletedByClient(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{})
+	defer server.Close()
+	client := dial(t, url)
+	defer client.Close()
+
+	client.Init(nil)
+	client.Subscribe("counter", graphqlws.SubscribePayload{
+		Query: "subscription { counter(to: 1000000) }",
+	})
+	expectMessage(t, client, "counter", graphqlws.MessageTypeNext, `{"data": {"counter": 1}}`)
+	client.Complete("counter")
+
+	// the id is free again once the server has processed the completion
+	client.Send("", graphqlws.MessageTypePing, nil)
+	for msg := readMessage(t, client); msg.Type != graphqlws.MessageTypePong; msg = readMessage(t, client) {
+		if msg.Type != graphqlws.MessageTypeNext {
+			t.Fatalf("unexpected %v message", msg.Type)
+		}
+	}
+	client.Subscribe("counter", graphqlws.SubscribePayload{
+		Query: "subscription { counter(to: 1) }",
+	})
+	for msg := readMessage(t, client); msg.Type != graphqlws.MessageTypeComplete; msg = readMessage(t, client) {
+		if msg.Type != graphqlws.MessageTypeNext {
+			t.Fatalf("unexpected %v message", msg.Type)
+		}
+	}
+}
+
+func TestServer_StopsGoroutinesOnClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	server, url := newServer(t, &graphqlws.Config{KeepAlive: time.Hour})
+	client := dial(t, url)
+
+	client.Init(nil)
+	client.Subscribe("counter", graphqlws.SubscribePayload{
+		Query: "subscription { counter(to: 1000000) }",
+	})
+	expectMessage(t, client, "counter", graphqlws.MessageTypeNext, `{"data": {"counter": 1}}`)
+	client.Close()
+	server.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected goroutines to stop, %v running, %v before", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestServer_ReportsInvalidOperations(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{})
+	defer server.Close()
+	client := dial(t, url)
+	defer client.Close()
+
+	client.Init(nil)
+	client.Subscribe("1", graphqlws.SubscribePayload{Query: "{ unknown }"})
+	msg := readMessage(t, client)
+	if msg.ID != "1" || msg.Type != graphqlws.MessageTypeError {
+		t.Fatalf("expected error message, got %v", msg.Type)
+	}
+	var errs []map[string]interface{}
+	if err := json.Unmarshal(msg.Payload, &errs); err != nil || len(errs) != 1 {
+		t.Fatalf("expected a list of one error, got %s", msg.Payload)
+	}
+}
+
+func TestServer_PassesConnectionInitPayloadToContext(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{
+		OnConnectionInit: func(ctx context.Context, payload map[string]interface{}) (context.Context, error) {
+			token, _ := payload["token"].(string)
+			if token == "" {
+				return nil, errors.New("missing token")
+			}
+			return context.WithValue(ctx, ctxKey("user"), token), nil
+		},
+	})
+	defer server.Close()
+
+	client := dial(t, url)
+	defer client.Close()
+	if err := client.Init(map[string]interface{}{"token": "luke"}); err != nil {
+		t.Fatalf("unexpected error initializing connection: %v", err)
+	}
+	client.Subscribe("1", graphqlws.SubscribePayload{Query: "{ user }"})
+	expectMessage(t, client, "1", graphqlws.MessageTypeNext, `{"data": {"user": "luke"}}`)
+
+	refused := dial(t, url)
+	defer refused.Close()
+	refused.Send("", graphqlws.MessageTypeConnectionInit, nil)
+	expectClose(t, refused, graphqlws.CloseForbidden)
+}
+
+func TestServer_AnswersPings(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{})
+	defer server.Close()
+	client := dial(t, url)
+	defer client.Close()
+
+	client.Send("", graphqlws.MessageTypePing, nil)
+	expectMessage(t, client, "", graphqlws.MessageTypePong, "")
+}
+
+func TestServer_ClosesConnectionOnProtocolViolations(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{
+		ConnectionInitTimeout: 50 * time.Millisecond,
+	})
+	defer server.Close()
+
+	tests := []struct {
+		Name string
+		Send func(client *graphqlws.Client)
+		Code int
+	}{
+		{"subscribe before init", func(client *graphqlws.Client) {
+			client.Subscribe("1", graphqlws.SubscribePayload{Query: "{ user }"})
+		}, graphqlws.CloseUnauthorized},
+		{"init twice", func(client *graphqlws.Client) {
+			client.Init(nil)
+			client.Send("", graphqlws.MessageTypeConnectionInit, nil)
+		}, graphqlws.CloseTooManyInitialisationRequest},
+		{"duplicate id", func(client *graphqlws.Client) {
+			client.Init(nil)
+			client.Subscribe("1", graphqlws.SubscribePayload{Query: "subscription { counter(to: 1000000) }"})
+			client.Subscribe("1", graphqlws.SubscribePayload{Query: "{ user }"})
+		}, graphqlws.CloseSubscriberAlreadyExists},
+		{"unknown message type", func(client *graphqlws.Client) {
+			client.Send("", "start", nil)
+		}, graphqlws.CloseBadRequest},
+		{"subscribe without id", func(client *graphqlws.Client) {
+			client.Init(nil)
+			client.Subscribe("", graphqlws.SubscribePayload{Query: "{ user }"})
+		}, graphqlws.CloseBadRequest},
+		{"no init", func(client *graphqlws.Client) {}, graphqlws.CloseConnectionInitTimeout},
+	}
+	for _, test := range tests {
+		client := dial(t, url)
+		test.Send(client)
+		expectClose(t, client, test.Code)
+		client.Close()
+	}
+}
+
+func TestServer_ClosesConnectionOnMessagesTooLarge(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{
+		MaxMessageSize: 64,
+	})
+	defer server.Close()
+
+	client := dial(t, url)
+	defer client.Close()
+	if err := client.Init(nil); err != nil {
+		t.Fatalf("unexpected error initializing connection: %v", err)
+	}
+	client.Subscribe("1", graphqlws.SubscribePayload{Query: "{ user " + strings.Repeat(" ", 64) + "}"})
+	expectClose(t, client, websocket.CloseMessageTooBig)
+}
+
+func TestServer_RequiresSubprotocol(t *testing.T) {
+	server, url := newServer(t, &graphqlws.Config{})
+	defer server.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("unexpected error dialing server: %v", err)
+	}
+	defer ws.Close()
+	_, _, err = ws.ReadMessage()
+	if closeErr, ok := err.(*websocket.CloseError); !ok || closeErr.Code != graphqlws.CloseSubprotocolNotAcceptable {
+		t.Fatalf("expected connection to be closed with code %v, got: %v", graphqlws.CloseSubprotocolNotAcceptable, err)
+	}
+}
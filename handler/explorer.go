@@ -0,0 +1,366 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+)
+
+// ExplorerConfig holds the settings of an Explorer page.
+type ExplorerConfig struct {
+	// Endpoint is the URL the page sends operations to.
+	// Defaults to the path of the request the page is served for.
+	Endpoint string
+
+	// Title is the title of the page. Defaults to "GraphQL Explorer".
+	Title string
+
+	// DefaultQuery is shown in the editor when the URL does not carry a query.
+	DefaultQuery string
+
+	// Headers are sent along with every operation.
+	Headers map[string]string
+}
+
+// Explorer serves GraphiQL, the in-browser IDE for exploring a GraphQL
+// endpoint, with its scripts and styles built into the binary: nothing is
+// fetched from a CDN. They are vendored into graphiql_assets.go by running
+// go generate; until then, a lightweight page is served instead, with an
+// editor for operations and their variables, the results, and documentation
+// built from the introspection of the schema.
+//
+// The assets are served from the path of the page itself, under the
+// `graphiqlAsset` URL parameter. The `query`, `variables` and
+// `operationName` URL parameters prefill the editors, and are kept in sync
+// with them so that a session can be shared by copying the URL.
+type Explorer struct {
+	config ExplorerConfig
+}
+
+// NewExplorer returns an Explorer handler for the given configuration.
+func NewExplorer(c *ExplorerConfig) *Explorer {
+	config := ExplorerConfig{}
+	if c != nil {
+		config = *c
+	}
+	if config.Title == "" {
+		config.Title = "GraphQL Explorer"
+	}
+	return &Explorer{config}
+}
+
+type explorerData struct {
+	Title         string
+	Endpoint      string
+	Headers       map[string]string
+	Query         string
+	Variables     string
+	OperationName string
+}
+
+// ServeHTTP implements http.Handler.
+func (e *Explorer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	values := r.URL.Query()
+	if name := values.Get(graphiqlAssetParam); name != "" {
+		serveGraphiQLAsset(w, r, name)
+		return
+	}
+	data := explorerData{
+		Title:         e.config.Title,
+		Endpoint:      e.config.Endpoint,
+		Headers:       e.config.Headers,
+		Query:         values.Get("query"),
+		Variables:     values.Get("variables"),
+		OperationName: values.Get("operationName"),
+	}
+	if data.Endpoint == "" {
+		data.Endpoint = r.URL.Path
+	}
+	if data.Headers == nil {
+		data.Headers = map[string]string{}
+	}
+	if _, ok := values["query"]; !ok {
+		data.Query = e.config.DefaultQuery
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	var err error
+	if hasGraphiQL() {
+		page := graphiqlData{explorerData: data}
+		for _, name := range graphiqlStyleSheets {
+			page.StyleSheets = append(page.StyleSheets, graphiqlAssetURL(r, name))
+		}
+		for _, name := range graphiqlScripts {
+			page.Scripts = append(page.Scripts, graphiqlAssetURL(r, name))
+		}
+		err = graphiqlTemplate.Execute(w, page)
+	} else {
+		err = explorerTemplate.Execute(w, data)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// acceptsHTML determines if the request was sent by a browser navigating to
+// the endpoint rather than by a GraphQL client. The `raw` URL parameter forces
+// a JSON response.
+func acceptsHTML(r *http.Request) bool {
+	if _, ok := r.URL.Query()["raw"]; ok {
+		return false
+	}
+	return accepts(r, "text/html")
+}
+
+var explorerTemplate = template.Must(template.New("Explorer").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+* { box-sizing: border-box; }
+html, body { height: 100%; margin: 0; }
+body {
+  display: flex; flex-direction: column;
+  font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 14px;
+  color: #333; background: #f7f7f7;
+}
+header {
+  display: flex; align-items: center; gap: 8px; padding: 6px 12px;
+  background: linear-gradient(#f7f7f7, #e2e2e2); border-bottom: 1px solid #d0d0d0;
+}
+header h1 { margin: 0 12px 0 0; font-size: 18px; font-weight: normal; }
+header .spacer { flex: 1; }
+button {
+  padding: 4px 10px; border: 1px solid #c0c0c0; border-radius: 3px;
+  background: linear-gradient(#fdfdfd, #d2d3d6); cursor: pointer; font-size: 13px;
+}
+button.run { padding: 4px 14px; color: #fff; background: linear-gradient(#2b7bc4, #1f61a0); border-color: #1f61a0; }
+input { padding: 4px 6px; border: 1px solid #c0c0c0; border-radius: 3px; font-size: 13px; }
+main { flex: 1; display: flex; min-height: 0; }
+section { display: flex; flex-direction: column; min-width: 0; border-right: 1px solid #e0e0e0; }
+section.editors { flex: 1; }
+section.result { flex: 1; background: #fff; }
+section.docs { width: 300px; display: none; overflow: auto; background: #fff; padding: 8px 12px; }
+section.docs.open { display: block; }
+.label {
+  padding: 4px 12px; font-size: 11px; letter-spacing: 1px; text-transform: uppercase;
+  color: #777; background: #eee; border-bottom: 1px solid #e0e0e0;
+}
+textarea, pre {
+  flex: 1; margin: 0; padding: 8px 12px; border: 0; outline: none; resize: none; overflow: auto;
+  font-family: Menlo, Consolas, "Courier New", monospace; font-size: 13px; line-height: 1.5;
+  background: #fff; white-space: pre; tab-size: 2;
+}
+#variables { flex: 0 0 30%; border-top: 1px solid #e0e0e0; }
+#result.error { color: #c00; }
+.docs h2 { font-size: 16px; margin: 8px 0; }
+.docs a { color: #1f61a0; cursor: pointer; text-decoration: none; }
+.docs .type { color: #ca9800; }
+.docs .arg { color: #8b2bb9; }
+.docs .description { color: #777; margin: 2px 0 8px; }
+.docs ul { list-style: none; padding: 0; margin: 0; }
+.docs li { padding: 3px 0; }
+</style>
+</head>
+<body>
+<header>
+  <h1>GraphQL Explorer</h1>
+  <button class="run" id="run" title="Execute Query (Ctrl-Enter)">&#9654; Run</button>
+  <input id="operationName" placeholder="Operation name">
+  <button id="prettify" title="Prettify Variables">Prettify</button>
+  <span class="spacer"></span>
+  <button id="toggleDocs">Docs</button>
+</header>
+<main>
+  <section class="editors">
+    <div class="label">Query</div>
+    <textarea id="query" spellcheck="false"></textarea>
+    <div class="label">Query Variables</div>
+    <textarea id="variables" spellcheck="false"></textarea>
+  </section>
+  <section class="result">
+    <div class="label">Result</div>
+    <pre id="result"></pre>
+  </section>
+  <section class="docs" id="docs"></section>
+</main>
+<script>
+(function () {
+  var endpoint = {{.Endpoint}};
+  var headers = {{.Headers}};
+  var $ = function (id) { return document.getElementById(id); };
+  var query = $("query"), variables = $("variables"), operationName = $("operationName"), result = $("result");
+
+  query.value = {{.Query}};
+  variables.value = {{.Variables}};
+  operationName.value = {{.OperationName}};
+
+  function fetcher(params) {
+    var h = { "Content-Type": "application/json", "Accept": "application/json" };
+    for (var name in headers) { h[name] = headers[name]; }
+    return fetch(endpoint, {
+      method: "POST",
+      headers: h,
+      credentials: "same-origin",
+      body: JSON.stringify(params)
+    }).then(function (response) {
+      return response.text();
+    }).then(function (body) {
+      try { return JSON.parse(body); } catch (e) { return body; }
+    });
+  }
+
+  function updateURL() {
+    var params = [];
+    [["query", query.value], ["variables", variables.value], ["operationName", operationName.value]].forEach(function (p) {
+      if (p[1]) { params.push(encodeURIComponent(p[0]) + "=" + encodeURIComponent(p[1])); }
+    });
+    history.replaceState(null, document.title, "?" + params.join("&"));
+  }
+
+  function show(value, isError) {
+    result.className = isError ? "error" : "";
+    result.textContent = typeof value === "string" ? value : JSON.stringify(value, null, 2);
+  }
+
+  function run() {
+    var vars = null;
+    if (variables.value.trim() !== "") {
+      try { vars = JSON.parse(variables.value); } catch (e) { show("Variables are invalid JSON: " + e.message, true); return; }
+    }
+    updateURL();
+    show("Loading...");
+    fetcher({ query: query.value, variables: vars, operationName: operationName.value || null })
+      .then(function (data) { show(data, typeof data === "string"); })
+      .catch(function (e) { show(String(e), true); });
+  }
+
+  function prettify() {
+    try { variables.value = JSON.stringify(JSON.parse(variables.value), null, 2); } catch (e) {}
+  }
+
+  [query, variables].forEach(function (editor) {
+    editor.addEventListener("keydown", function (e) {
+      if (e.key === "Enter" && (e.ctrlKey || e.metaKey)) { e.preventDefault(); run(); }
+      if (e.key === "Tab") {
+        e.preventDefault();
+        var start = editor.selectionStart;
+        editor.value = editor.value.slice(0, start) + "  " + editor.value.slice(editor.selectionEnd);
+        editor.selectionStart = editor.selectionEnd = start + 2;
+      }
+    });
+    editor.addEventListener("change", updateURL);
+  });
+  operationName.addEventListener("change", updateURL);
+  $("run").addEventListener("click", run);
+  $("prettify").addEventListener("click", prettify);
+
+  // Documentation explorer, built from the introspection of the endpoint.
+  var docs = $("docs"), types = {}, schema = null;
+  var introspection = "{ __schema { queryType { name } mutationType { name } types { name kind description " +
+    "fields { name description args { name type { ...TypeRef } } type { ...TypeRef } } " +
+    "inputFields { name description type { ...TypeRef } } enumValues { name description } } } } " +
+    "fragment TypeRef on __Type { kind name ofType { kind name ofType { kind name ofType { kind name } } } }";
+
+  function el(tag, className, text) {
+    var node = document.createElement(tag);
+    if (className) { node.className = className; }
+    if (text) { node.textContent = text; }
+    return node;
+  }
+
+  function typeLink(ref) {
+    if (ref.kind === "NON_NULL") { var n = typeLink(ref.ofType); n.appendChild(document.createTextNode("!")); return n; }
+    var span = el("span");
+    if (ref.kind === "LIST") {
+      span.appendChild(document.createTextNode("["));
+      span.appendChild(typeLink(ref.ofType));
+      span.appendChild(document.createTextNode("]"));
+      return span;
+    }
+    var a = el("a", "type", ref.name);
+    a.addEventListener("click", function () { showType(ref.name); });
+    span.appendChild(a);
+    return span;
+  }
+
+  function showType(name) {
+    var type = types[name];
+    docs.textContent = "";
+    var back = el("a", "", "‹ Schema");
+    back.addEventListener("click", showSchema);
+    docs.appendChild(back);
+    docs.appendChild(el("h2", "type", type.name));
+    if (type.description) { docs.appendChild(el("div", "description", type.description)); }
+    var list = el("ul");
+    (type.fields || type.inputFields || []).forEach(function (field) {
+      var li = el("li");
+      li.appendChild(document.createTextNode(field.name));
+      if (field.args && field.args.length) {
+        li.appendChild(document.createTextNode("("));
+        field.args.forEach(function (arg, i) {
+          if (i) { li.appendChild(document.createTextNode(", ")); }
+          li.appendChild(el("span", "arg", arg.name));
+          li.appendChild(document.createTextNode(": "));
+          li.appendChild(typeLink(arg.type));
+        });
+        li.appendChild(document.createTextNode(")"));
+      }
+      li.appendChild(document.createTextNode(": "));
+      li.appendChild(typeLink(field.type));
+      if (field.description) { li.appendChild(el("div", "description", field.description)); }
+      list.appendChild(li);
+    });
+    (type.enumValues || []).forEach(function (value) {
+      var li = el("li", "", value.name);
+      if (value.description) { li.appendChild(el("div", "description", value.description)); }
+      list.appendChild(li);
+    });
+    docs.appendChild(list);
+  }
+
+  function showSchema() {
+    docs.textContent = "";
+    docs.appendChild(el("h2", "", "Schema"));
+    var list = el("ul");
+    [["query", schema.queryType], ["mutation", schema.mutationType]].forEach(function (root) {
+      if (!root[1]) { return; }
+      var li = el("li", "", root[0] + ": ");
+      li.appendChild(typeLink({ kind: "OBJECT", name: root[1].name }));
+      list.appendChild(li);
+    });
+    docs.appendChild(list);
+    docs.appendChild(el("h2", "", "All types"));
+    list = el("ul");
+    schema.types.forEach(function (type) {
+      if (type.name.indexOf("__") === 0) { return; }
+      var li = el("li");
+      li.appendChild(typeLink(type));
+      list.appendChild(li);
+    });
+    docs.appendChild(list);
+  }
+
+  $("toggleDocs").addEventListener("click", function () {
+    docs.classList.toggle("open");
+    if (schema || !docs.classList.contains("open")) { return; }
+    docs.textContent = "Loading...";
+    fetcher({ query: introspection }).then(function (data) {
+      if (!data || !data.data) { docs.textContent = "Failed to load the schema."; return; }
+      schema = data.data.__schema;
+      schema.types.forEach(function (type) { types[type.name] = type; });
+      showSchema();
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`))
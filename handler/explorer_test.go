@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/handler"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestExplorer_ServesPageForEndpoint(t *testing.T) {
+	g := handler.NewExplorer(&handler.ExplorerConfig{
+		Endpoint: "/api/graphql",
+		Headers:  map[string]string{"X-Token": "secret"},
+	})
+	req, _ := http.NewRequest("GET", "/explorer?query=%7Bhero%7Bname%7D%7D", nil)
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Fatalf("unexpected content type %v", contentType)
+	}
+	body := w.Body.String()
+	for _, expected := range []string{
+		`<title>GraphQL Explorer</title>`,
+		`var endpoint = "/api/graphql";`,
+		`"X-Token":"secret"`,
+		`"{hero{name}}"`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Fatalf("expected page to contain %v", expected)
+		}
+	}
+	for _, external := range []string{`src="http`, `href="http`, `src="//`, `href="//`} {
+		if strings.Contains(body, external) {
+			t.Fatalf("expected page not to load external assets, found %v", external)
+		}
+	}
+}
+
+func TestExplorer_EscapesParameters(t *testing.T) {
+	g := handler.NewExplorer(nil)
+	req, _ := http.NewRequest("GET", "/explorer?query=%3C%2Fscript%3E%3Cscript%3Ealert(1)", nil)
+	w := httptest.NewRecorder()
+	g.ServeHTTP(w, req)
+	if strings.Contains(w.Body.String(), "</script><script>alert(1)") {
+		t.Fatalf("expected query parameter to be escaped")
+	}
+}
+
+func TestHandler_ServesExplorerToBrowsers(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema:   &testutil.StarWarsSchema,
+		Explorer: &handler.ExplorerConfig{},
+	})
+
+	tests := []struct {
+		Target   string
+		Accept   string
+		Explorer bool
+	}{
+		{"/graphql", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", true},
+		{"/graphql?query={hero{name}}", "text/html", true},
+		{"/graphql?query={hero{name}}&raw", "text/html", false},
+		{"/graphql?query={hero{name}}", "application/json", false},
+		{"/graphql?query={hero{name}}", "", false},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest("GET", test.Target, nil)
+		req.Header.Set("Accept", test.Accept)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		isExplorer := strings.HasPrefix(w.Header().Get("Content-Type"), "text/html")
+		if isExplorer != test.Explorer {
+			t.Fatalf("%v with Accept %q: expected Explorer %v, got %v", test.Target, test.Accept, test.Explorer, isExplorer)
+		}
+		if isExplorer && !strings.Contains(w.Body.String(), `var endpoint = "/graphql";`) {
+			t.Fatalf("expected Explorer to default to the request path")
+		}
+	}
+}
+
+func TestHandler_DoesNotServeExplorerUnlessEnabled(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &testutil.StarWarsSchema,
+	})
+	req, _ := http.NewRequest("GET", "/graphql?query={hero{name}}", nil)
+	req.Header.Set("Accept", "text/html")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if contentType := w.Header().Get("Content-Type"); strings.HasPrefix(contentType, "text/html") {
+		t.Fatalf("unexpected content type %v", contentType)
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+	"net/url"
+)
+
+//go:generate go run graphiql_gen.go
+
+// graphiqlAssetParam is the URL parameter under which the Explorer serves the
+// assets of GraphiQL, from the path it is served at.
+const graphiqlAssetParam = "graphiqlAsset"
+
+// graphiqlAsset is a script or style sheet of GraphiQL built into the binary.
+type graphiqlAsset struct {
+	contentType string
+	body        string
+}
+
+// graphiqlAssets holds the assets of GraphiQL, registered by the
+// graphiql_assets.go file graphiql_gen.go vendors them into. The Explorer
+// serves its lightweight page as long as they are missing.
+var graphiqlAssets = map[string]graphiqlAsset{}
+
+// The assets loaded by the GraphiQL page, in order.
+var (
+	graphiqlStyleSheets = []string{"graphiql.min.css"}
+	graphiqlScripts     = []string{"react.production.min.js", "react-dom.production.min.js", "graphiql.min.js"}
+)
+
+// hasGraphiQL determines if all the assets of GraphiQL are built in.
+func hasGraphiQL() bool {
+	for _, names := range [][]string{graphiqlStyleSheets, graphiqlScripts} {
+		for _, name := range names {
+			if _, ok := graphiqlAssets[name]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// serveGraphiQLAsset writes the asset of GraphiQL of the given name.
+func serveGraphiQLAsset(w http.ResponseWriter, r *http.Request, name string) {
+	asset, ok := graphiqlAssets[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", asset.contentType)
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Write([]byte(asset.body))
+}
+
+// graphiqlAssetURL returns the URL of an asset of GraphiQL, served from the
+// path of the page.
+func graphiqlAssetURL(r *http.Request, name string) string {
+	return r.URL.Path + "?" + url.Values{graphiqlAssetParam: {name}}.Encode()
+}
+
+type graphiqlData struct {
+	explorerData
+	StyleSheets []string
+	Scripts     []string
+}
+
+var graphiqlTemplate = template.Must(template.New("GraphiQL").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+html, body { height: 100%; margin: 0; overflow: hidden; }
+#graphiql { height: 100vh; }
+</style>
+{{range .StyleSheets}}<link rel="stylesheet" href="{{.}}">
+{{end}}{{range .Scripts}}<script src="{{.}}"></script>
+{{end}}</head>
+<body>
+<div id="graphiql">Loading...</div>
+<script>
+(function () {
+  var endpoint = {{.Endpoint}};
+  var headers = {{.Headers}};
+  var parameters = {
+    query: {{.Query}},
+    variables: {{.Variables}},
+    operationName: {{.OperationName}}
+  };
+
+  // Keeps the URL in sync with the editors, so that it can be shared.
+  function updateURL() {
+    var search = Object.keys(parameters).filter(function (key) {
+      return parameters[key];
+    }).map(function (key) {
+      return encodeURIComponent(key) + "=" + encodeURIComponent(parameters[key]);
+    }).join("&");
+    history.replaceState(null, null, "?" + search);
+  }
+
+  function fetcher(params) {
+    var requestHeaders = { "Accept": "application/json", "Content-Type": "application/json" };
+    Object.keys(headers).forEach(function (name) { requestHeaders[name] = headers[name]; });
+    return fetch(endpoint, {
+      method: "POST",
+      headers: requestHeaders,
+      body: JSON.stringify(params),
+      credentials: "same-origin"
+    }).then(function (response) {
+      return response.text();
+    }).then(function (text) {
+      try {
+        return JSON.parse(text);
+      } catch (e) {
+        return text;
+      }
+    });
+  }
+
+  ReactDOM.render(
+    React.createElement(GraphiQL, {
+      fetcher: fetcher,
+      query: parameters.query,
+      variables: parameters.variables,
+      operationName: parameters.operationName,
+      onEditQuery: function (value) { parameters.query = value; updateURL(); },
+      onEditVariables: function (value) { parameters.variables = value; updateURL(); },
+      onEditOperationName: function (value) { parameters.operationName = value; updateURL(); }
+    }),
+    document.getElementById("graphiql")
+  );
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql/testutil"
+)
+
+// withGraphiQLAssets stands in for the vendored assets of GraphiQL.
+func withGraphiQLAssets(t *testing.T, f func()) {
+	saved := graphiqlAssets
+	defer func() { graphiqlAssets = saved }()
+	graphiqlAssets = map[string]graphiqlAsset{}
+	for _, name := range append(append([]string{}, graphiqlStyleSheets...), graphiqlScripts...) {
+		graphiqlAssets[name] = graphiqlAsset{
+			contentType: "application/javascript; charset=utf-8",
+			body:        "/* " + name + " */",
+		}
+	}
+	f()
+}
+
+func TestHandler_ServesGraphiQLWithBuiltInAssets(t *testing.T) {
+	withGraphiQLAssets(t, func() {
+		h := New(&Config{
+			Schema:   &testutil.StarWarsSchema,
+			Explorer: &ExplorerConfig{Endpoint: "/api/graphql"},
+		})
+
+		req, _ := http.NewRequest("GET", "/graphql?query=%7Bhero%7D", nil)
+		req.Header.Set("Accept", "text/html")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		body := w.Body.String()
+		for _, expected := range []string{
+			`<link rel="stylesheet" href="/graphql?graphiqlAsset=graphiql.min.css">`,
+			`<script src="/graphql?graphiqlAsset=graphiql.min.js"></script>`,
+			`React.createElement(GraphiQL`,
+			`var endpoint = "/api/graphql";`,
+			`query: "{hero}"`,
+		} {
+			if !strings.Contains(body, expected) {
+				t.Fatalf("expected page to contain %v, got: %v", expected, body)
+			}
+		}
+
+		req, _ = http.NewRequest("GET", "/graphql?graphiqlAsset=graphiql.min.js", nil)
+		req.Header.Set("Accept", "*/*")
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || w.Body.String() != "/* graphiql.min.js */" {
+			t.Fatalf("unexpected asset response %v: %v", w.Code, w.Body.String())
+		}
+		if contentType := w.Header().Get("Content-Type"); contentType != "application/javascript; charset=utf-8" {
+			t.Fatalf("unexpected content type %v", contentType)
+		}
+
+		req, _ = http.NewRequest("GET", "/graphql?graphiqlAsset=unknown.js", nil)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("unexpected server response %v", w.Code)
+		}
+	})
+}
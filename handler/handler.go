@@ -0,0 +1,481 @@
+// Package handler serves a GraphQL schema over HTTP.
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/upload"
+	"golang.org/x/net/context"
+)
+
+// ContextFn returns the context an operation is executed with.
+type ContextFn func(r *http.Request) context.Context
+
+// RootObjectFn returns the root object an operation is executed with.
+type RootObjectFn func(ctx context.Context, r *http.Request) map[string]interface{}
+
+// Config holds the settings of a Handler.
+type Config struct {
+	Schema *graphql.Schema
+
+	// Pretty indents the JSON responses.
+	Pretty bool
+
+	// ContextFn, if provided, returns the context of each request.
+	// Defaults to context.Background().
+	ContextFn ContextFn
+
+	// RootObjectFn, if provided, returns the root object of each request.
+	RootObjectFn RootObjectFn
+
+	// Uploads, if provided, enables multipart/form-data requests carrying
+	// file uploads, with the given limits.
+	Uploads *upload.Options
+
+	// MaxBatchSize is the maximum number of operations accepted in a single
+	// batched request. Batched requests are rejected when it is zero.
+	MaxBatchSize int
+
+	// BatchConcurrency is the maximum number of operations of a batch executed
+	// concurrently. Operations are executed one after the other when it is
+	// less than two.
+	BatchConcurrency int
+
+	// PersistedQueries stores the queries registered by clients using automatic
+	// persisted queries. Defaults to an LRUPersistedQueryStore holding
+	// DefaultPersistedQueryCacheSize queries.
+	PersistedQueries PersistedQueryStore
+
+	// DisablePersistedQueries rejects requests using automatic persisted queries.
+	DisablePersistedQueries bool
+
+	// DocumentCache, if provided, caches the parsed and validated documents of
+	// the operations executed by the handler.
+	DocumentCache *graphql.DocumentCache
+
+	// ParseLimits, if provided, limits the size and nesting of the operations
+	// parsed by the handler, rejecting those exceeding them with 400.
+	ParseLimits graphql.ParseLimits
+
+	// Explorer, if provided, serves the Explorer page to GET requests accepting
+	// text/html, such as those of a browser navigating to the endpoint.
+	Explorer *ExplorerConfig
+
+	// MaxBodySize is the maximum size in bytes of the body of a request,
+	// multipart requests excepted, whose limits are set by Uploads.
+	// Defaults to DefaultMaxBodySize.
+	MaxBodySize int64
+
+	// EventStreamKeepAlive is the interval at which keep-alive comments are
+	// written to the event streams of clients accepting text/event-stream.
+	// Defaults to DefaultEventStreamKeepAlive.
+	EventStreamKeepAlive time.Duration
+}
+
+// Handler serves GraphQL operations over HTTP.
+type Handler struct {
+	Schema       *graphql.Schema
+	pretty       bool
+	contextFn    ContextFn
+	rootObjectFn RootObjectFn
+	uploads      *upload.Options
+
+	maxBatchSize     int
+	batchConcurrency int
+	persistedQueries PersistedQueryStore
+	documentCache    *graphql.DocumentCache
+	parseLimits      graphql.ParseLimits
+	explorer         *Explorer
+
+	maxBodySize          int64
+	eventStreamKeepAlive time.Duration
+}
+
+// DefaultMaxBodySize is the maximum size of request bodies when none is
+// configured.
+const DefaultMaxBodySize = 1 << 20
+
+// NewConfig returns a Config with default settings.
+func NewConfig() *Config {
+	return &Config{
+		Schema: nil,
+		Pretty: true,
+	}
+}
+
+// New returns a Handler for the given configuration.
+func New(p *Config) *Handler {
+	if p == nil {
+		p = NewConfig()
+	}
+	if p.Schema == nil {
+		panic("undefined GraphQL schema")
+	}
+
+	persistedQueries := p.PersistedQueries
+	if persistedQueries == nil {
+		persistedQueries = NewLRUPersistedQueryStore(DefaultPersistedQueryCacheSize)
+	}
+	if p.DisablePersistedQueries {
+		persistedQueries = nil
+	}
+
+	var explorer *Explorer
+	if p.Explorer != nil {
+		explorer = NewExplorer(p.Explorer)
+	}
+
+	maxBodySize := p.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+
+	eventStreamKeepAlive := p.EventStreamKeepAlive
+	if eventStreamKeepAlive <= 0 {
+		eventStreamKeepAlive = DefaultEventStreamKeepAlive
+	}
+
+	return &Handler{
+		Schema:       p.Schema,
+		pretty:       p.Pretty,
+		contextFn:    p.ContextFn,
+		rootObjectFn: p.RootObjectFn,
+		uploads:      p.Uploads,
+
+		maxBatchSize:     p.MaxBatchSize,
+		batchConcurrency: p.BatchConcurrency,
+		persistedQueries: persistedQueries,
+		documentCache:    p.DocumentCache,
+		parseLimits:      p.ParseLimits,
+		explorer:         explorer,
+
+		maxBodySize:          maxBodySize,
+		eventStreamKeepAlive: eventStreamKeepAlive,
+	}
+}
+
+// ContextHandler executes the operations of the request with the given context.
+//
+// Operations may refer to a query registered earlier through the
+// `persistedQuery` extension instead of sending its text.
+//
+// The response status code is 200 unless the request could not be executed:
+// malformed requests and operations failing to parse or validate are answered
+// with 400, mutations sent with GET with 405 and unsupported content types with 415.
+// Browsers navigating to the endpoint are served the Explorer page and its
+// assets, if enabled, and clients accepting text/event-stream are streamed the
+// results of their operation, subscriptions in particular, as server-sent
+// events.
+// Batched requests are answered with an array of results and a 200 status code
+// whenever the batch itself is well-formed.
+func (h *Handler) ContextHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+	if h.explorer != nil && r.Method == "GET" && (acceptsHTML(r) || r.URL.Query().Get(graphiqlAssetParam) != "") {
+		h.explorer.ServeHTTP(w, r)
+		return
+	}
+
+	if r.Method != "GET" && r.Method != "POST" {
+		w.Header().Set("Allow", "GET, POST")
+		h.writeErrors(w, http.StatusMethodNotAllowed, errors.New("GraphQL only supports GET and POST requests."))
+		return
+	}
+
+	var opts []*RequestOptions
+	var isBatch bool
+	if r.Method == "POST" && contentType(r) == ContentTypeMultipart {
+		if h.uploads == nil {
+			h.writeErrors(w, http.StatusUnsupportedMediaType, errUnsupportedContentType)
+			return
+		}
+		req, err := upload.ParseRequest(w, r, *h.uploads)
+		if err != nil {
+			h.writeErrors(w, upload.StatusCode(err), err)
+			return
+		}
+		defer req.Close()
+		for _, operation := range req.Operations {
+			opts = append(opts, &RequestOptions{
+				Query:         operation.Query,
+				Variables:     operation.Variables,
+				OperationName: operation.OperationName,
+			})
+		}
+		isBatch = req.Batch
+	} else {
+		var err error
+		body := &limitedBody{ReadCloser: r.Body, remaining: h.maxBodySize}
+		r.Body = body
+		opts, isBatch, err = NewBatchRequestOptions(r)
+		if err == errUnsupportedContentType {
+			h.writeErrors(w, http.StatusUnsupportedMediaType, err)
+			return
+		}
+		if err != nil && body.exceeded {
+			h.writeErrors(w, http.StatusRequestEntityTooLarge, errBodyTooLarge)
+			return
+		}
+		if err != nil {
+			h.writeErrors(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if !isBatch {
+		h.serveOperation(ctx, w, r, opts[0])
+		return
+	}
+
+	if h.maxBatchSize <= 0 {
+		h.writeErrors(w, http.StatusBadRequest, errors.New("Batched requests are not supported."))
+		return
+	}
+	if len(opts) == 0 {
+		h.writeErrors(w, http.StatusBadRequest, errors.New("Must provide at least one operation."))
+		return
+	}
+	if len(opts) > h.maxBatchSize {
+		h.writeErrors(w, http.StatusRequestEntityTooLarge,
+			fmt.Errorf("Batch contains %v operations, the maximum is %v.", len(opts), h.maxBatchSize))
+		return
+	}
+	h.writeJSON(w, http.StatusOK, h.executeBatch(ctx, r, opts))
+}
+
+// serveOperation executes a single operation and writes its result.
+func (h *Handler) serveOperation(ctx context.Context, w http.ResponseWriter, r *http.Request, opts *RequestOptions) {
+	hash, err := h.loadPersistedQuery(opts)
+	if err != nil {
+		status := http.StatusBadRequest
+		if err == ErrPersistedQueryNotFound || err == ErrPersistedQueryNotSupported {
+			status = http.StatusOK
+		}
+		h.writeErrors(w, status, err)
+		return
+	}
+	if opts.Query == "" {
+		h.writeErrors(w, http.StatusBadRequest, errMissingQuery)
+		return
+	}
+
+	p := h.params(ctx, r, opts)
+	AST, result := graphql.ParseAndValidate(p)
+	if result != nil {
+		h.writeJSON(w, http.StatusBadRequest, result)
+		return
+	}
+	h.persistQuery(hash, opts.Query)
+
+	if r.Method == "GET" && operationType(AST, opts.OperationName) == ast.OperationTypeMutation {
+		w.Header().Set("Allow", "POST")
+		h.writeErrors(w, http.StatusMethodNotAllowed, errors.New("Can only perform a mutation operation from a POST request."))
+		return
+	}
+
+	if accepts(r, ContentTypeEventStream) {
+		h.serveEventStream(w, r, p, AST)
+		return
+	}
+	if accepts(r, ContentTypeMultipartMixed) {
+		h.serveIncremental(w, r, p, AST)
+		return
+	}
+
+	result = graphql.Execute(executeParams(p, AST))
+	status := http.StatusOK
+	if result.Data == nil && result.HasErrors() {
+		status = http.StatusBadRequest
+	}
+	h.writeJSON(w, status, result)
+}
+
+// executeBatch executes the operations of a batch, running at most
+// batchConcurrency of them at a time, and returns their results in order.
+func (h *Handler) executeBatch(ctx context.Context, r *http.Request, opts []*RequestOptions) []*graphql.Result {
+	results := make([]*graphql.Result, len(opts))
+	pending := make([]*RequestOptions, 0, len(opts))
+	hashes := make([]string, 0, len(opts))
+	indexes := make([]int, 0, len(opts))
+	for i, opt := range opts {
+		hash, err := h.loadPersistedQuery(opt)
+		if err != nil {
+			results[i] = &graphql.Result{
+				Errors: gqlerrors.FormatErrors(err),
+			}
+			continue
+		}
+		pending = append(pending, opt)
+		hashes = append(hashes, hash)
+		indexes = append(indexes, i)
+	}
+
+	if h.batchConcurrency <= 1 {
+		for j, opt := range pending {
+			results[indexes[j]] = h.execute(ctx, r, opt, hashes[j])
+		}
+		return results
+	}
+
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, h.batchConcurrency)
+	for j, opt := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, opt *RequestOptions, hash string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i] = h.execute(ctx, r, opt, hash)
+		}(indexes[j], opt, hashes[j])
+	}
+	wg.Wait()
+	return results
+}
+
+// execute runs a single operation, registering its query under the hash of
+// its persisted query, if any, once it is valid.
+func (h *Handler) execute(ctx context.Context, r *http.Request, opts *RequestOptions, hash string) *graphql.Result {
+	if opts.Query == "" {
+		return &graphql.Result{
+			Errors: gqlerrors.FormatErrors(errMissingQuery),
+		}
+	}
+	p := h.params(ctx, r, opts)
+	AST, result := graphql.ParseAndValidate(p)
+	if result != nil {
+		return result
+	}
+	h.persistQuery(hash, opts.Query)
+	return graphql.Execute(executeParams(p, AST))
+}
+
+// params returns the parameters an operation is executed with.
+func (h *Handler) params(ctx context.Context, r *http.Request, opts *RequestOptions) graphql.Params {
+	var rootObject map[string]interface{}
+	if h.rootObjectFn != nil {
+		rootObject = h.rootObjectFn(ctx, r)
+	}
+	return graphql.Params{
+		Schema:         *h.Schema,
+		RequestString:  opts.Query,
+		RootObject:     rootObject,
+		VariableValues: opts.Variables,
+		OperationName:  opts.OperationName,
+		Context:        ctx,
+		DocumentCache:  h.documentCache,
+		ParseLimits:    h.parseLimits,
+	}
+}
+
+// executeParams returns the parameters executing the validated document of
+// an operation.
+func executeParams(p graphql.Params, AST *ast.Document) graphql.ExecuteParams {
+	return graphql.ExecuteParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           AST,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		Context:       p.Context,
+	}
+}
+
+var (
+	errMissingQuery = errors.New("Must provide query string.")
+	errBodyTooLarge = errors.New("Request body is too large.")
+)
+
+// limitedBody limits the size of a request body, recording once it is
+// exceeded.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		return n, err
+	}
+	n = int(b.remaining)
+	b.remaining = 0
+	b.exceeded = true
+	return n, errBodyTooLarge
+}
+
+// cancelOnDisconnect returns a copy of ctx which is cancelled once the client
+// of the request disconnects, for operations streaming their results.
+func cancelOnDisconnect(ctx context.Context, w http.ResponseWriter) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	notifier, ok := w.(http.CloseNotifier)
+	if !ok {
+		return ctx, cancel
+	}
+	closed := notifier.CloseNotify()
+	go func() {
+		select {
+		case <-closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+	if h.contextFn != nil {
+		ctx = h.contextFn(r)
+	}
+	h.ContextHandler(ctx, w, r)
+}
+
+func (h *Handler) writeErrors(w http.ResponseWriter, status int, errs ...error) {
+	h.writeJSON(w, status, &graphql.Result{
+		Errors: gqlerrors.FormatErrors(errs...),
+	})
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if h.pretty {
+		buff, _ := json.MarshalIndent(v, "", "\t")
+		w.Write(buff)
+		return
+	}
+	buff, _ := json.Marshal(v)
+	w.Write(buff)
+}
+
+// operationType returns the type of the operation of the document that would
+// be executed, or an empty string if there is none.
+func operationType(AST *ast.Document, operationName string) string {
+	for _, definition := range AST.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || operation.GetName() != nil && operation.GetName().Value == operationName {
+			return operation.GetOperation()
+		}
+	}
+	return ""
+}
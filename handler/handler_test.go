@@ -0,0 +1,326 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/handler"
+	"github.com/graphql-go/graphql/testutil"
+	"golang.org/x/net/context"
+)
+
+type ctxKey string
+
+var counterSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Context.Value(ctxKey("user")), nil
+				},
+			},
+			"root": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Info.RootValue.(map[string]interface{})["root"], nil
+				},
+			},
+		},
+	}),
+	Mutation: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"increment": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return 1, nil
+				},
+			},
+		},
+	}),
+})
+
+func decodeResponse(t *testing.T, w *httptest.ResponseRecorder) *graphql.Result {
+	var target graphql.Result
+	if err := json.NewDecoder(w.Body).Decode(&target); err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	return &target
+}
+
+func TestHandler_BasicQuery(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+			},
+		},
+	}
+	queryString := `query=query HeroNameQuery { hero { name } }`
+	req, _ := http.NewRequest("GET", "/graphql?"+url.PathEscape(queryString), nil)
+
+	h := handler.New(&handler.Config{
+		Schema: &testutil.StarWarsSchema,
+		Pretty: true,
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "application/json; charset=utf-8" {
+		t.Fatalf("unexpected content type %v", contentType)
+	}
+	result := decodeResponse(t, w)
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestHandler_ContextAndRootObject(t *testing.T) {
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"user": "leia",
+			"root": "leia's root",
+		},
+	}
+	h := handler.New(&handler.Config{
+		Schema: &counterSchema,
+		ContextFn: func(r *http.Request) context.Context {
+			return context.WithValue(context.Background(), ctxKey("user"), r.Header.Get("X-User"))
+		},
+		RootObjectFn: func(ctx context.Context, r *http.Request) map[string]interface{} {
+			return map[string]interface{}{
+				"root": ctx.Value(ctxKey("user")).(string) + "'s root",
+			}
+		},
+	})
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(`{ user root }`))
+	req.Header.Set("Content-Type", "application/graphql")
+	req.Header.Set("X-User", "leia")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	result := decodeResponse(t, w)
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestHandler_StatusCodes(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &counterSchema,
+	})
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		code        int
+	}{
+		{
+			name:   "mutation over GET",
+			method: "GET",
+			target: "/graphql?query=" + url.QueryEscape("mutation { increment }"),
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:        "mutation over POST",
+			method:      "POST",
+			target:      "/graphql",
+			contentType: "application/graphql",
+			body:        "mutation { increment }",
+			code:        http.StatusOK,
+		},
+		{
+			name:   "unsupported method",
+			method: "PUT",
+			target: "/graphql?query=" + url.QueryEscape("{ user }"),
+			code:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "missing query",
+			method: "GET",
+			target: "/graphql",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:        "invalid JSON",
+			method:      "POST",
+			target:      "/graphql",
+			contentType: "application/json",
+			body:        `{ "query": `,
+			code:        http.StatusBadRequest,
+		},
+		{
+			name:        "unsupported content type",
+			method:      "POST",
+			target:      "/graphql",
+			contentType: "text/plain",
+			body:        `{ user }`,
+			code:        http.StatusUnsupportedMediaType,
+		},
+		{
+			name:   "syntax error",
+			method: "GET",
+			target: "/graphql?query=" + url.QueryEscape("{ user "),
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "validation error",
+			method: "GET",
+			target: "/graphql?query=" + url.QueryEscape("{ unknown }"),
+			code:   http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest(test.method, test.target, bytes.NewBufferString(test.body))
+		if test.contentType != "" {
+			req.Header.Set("Content-Type", test.contentType)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != test.code {
+			t.Fatalf("%v: unexpected status code, got: %v, expected: %v", test.name, w.Code, test.code)
+		}
+		result := decodeResponse(t, w)
+		if test.code != http.StatusOK && len(result.Errors) == 0 {
+			t.Fatalf("%v: expected errors in response", test.name)
+		}
+	}
+}
+
+func TestHandler_RejectsBodiesTooLarge(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema:      &counterSchema,
+		MaxBodySize: 16,
+	})
+	for _, contentType := range []string{"application/json", "application/graphql", "application/x-www-form-urlencoded"} {
+		req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(`{ "query": "{ user root }" }`))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("%v: unexpected status code, got: %v", contentType, w.Code)
+		}
+		result := decodeResponse(t, w)
+		if len(result.Errors) != 1 || result.Errors[0].Message != "Request body is too large." {
+			t.Fatalf("%v: unexpected errors: %v", contentType, result.Errors)
+		}
+	}
+}
+
+func TestHandler_RejectsOperationsExceedingParseLimits(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema:      &counterSchema,
+		ParseLimits: graphql.ParseLimits{MaxTokens: 2},
+	})
+	req, _ := http.NewRequest("GET", "/graphql?query="+url.QueryEscape("{ user root }"), nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code, got: %v", w.Code)
+	}
+	result := decodeResponse(t, w)
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0].Message, "Document contains more than 2 tokens.") {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+}
+
+func TestHandler_ParsesOperationsOnce(t *testing.T) {
+	cache := graphql.NewDocumentCache(8)
+	h := handler.New(&handler.Config{
+		Schema:        &counterSchema,
+		DocumentCache: cache,
+	})
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", "/graphql?query="+url.QueryEscape("{ user }"), nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status code, got: %v", w.Code)
+		}
+	}
+	expected := graphql.DocumentCacheStats{Hits: 1, Misses: 1, Len: 1}
+	if stats := cache.Stats(); !reflect.DeepEqual(expected, stats) {
+		t.Fatalf("Unexpected stats, Diff: %v", testutil.Diff(expected, stats))
+	}
+}
+
+func TestHandler_Batch(t *testing.T) {
+	for _, concurrency := range []int{0, 2} {
+		h := handler.New(&handler.Config{
+			Schema:           &testutil.StarWarsSchema,
+			MaxBatchSize:     3,
+			BatchConcurrency: concurrency,
+		})
+		body := `[
+			{ "query": "{ hero { name } }" },
+			{ "query": "query H($id: String!) { human(id: $id) { name } }", "variables": { "id": "1000" } },
+			{ "query": "{ unknown }" }
+		]`
+		req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected server response %v", w.Code)
+		}
+		var results []*graphql.Result
+		if err := json.NewDecoder(w.Body).Decode(&results); err != nil {
+			t.Fatalf("unexpected error decoding response: %v", err)
+		}
+		if len(results) != 3 {
+			t.Fatalf("expected 3 results, got: %v", len(results))
+		}
+		expected := []interface{}{
+			map[string]interface{}{"hero": map[string]interface{}{"name": "R2-D2"}},
+			map[string]interface{}{"human": map[string]interface{}{"name": "Luke Skywalker"}},
+		}
+		for i, data := range expected {
+			if !reflect.DeepEqual(results[i].Data, data) {
+				t.Fatalf("wrong result #%v, graphql result diff: %v", i, testutil.Diff(data, results[i].Data))
+			}
+		}
+		if !results[2].HasErrors() {
+			t.Fatalf("expected errors in result #2")
+		}
+	}
+}
+
+func TestHandler_BatchLimits(t *testing.T) {
+	body := `[{ "query": "{ hero { name } }" }, { "query": "{ hero { name } }" }]`
+	tests := []struct {
+		maxBatchSize int
+		code         int
+	}{
+		{0, http.StatusBadRequest},
+		{1, http.StatusRequestEntityTooLarge},
+		{2, http.StatusOK},
+	}
+	for _, test := range tests {
+		h := handler.New(&handler.Config{
+			Schema:       &testutil.StarWarsSchema,
+			MaxBatchSize: test.maxBatchSize,
+		})
+		req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != test.code {
+			t.Fatalf("max batch size %v: unexpected status code, got: %v, expected: %v", test.maxBatchSize, w.Code, test.code)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// multipartBoundary is the boundary of multipart/mixed responses, as used by
+// the GraphQL incremental delivery over HTTP proposal.
+const multipartBoundary = "-"
+
+// MultipartWriter writes the payloads of an incrementally delivered result as
+// the parts of a multipart/mixed response, flushing each of them to the client
+// as soon as it is written.
+type MultipartWriter struct {
+	w       io.Writer
+	flusher http.Flusher
+}
+
+// NewMultipartWriter sets the Content-Type header of the response and writes
+// the opening boundary. Any other header must be set beforehand.
+func NewMultipartWriter(w http.ResponseWriter) *MultipartWriter {
+	w.Header().Set("Content-Type", ContentTypeMultipartMixed+`; boundary="`+multipartBoundary+`"`)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	mw := &MultipartWriter{
+		w:       w,
+		flusher: flusher,
+	}
+	mw.write("\r\n--" + multipartBoundary)
+	return mw
+}
+
+// WritePart writes the JSON encoding of v as a part, followed by a boundary.
+func (mw *MultipartWriter) WritePart(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return mw.write("\r\nContent-Type: application/json; charset=utf-8\r\n\r\n" + string(b) + "\r\n--" + multipartBoundary)
+}
+
+// Close turns the last boundary written into the closing one.
+func (mw *MultipartWriter) Close() error {
+	return mw.write("--\r\n")
+}
+
+func (mw *MultipartWriter) write(s string) error {
+	if _, err := io.WriteString(mw.w, s); err != nil {
+		return err
+	}
+	if mw.flusher != nil {
+		mw.flusher.Flush()
+	}
+	return nil
+}
+
+// serveIncremental executes an operation for a client accepting
+// multipart/mixed, delivering the data of fragments using @defer and of list
+// fields using @stream in subsequent parts of the response.
+//
+// Operations delivered in a single payload, such as those not using these
+// directives, are answered with a JSON response, as for other clients. The
+// document of the operation has already been validated. The operation is
+// cancelled when the client disconnects.
+func (h *Handler) serveIncremental(w http.ResponseWriter, r *http.Request, p graphql.Params, AST *ast.Document) {
+	ctx, cancel := cancelOnDisconnect(p.Context, w)
+	defer cancel()
+	p.Context = ctx
+	payloads := graphql.ExecuteIncremental(executeParams(p, AST))
+
+	initial, ok := <-payloads
+	if !ok {
+		return
+	}
+	if !initial.HasNext {
+		result := &graphql.Result{
+			Data:   initial.Data,
+			Errors: initial.Errors,
+		}
+		status := http.StatusOK
+		if result.Data == nil && result.HasErrors() {
+			status = http.StatusBadRequest
+		}
+		h.writeJSON(w, status, result)
+		return
+	}
+
+	mw := NewMultipartWriter(w)
+	mw.WritePart(initial)
+	hasNext := true
+	for payload := range payloads {
+		if mw.WritePart(payload) != nil {
+			return
+		}
+		hasNext = payload.HasNext
+	}
+	if !hasNext {
+		mw.Close()
+	}
+}
@@ -0,0 +1,143 @@
+package handler_test
+
+import (
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/handler"
+)
+
+var incrementalSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hello": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "world", nil
+				},
+			},
+			"letters": &graphql.Field{
+				Type: graphql.NewList(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return []string{"a", "b"}, nil
+				},
+			},
+		},
+	}),
+	Directives: []*graphql.Directive{
+		graphql.IncludeDirective,
+		graphql.SkipDirective,
+		graphql.DeferDirective,
+		graphql.StreamDirective,
+	},
+})
+
+func incrementalRequest(query string) *http.Request {
+	req, _ := http.NewRequest("GET", "/graphql?query="+url.QueryEscape(query), nil)
+	req.Header.Set("Accept", "multipart/mixed, application/json")
+	return req
+}
+
+func readParts(t *testing.T, w *httptest.ResponseRecorder) []string {
+	mediaType, params, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("unexpected content type %v", w.Header().Get("Content-Type"))
+	}
+	parts := []string{}
+	reader := multipart.NewReader(w.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if contentType := part.Header.Get("Content-Type"); contentType != "application/json; charset=utf-8" {
+			t.Fatalf("unexpected part content type %v", contentType)
+		}
+		b, _ := ioutil.ReadAll(part)
+		parts = append(parts, string(b))
+	}
+}
+
+func TestMultipartWriter_WritesParts(t *testing.T) {
+	w := httptest.NewRecorder()
+	mw := handler.NewMultipartWriter(w)
+	mw.WritePart(map[string]interface{}{"hasNext": true})
+	mw.WritePart(map[string]interface{}{"hasNext": false})
+	mw.Close()
+
+	expected := "\r\n---" +
+		"\r\nContent-Type: application/json; charset=utf-8\r\n\r\n" + `{"hasNext":true}` + "\r\n---" +
+		"\r\nContent-Type: application/json; charset=utf-8\r\n\r\n" + `{"hasNext":false}` + "\r\n-----\r\n"
+	if body := w.Body.String(); body != expected {
+		t.Fatalf("expected body %q, got %q", expected, body)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != `multipart/mixed; boundary="-"` {
+		t.Fatalf("unexpected content type %v", contentType)
+	}
+	if !w.Flushed {
+		t.Fatalf("expected parts to be flushed")
+	}
+}
+
+func TestHandler_Multipart_DeliversDeferredAndStreamedData(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &incrementalSchema,
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, incrementalRequest(`{ letters @stream(initialCount: 1) }`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	expected := []string{
+		`{"data":{"letters":["a"]},"hasNext":true}`,
+		`{"hasNext":false,"incremental":[{"items":["b"],"path":["letters",1]}]}`,
+	}
+	if parts := readParts(t, w); !reflect.DeepEqual(expected, parts) {
+		t.Fatalf("expected parts %v, got %v", expected, parts)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, incrementalRequest(`{ ... @defer(label: "greeting") { hello } }`))
+	expected = []string{
+		`{"data":{},"hasNext":true}`,
+		`{"hasNext":false,"incremental":[{"data":{"hello":"world"},"label":"greeting","path":[]}]}`,
+	}
+	if parts := readParts(t, w); !reflect.DeepEqual(expected, parts) {
+		t.Fatalf("expected parts %v, got %v", expected, parts)
+	}
+}
+
+func TestHandler_Multipart_AnswersSinglePayloadsWithJSON(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &incrementalSchema,
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, incrementalRequest(`{ hello }`))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	if body := w.Body.String(); body != `{"data":{"hello":"world"}}` {
+		t.Fatalf("unexpected body %v", body)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, incrementalRequest(`{ hello @stream }`))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	if result := decodeResponse(t, w); len(result.Errors) != 1 {
+		t.Fatalf("expected a single error, got: %v", result.Errors)
+	}
+}
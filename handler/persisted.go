@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultPersistedQueryCacheSize is the number of queries kept by the store a
+// Handler uses when none is configured.
+const DefaultPersistedQueryCacheSize = 1000
+
+var (
+	// ErrPersistedQueryNotFound is reported when a client sends the hash of a
+	// query the server does not know yet. Clients are expected to retry with
+	// both the query and its hash.
+	ErrPersistedQueryNotFound error = &persistedQueryError{"PersistedQueryNotFound", "PERSISTED_QUERY_NOT_FOUND"}
+
+	// ErrPersistedQueryNotSupported is reported when a client sends a hash to
+	// a Handler with persisted queries disabled.
+	ErrPersistedQueryNotSupported error = &persistedQueryError{"PersistedQueryNotSupported", "PERSISTED_QUERY_NOT_SUPPORTED"}
+
+	// ErrPersistedQueryHashMismatch is reported when the hash sent by a client
+	// is not the SHA-256 hash of the query it sent along.
+	ErrPersistedQueryHashMismatch = errors.New("Provided sha256Hash does not match query.")
+)
+
+// persistedQueryError is an error clients of automatic persisted queries
+// recognize by the code in its extensions.
+type persistedQueryError struct {
+	message string
+	code    string
+}
+
+func (e *persistedQueryError) Error() string {
+	return e.message
+}
+
+func (e *persistedQueryError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// PersistedQueryStore stores queries by the hex encoded SHA-256 hash of their text.
+// Implementations must be safe for concurrent use.
+type PersistedQueryStore interface {
+	Get(hash string) (query string, ok bool)
+	Put(hash string, query string)
+}
+
+// LRUPersistedQueryStore is an in-memory PersistedQueryStore that evicts the
+// least recently used query once it holds Size queries.
+type LRUPersistedQueryStore struct {
+	Size int
+
+	entries map[string]*list.Element
+	order   *list.List
+	mutex   sync.Mutex
+}
+
+type lruEntry struct {
+	hash  string
+	query string
+}
+
+// NewLRUPersistedQueryStore returns an LRUPersistedQueryStore holding at most size queries.
+func NewLRUPersistedQueryStore(size int) *LRUPersistedQueryStore {
+	return &LRUPersistedQueryStore{
+		Size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Get returns the query stored for hash, marking it as recently used.
+func (s *LRUPersistedQueryStore) Get(hash string) (string, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	elem, ok := s.entries[hash]
+	if !ok {
+		return "", false
+	}
+	s.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).query, true
+}
+
+// Put stores query for hash, evicting the least recently used query if the store is full.
+func (s *LRUPersistedQueryStore) Put(hash string, query string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if elem, ok := s.entries[hash]; ok {
+		elem.Value.(*lruEntry).query = query
+		s.order.MoveToFront(elem)
+		return
+	}
+	s.entries[hash] = s.order.PushFront(&lruEntry{hash: hash, query: query})
+	for s.Size > 0 && s.order.Len() > s.Size {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).hash)
+	}
+}
+
+// Len returns the number of stored queries.
+func (s *LRUPersistedQueryStore) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.order.Len()
+}
+
+// persistedQueryHash returns the hash of the `persistedQuery` extension of a
+// request, or an empty string if the request does not use persisted queries.
+func persistedQueryHash(opts *RequestOptions) (string, error) {
+	ext, ok := opts.Extensions["persistedQuery"]
+	if !ok {
+		return "", nil
+	}
+	persistedQuery, ok := ext.(map[string]interface{})
+	if !ok {
+		return "", errors.New(`The "persistedQuery" extension must be an object.`)
+	}
+	if version, ok := persistedQuery["version"].(float64); !ok || version != 1 {
+		return "", fmt.Errorf(`Unsupported persisted query version: %v.`, persistedQuery["version"])
+	}
+	hash, ok := persistedQuery["sha256Hash"].(string)
+	if !ok || hash == "" {
+		return "", errors.New(`The "persistedQuery" extension must provide a "sha256Hash".`)
+	}
+	return strings.ToLower(hash), nil
+}
+
+// loadPersistedQuery resolves the query of a request using the `persistedQuery`
+// extension: a request carrying only a hash is given the stored query, and a
+// request carrying both a query and its hash has the hash returned, for
+// persistQuery to register the query once it is known to be valid.
+func (h *Handler) loadPersistedQuery(opts *RequestOptions) (string, error) {
+	hash, err := persistedQueryHash(opts)
+	if err != nil || hash == "" {
+		return "", err
+	}
+	if h.persistedQueries == nil {
+		return "", ErrPersistedQueryNotSupported
+	}
+	if opts.Query == "" {
+		query, ok := h.persistedQueries.Get(hash)
+		if !ok {
+			return "", ErrPersistedQueryNotFound
+		}
+		opts.Query = query
+		return "", nil
+	}
+	sum := sha256.Sum256([]byte(opts.Query))
+	if hex.EncodeToString(sum[:]) != hash {
+		return "", ErrPersistedQueryHashMismatch
+	}
+	return hash, nil
+}
+
+// persistQuery registers a query parsed and validated successfully under the
+// hash returned by loadPersistedQuery, if any.
+func (h *Handler) persistQuery(hash string, query string) {
+	if hash != "" {
+		h.persistedQueries.Put(hash, query)
+	}
+}
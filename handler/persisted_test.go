@@ -0,0 +1,151 @@
+package handler_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/handler"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func sha256Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+func persistedQueryExtensions(hash string) string {
+	return fmt.Sprintf(`{"persistedQuery": {"version": 1, "sha256Hash": "%v"}}`, hash)
+}
+
+func postJSON(h http.Handler, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_PersistedQuery_RegistersAndLooksUpQuery(t *testing.T) {
+	query := "{ hero { name } }"
+	hash := sha256Hash(query)
+	h := handler.New(&handler.Config{
+		Schema: &testutil.StarWarsSchema,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"name": "R2-D2",
+			},
+		},
+	}
+
+	// the hash alone is unknown at first
+	w := postJSON(h, fmt.Sprintf(`{"extensions": %v}`, persistedQueryExtensions(hash)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	result := decodeResponse(t, w)
+	if len(result.Errors) != 1 || result.Errors[0].Message != handler.ErrPersistedQueryNotFound.Error() ||
+		result.Errors[0].Extensions["code"] != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Fatalf("expected PersistedQueryNotFound error, got: %v", result.Errors)
+	}
+
+	// sending the query along with its hash registers it
+	w = postJSON(h, fmt.Sprintf(`{"query": %q, "extensions": %v}`, query, persistedQueryExtensions(hash)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	if result := decodeResponse(t, w); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+
+	// the hash alone is now enough, including over GET
+	target := "/graphql?extensions=" + url.QueryEscape(persistedQueryExtensions(hash))
+	req, _ := http.NewRequest("GET", target, nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	if result := decodeResponse(t, w); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestHandler_PersistedQuery_RejectsHashMismatch(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &testutil.StarWarsSchema,
+	})
+	w := postJSON(h, fmt.Sprintf(`{"query": "{ hero { name } }", "extensions": %v}`, persistedQueryExtensions(sha256Hash("{ hero { id } }"))))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	result := decodeResponse(t, w)
+	if len(result.Errors) != 1 || result.Errors[0].Message != handler.ErrPersistedQueryHashMismatch.Error() {
+		t.Fatalf("expected hash mismatch error, got: %v", result.Errors)
+	}
+}
+
+func TestHandler_PersistedQuery_DoesNotRegisterInvalidQueries(t *testing.T) {
+	store := handler.NewLRUPersistedQueryStore(handler.DefaultPersistedQueryCacheSize)
+	h := handler.New(&handler.Config{
+		Schema:           &testutil.StarWarsSchema,
+		PersistedQueries: store,
+		MaxBatchSize:     10,
+	})
+	for _, query := range []string{"{ hero { name }", "{ hero { unknown } }"} {
+		w := postJSON(h, fmt.Sprintf(`{"query": %q, "extensions": %v}`, query, persistedQueryExtensions(sha256Hash(query))))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("unexpected server response %v", w.Code)
+		}
+		w = postJSON(h, fmt.Sprintf(`[{"query": %q, "extensions": %v}]`, query, persistedQueryExtensions(sha256Hash(query))))
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected server response %v", w.Code)
+		}
+	}
+	if store.Len() != 0 {
+		t.Fatalf("expected no query to be registered, got %v", store.Len())
+	}
+}
+
+func TestHandler_PersistedQuery_Disabled(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema:                  &testutil.StarWarsSchema,
+		DisablePersistedQueries: true,
+	})
+	w := postJSON(h, fmt.Sprintf(`{"extensions": %v}`, persistedQueryExtensions(sha256Hash("{ hero { name } }"))))
+	result := decodeResponse(t, w)
+	if len(result.Errors) != 1 || result.Errors[0].Message != handler.ErrPersistedQueryNotSupported.Error() ||
+		result.Errors[0].Extensions["code"] != "PERSISTED_QUERY_NOT_SUPPORTED" {
+		t.Fatalf("expected PersistedQueryNotSupported error, got: %v", result.Errors)
+	}
+}
+
+func TestLRUPersistedQueryStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := handler.NewLRUPersistedQueryStore(2)
+	store.Put("a", "{ a }")
+	store.Put("b", "{ b }")
+	if _, ok := store.Get("a"); !ok {
+		t.Fatalf("expected query a to be stored")
+	}
+	store.Put("c", "{ c }")
+	if _, ok := store.Get("b"); ok {
+		t.Fatalf("expected query b to be evicted")
+	}
+	for _, hash := range []string{"a", "c"} {
+		if _, ok := store.Get(hash); !ok {
+			t.Fatalf("expected query %v to be stored", hash)
+		}
+	}
+	if store.Len() != 2 {
+		t.Fatalf("unexpected store length %v", store.Len())
+	}
+}
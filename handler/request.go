@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	ContentTypeJSON           = "application/json"
+	ContentTypeGraphQL        = "application/graphql"
+	ContentTypeFormURLEncoded = "application/x-www-form-urlencoded"
+	ContentTypeMultipart      = "multipart/form-data"
+	ContentTypeEventStream    = "text/event-stream"
+	ContentTypeMultipartMixed = "multipart/mixed"
+)
+
+// RequestOptions are the parameters of a single GraphQL operation sent over HTTP.
+type RequestOptions struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+	Extensions    map[string]interface{} `json:"extensions"`
+}
+
+// requestOptionsCompatibility accepts variables and extensions encoded either
+// as an object or as a JSON string, as sent by some clients.
+type requestOptionsCompatibility struct {
+	Query         string      `json:"query"`
+	Variables     interface{} `json:"variables"`
+	OperationName string      `json:"operationName"`
+	Extensions    interface{} `json:"extensions"`
+}
+
+func (opts *requestOptionsCompatibility) requestOptions() (*RequestOptions, error) {
+	variables, err := parseObject("Variables", opts.Variables)
+	if err != nil {
+		return nil, err
+	}
+	extensions, err := parseObject("Extensions", opts.Extensions)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestOptions{
+		Query:         opts.Query,
+		Variables:     variables,
+		OperationName: opts.OperationName,
+		Extensions:    extensions,
+	}, nil
+}
+
+// parseObject decodes a parameter holding a JSON object, either already
+// decoded or as a string.
+func parseObject(name string, value interface{}) (map[string]interface{}, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case map[string]interface{}:
+		return value, nil
+	case string:
+		if value == "" {
+			return nil, nil
+		}
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(value), &obj); err != nil {
+			return nil, fmt.Errorf(`%v are invalid JSON: %v`, name, err)
+		}
+		return obj, nil
+	}
+	return nil, fmt.Errorf("%v must be an object.", name)
+}
+
+func getFromValues(values url.Values) (*RequestOptions, error) {
+	opts := &requestOptionsCompatibility{
+		Query:         values.Get("query"),
+		Variables:     values.Get("variables"),
+		OperationName: values.Get("operationName"),
+		Extensions:    values.Get("extensions"),
+	}
+	return opts.requestOptions()
+}
+
+// contentType returns the media type of the request without its parameters.
+func contentType(r *http.Request) string {
+	contentTypeStr := r.Header.Get("Content-Type")
+	return strings.TrimSpace(strings.ToLower(strings.Split(contentTypeStr, ";")[0]))
+}
+
+// NewRequestOptions parses the GraphQL parameters of a GET or POST request.
+//
+// GET requests read `query`, `variables` and `operationName` from the URL.
+// POST requests are read according to their content type: `application/json`
+// bodies hold the parameters as an object, `application/graphql` bodies hold
+// the query itself and form-encoded bodies hold the parameters as fields.
+// The `query` and `extensions` URL parameters, if present, take precedence in all cases.
+func NewRequestOptions(r *http.Request) (*RequestOptions, error) {
+	if reqOpt, err := getFromValues(r.URL.Query()); err != nil || reqOpt.Query != "" || reqOpt.Extensions != nil {
+		return reqOpt, err
+	}
+
+	if r.Method != "POST" {
+		return &RequestOptions{}, nil
+	}
+
+	switch contentType(r) {
+	case ContentTypeGraphQL:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		return &RequestOptions{
+			Query: string(body),
+		}, nil
+	case ContentTypeFormURLEncoded:
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		return getFromValues(r.PostForm)
+	case ContentTypeJSON, "":
+		var opts requestOptionsCompatibility
+		if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+			return nil, fmt.Errorf("Body is invalid JSON: %v", err)
+		}
+		return opts.requestOptions()
+	}
+	return nil, errUnsupportedContentType
+}
+
+// NewBatchRequestOptions parses the GraphQL parameters of a request that may
+// hold a batch of operations.
+//
+// Only POST requests whose JSON body is an array are batched, in which case
+// isBatch is true; any other request is parsed by NewRequestOptions and yields
+// a single operation.
+func NewBatchRequestOptions(r *http.Request) (opts []*RequestOptions, isBatch bool, err error) {
+	ct := contentType(r)
+	if r.Method != "POST" || (ct != ContentTypeJSON && ct != "") || r.URL.Query().Get("query") != "" || r.URL.Query().Get("extensions") != "" {
+		reqOpt, err := NewRequestOptions(r)
+		if err != nil {
+			return nil, false, err
+		}
+		return []*RequestOptions{reqOpt}, false, nil
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		var compat requestOptionsCompatibility
+		if err := json.Unmarshal(body, &compat); err != nil {
+			return nil, false, fmt.Errorf("Body is invalid JSON: %v", err)
+		}
+		reqOpt, err := compat.requestOptions()
+		if err != nil {
+			return nil, false, err
+		}
+		return []*RequestOptions{reqOpt}, false, nil
+	}
+
+	var batch []requestOptionsCompatibility
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return nil, true, fmt.Errorf("Body is invalid JSON: %v", err)
+	}
+	opts = make([]*RequestOptions, 0, len(batch))
+	for i := range batch {
+		reqOpt, err := batch[i].requestOptions()
+		if err != nil {
+			return nil, true, fmt.Errorf("Operation #%v: %v", i+1, err)
+		}
+		opts = append(opts, reqOpt)
+	}
+	return opts, true, nil
+}
+
+var errUnsupportedContentType = errors.New("Unsupported content type.")
+
+// accepts determines if the Accept header of the request lists the media type.
+func accepts(r *http.Request, mediaType string) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		t, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && t == mediaType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestRequestOptions_GET_BasicQueryString(t *testing.T) {
+	queryString := "query=query RebelsShipsQuery { rebels { name } }"
+	expected := &RequestOptions{
+		Query:     "query RebelsShipsQuery { rebels { name } }",
+		Variables: nil,
+	}
+
+	req, _ := http.NewRequest("GET", "/graphql?"+url.PathEscape(queryString), nil)
+	result, err := NewRequestOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRequestOptions_GET_WithVariablesAsObject(t *testing.T) {
+	variables := url.QueryEscape(`{ "a": 1, "b": "2" }`)
+	query := url.QueryEscape("query RebelsShipsQuery { rebels { name } }")
+	queryString := "query=" + query + "&variables=" + variables + "&operationName=RebelsShipsQuery"
+	expected := &RequestOptions{
+		Query: "query RebelsShipsQuery { rebels { name } }",
+		Variables: map[string]interface{}{
+			"a": float64(1),
+			"b": "2",
+		},
+		OperationName: "RebelsShipsQuery",
+	}
+
+	req, _ := http.NewRequest("GET", "/graphql?"+queryString, nil)
+	result, err := NewRequestOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRequestOptions_GET_WithInvalidVariables(t *testing.T) {
+	queryString := "query=" + url.QueryEscape("{ hero }") + "&variables=" + url.QueryEscape("{ a: 1 }")
+	req, _ := http.NewRequest("GET", "/graphql?"+queryString, nil)
+	if _, err := NewRequestOptions(req); err == nil {
+		t.Fatalf("expected error for invalid variables")
+	}
+}
+
+func TestRequestOptions_POST_ContentTypeApplicationJSON(t *testing.T) {
+	body := `
+	{
+		"query": "query RebelsShipsQuery { rebels { name } }",
+		"variables": { "a": 1, "b": "2" },
+		"operationName": "RebelsShipsQuery"
+	}`
+	expected := &RequestOptions{
+		Query: "query RebelsShipsQuery { rebels { name } }",
+		Variables: map[string]interface{}{
+			"a": float64(1),
+			"b": "2",
+		},
+		OperationName: "RebelsShipsQuery",
+	}
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(body))
+	req.Header.Add("Content-Type", "application/json; charset=utf-8")
+	result, err := NewRequestOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRequestOptions_POST_ContentTypeApplicationJSON_WithVariablesAsString(t *testing.T) {
+	body := `
+	{
+		"query": "query RebelsShipsQuery { rebels { name } }",
+		"variables": "{ \"a\": 1, \"b\": \"2\" }"
+	}`
+	expected := &RequestOptions{
+		Query: "query RebelsShipsQuery { rebels { name } }",
+		Variables: map[string]interface{}{
+			"a": float64(1),
+			"b": "2",
+		},
+	}
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(body))
+	req.Header.Add("Content-Type", "application/json")
+	result, err := NewRequestOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRequestOptions_POST_ContentTypeApplicationJSON_WithInvalidJSON(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(`{ "query": `))
+	req.Header.Add("Content-Type", "application/json")
+	if _, err := NewRequestOptions(req); err == nil {
+		t.Fatalf("expected error for invalid JSON body")
+	}
+}
+
+func TestRequestOptions_POST_ContentTypeApplicationGraphQL(t *testing.T) {
+	body := `query RebelsShipsQuery { rebels { name } }`
+	expected := &RequestOptions{
+		Query: "query RebelsShipsQuery { rebels { name } }",
+	}
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(body))
+	req.Header.Add("Content-Type", "application/graphql")
+	result, err := NewRequestOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRequestOptions_POST_ContentTypeFormURLEncoded(t *testing.T) {
+	data := url.Values{}
+	data.Add("query", "query RebelsShipsQuery { rebels { name } }")
+	data.Add("variables", `{ "a": 1 }`)
+	data.Add("operationName", "RebelsShipsQuery")
+	expected := &RequestOptions{
+		Query: "query RebelsShipsQuery { rebels { name } }",
+		Variables: map[string]interface{}{
+			"a": float64(1),
+		},
+		OperationName: "RebelsShipsQuery",
+	}
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(data.Encode()))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	result, err := NewRequestOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestRequestOptions_POST_UnsupportedContentType(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(`<query />`))
+	req.Header.Add("Content-Type", "application/xml")
+	if _, err := NewRequestOptions(req); err != errUnsupportedContentType {
+		t.Fatalf("expected unsupported content type error, got: %v", err)
+	}
+}
+
+func TestBatchRequestOptions_POST_ContentTypeApplicationJSON(t *testing.T) {
+	body := `
+	[
+		{ "query": "{ a }" },
+		{ "query": "query B($b: Int) { b(b: $b) }", "variables": { "b": 1 }, "operationName": "B" }
+	]`
+	expected := []*RequestOptions{
+		{
+			Query: "{ a }",
+		},
+		{
+			Query: "query B($b: Int) { b(b: $b) }",
+			Variables: map[string]interface{}{
+				"b": float64(1),
+			},
+			OperationName: "B",
+		},
+	}
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(body))
+	req.Header.Add("Content-Type", "application/json")
+	result, isBatch, err := NewBatchRequestOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isBatch {
+		t.Fatalf("expected request to be a batch")
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestBatchRequestOptions_POST_SingleOperation(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/graphql", bytes.NewBufferString(`{ "query": "{ a }" }`))
+	req.Header.Add("Content-Type", "application/json")
+	result, isBatch, err := NewBatchRequestOptions(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if isBatch {
+		t.Fatalf("expected request not to be a batch")
+	}
+	expected := []*RequestOptions{{Query: "{ a }"}}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// DefaultEventStreamKeepAlive is the interval at which keep-alive comments are
+// written to event streams when none is configured.
+const DefaultEventStreamKeepAlive = 12 * time.Second
+
+// serveEventStream executes an operation for a client accepting
+// text/event-stream, following the "distinct connections" mode of the GraphQL
+// over Server-Sent Events protocol: each result is sent as a `next` event,
+// and the end of the operation as a `complete` event.
+//
+// The document of the operation has already been validated. Operations that
+// cannot be executed, such as those with invalid variables, are answered with
+// a JSON response, as for other clients; every result of an operation that
+// started is sent as an event. The operation is cancelled when the client
+// disconnects.
+func (h *Handler) serveEventStream(w http.ResponseWriter, r *http.Request, p graphql.Params, AST *ast.Document) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeErrors(w, http.StatusInternalServerError, errors.New("Streaming is not supported."))
+		return
+	}
+
+	ctx, cancel := cancelOnDisconnect(p.Context, w)
+	defer cancel()
+	p.Context = ctx
+	results, result := graphql.ExecuteSubscription(executeParams(p, AST))
+	if result != nil {
+		h.writeJSON(w, http.StatusBadRequest, result)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeEventStream+"; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(h.eventStreamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ":\n\n")
+		case result, ok := <-results:
+			if !ok {
+				writeEvent(w, "complete", nil)
+				flusher.Flush()
+				return
+			}
+			writeEvent(w, "next", result)
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes a server-sent event carrying the JSON encoding of data, if any.
+func writeEvent(w http.ResponseWriter, event string, data interface{}) {
+	payload := []byte{}
+	if data != nil {
+		payload, _ = json.Marshal(data)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+}
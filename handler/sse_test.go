@@ -0,0 +1,238 @@
+package handler_test
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/handler"
+	"golang.org/x/net/context"
+)
+
+// unsubscribed receives a value whenever a subscription to waiter ends.
+var unsubscribed = make(chan struct{}, 1)
+
+var streamSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hello": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "world", nil
+				},
+			},
+		},
+	}),
+	Subscription: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"counter": &graphql.Field{
+				Type: graphql.Int,
+				Args: graphql.FieldConfigArgument{
+					"to": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+				},
+				Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+					events := make(chan interface{})
+					go func() {
+						defer close(events)
+						for i := 1; i <= p.Args["to"].(int); i++ {
+							select {
+							case events <- i:
+							case <-p.Context.Done():
+								return
+							}
+						}
+					}()
+					return events, nil
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+			"failing": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+					events := make(chan interface{}, 1)
+					events <- 1
+					close(events)
+					return events, nil
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nil, errors.New("failed")
+				},
+			},
+			"waiter": &graphql.Field{
+				Type: graphql.Int,
+				Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+					events := make(chan interface{})
+					go func() {
+						defer close(events)
+						<-p.Context.Done()
+						unsubscribed <- struct{}{}
+					}()
+					return events, nil
+				},
+			},
+		},
+	}),
+})
+
+type event struct {
+	Event string
+	Data  string
+}
+
+func readEvents(body string) []event {
+	events := []event{}
+	for _, block := range strings.Split(body, "\n\n") {
+		e := event{}
+		for _, line := range strings.Split(block, "\n") {
+			if strings.HasPrefix(line, "event: ") {
+				e.Event = strings.TrimPrefix(line, "event: ")
+			}
+			if strings.HasPrefix(line, "data: ") {
+				e.Data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		if e.Event != "" {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func streamRequest(method string, query string) *http.Request {
+	var req *http.Request
+	if method == "GET" {
+		req, _ = http.NewRequest("GET", "/graphql?query="+url.QueryEscape(query), nil)
+	} else {
+		req, _ = http.NewRequest("POST", "/graphql", strings.NewReader(`{"query": "`+query+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	return req
+}
+
+func TestHandler_EventStream_StreamsSubscriptionResults(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &streamSchema,
+	})
+	for _, method := range []string{"GET", "POST"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, streamRequest(method, "subscription { counter(to: 3) }"))
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected server response %v", w.Code)
+		}
+		if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/event-stream") {
+			t.Fatalf("unexpected content type %v", contentType)
+		}
+		expected := []event{
+			{"next", `{"data":{"counter":1}}`},
+			{"next", `{"data":{"counter":2}}`},
+			{"next", `{"data":{"counter":3}}`},
+			{"complete", ""},
+		}
+		if events := readEvents(w.Body.String()); !reflect.DeepEqual(expected, events) {
+			t.Fatalf("%v: expected events %v, got %v", method, expected, events)
+		}
+	}
+}
+
+func TestHandler_EventStream_StreamsQueryResult(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &streamSchema,
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, streamRequest("GET", "{ hello }"))
+	expected := []event{
+		{"next", `{"data":{"hello":"world"}}`},
+		{"complete", ""},
+	}
+	if events := readEvents(w.Body.String()); !reflect.DeepEqual(expected, events) {
+		t.Fatalf("expected events %v, got %v", expected, events)
+	}
+}
+
+func TestHandler_EventStream_RejectsInvalidOperations(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &streamSchema,
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, streamRequest("GET", "subscription { unknown }"))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	if result := decodeResponse(t, w); len(result.Errors) != 1 {
+		t.Fatalf("expected a single error, got: %v", result.Errors)
+	}
+}
+
+func TestHandler_EventStream_RejectsOperationsWithInvalidVariables(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &streamSchema,
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, streamRequest("GET", "subscription ($to: Int!) { counter(to: $to) }"))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	if result := decodeResponse(t, w); len(result.Errors) != 1 {
+		t.Fatalf("expected a single error, got: %v", result.Errors)
+	}
+}
+
+func TestHandler_EventStream_StreamsEventsWithErrors(t *testing.T) {
+	h := handler.New(&handler.Config{
+		Schema: &streamSchema,
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, streamRequest("GET", "subscription { failing }"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected server response %v", w.Code)
+	}
+	events := readEvents(w.Body.String())
+	if len(events) != 2 || events[0].Event != "next" || !strings.Contains(events[0].Data, `"message":"failed"`) ||
+		events[1].Event != "complete" {
+		t.Fatalf("expected an event with errors and a complete event, got %v", events)
+	}
+}
+
+func TestHandler_EventStream_KeepsAliveAndCancelsOnDisconnect(t *testing.T) {
+	server := httptest.NewServer(handler.New(&handler.Config{
+		Schema:               &streamSchema,
+		EventStreamKeepAlive: 10 * time.Millisecond,
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := streamRequest("GET", "subscription { waiter }")
+	req.URL, _ = url.Parse(server.URL + req.URL.String())
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil || line != ":\n" {
+		t.Fatalf("expected a keep-alive comment, got %q, %v", line, err)
+	}
+
+	cancel()
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		t.Fatalf("expected subscription to end once the client disconnected")
+	}
+}
@@ -0,0 +1,226 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/handler"
+	"github.com/graphql-go/graphql/testutil"
+	"github.com/graphql-go/graphql/upload"
+)
+
+var uploadSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"ok": &graphql.Field{
+				Type: graphql.Boolean,
+			},
+		},
+	}),
+	Mutation: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"upload": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"file": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(upload.Scalar),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					file := p.Args["file"].(*upload.File)
+					b, err := ioutil.ReadAll(file)
+					if err != nil {
+						return nil, err
+					}
+					return file.Filename + ":" + string(b), nil
+				},
+			},
+			"uploadMany": &graphql.Field{
+				Type: graphql.NewList(graphql.String),
+				Args: graphql.FieldConfigArgument{
+					"files": &graphql.ArgumentConfig{
+						Type: graphql.NewList(upload.Scalar),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					names := []interface{}{}
+					for _, file := range p.Args["files"].([]interface{}) {
+						names = append(names, file.(*upload.File).Filename)
+					}
+					return names, nil
+				},
+			},
+		},
+	}),
+})
+
+type testFile struct {
+	key      string
+	filename string
+	content  string
+}
+
+func newUploadRequest(t *testing.T, operations, fileMap string, files ...testFile) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("operations", operations)
+	writer.WriteField("map", fileMap)
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.key, file.filename)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		part.Write([]byte(file.content))
+	}
+	writer.Close()
+	req := httptest.NewRequest("POST", "/graphql", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func serveUpload(t *testing.T, h http.Handler, req *http.Request) (int, *graphql.Result) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Code, decodeResponse(t, w)
+}
+
+func TestHandler_UploadSingleFile(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: &uploadSchema, Uploads: &upload.DefaultOptions})
+	req := newUploadRequest(t,
+		`{"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}`,
+		`{"0": ["variables.file"]}`,
+		testFile{"0", "a.txt", "hello"},
+	)
+	code, result := serveUpload(t, h, req)
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status code, got: %v", code)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"upload": "a.txt:hello",
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestHandler_UploadFileList(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: &uploadSchema, Uploads: &upload.DefaultOptions})
+	req := newUploadRequest(t,
+		`{"query": "mutation ($files: [Upload]) { uploadMany(files: $files) }", "variables": {"files": [null, null]}}`,
+		`{"0": ["variables.files.0"], "1": ["variables.files.1"]}`,
+		testFile{"0", "a.txt", "a"},
+		testFile{"1", "b.txt", "b"},
+	)
+	code, result := serveUpload(t, h, req)
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status code, got: %v", code)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"uploadMany": []interface{}{"a.txt", "b.txt"},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestHandler_UploadBatch(t *testing.T) {
+	h := handler.New(&handler.Config{Schema: &uploadSchema, Uploads: &upload.DefaultOptions, MaxBatchSize: 2})
+	req := newUploadRequest(t,
+		`[{"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}, {"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}]`,
+		`{"0": ["0.variables.file"], "1": ["1.variables.file"]}`,
+		testFile{"0", "a.txt", "a"},
+		testFile{"1", "b.txt", "b"},
+	)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code, got: %v", w.Code)
+	}
+	var results []*graphql.Result
+	if err := json.NewDecoder(w.Body).Decode(&results); err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	expected := []*graphql.Result{
+		{Data: map[string]interface{}{"upload": "a.txt:a"}},
+		{Data: map[string]interface{}{"upload": "b.txt:b"}},
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestHandler_UploadRejectsInvalidRequests(t *testing.T) {
+	operations := `{"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}`
+	tests := []struct {
+		name     string
+		options  upload.Options
+		req      *http.Request
+		code     int
+		expected string
+	}{
+		{
+			name: "missing map",
+			req:  newUploadRequest(t, operations, ``),
+			code: http.StatusBadRequest,
+		},
+		{
+			name:     "missing file",
+			req:      newUploadRequest(t, operations, `{"0": ["variables.file"]}`),
+			code:     http.StatusBadRequest,
+			expected: `Multipart request is missing file "0".`,
+		},
+		{
+			name:     "invalid path",
+			req:      newUploadRequest(t, operations, `{"0": ["variables.other.file"]}`, testFile{"0", "a.txt", "a"}),
+			code:     http.StatusBadRequest,
+			expected: `Invalid path "variables.other.file" for file "0": no value at "other"`,
+		},
+		{
+			name:     "too many files",
+			options:  upload.Options{MaxFiles: 1},
+			req:      newUploadRequest(t, operations, `{"0": ["variables.file"], "1": ["variables.file"]}`, testFile{"0", "a.txt", "a"}, testFile{"1", "b.txt", "b"}),
+			code:     http.StatusRequestEntityTooLarge,
+			expected: upload.ErrTooManyFiles.Error(),
+		},
+		{
+			name:     "file too large",
+			options:  upload.Options{MaxFileSize: 4},
+			req:      newUploadRequest(t, operations, `{"0": ["variables.file"]}`, testFile{"0", "a.txt", "hello"}),
+			code:     http.StatusRequestEntityTooLarge,
+			expected: upload.ErrFileTooLarge.Error(),
+		},
+		{
+			name:     "body too large",
+			options:  upload.Options{MaxBodySize: 16},
+			req:      newUploadRequest(t, operations, `{"0": ["variables.file"]}`, testFile{"0", "a.txt", "hello"}),
+			code:     http.StatusRequestEntityTooLarge,
+			expected: upload.ErrBodyTooLarge.Error(),
+		},
+	}
+	for _, test := range tests {
+		h := handler.New(&handler.Config{Schema: &uploadSchema, Uploads: &test.options})
+		code, result := serveUpload(t, h, test.req)
+		if code != test.code {
+			t.Fatalf("%v: unexpected status code, got: %v, expected: %v", test.name, code, test.code)
+		}
+		if len(result.Errors) != 1 {
+			t.Fatalf("%v: expected one error, got: %v", test.name, result.Errors)
+		}
+		if test.expected != "" && result.Errors[0].Message != test.expected {
+			t.Fatalf("%v: unexpected error, got: %v, expected: %v", test.name, result.Errors[0].Message, test.expected)
+		}
+	}
+}
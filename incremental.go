@@ -0,0 +1,448 @@
+package graphql
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"golang.org/x/net/context"
+)
+
+// IncrementalResult is a payload of a result delivered incrementally, in the
+// format of the GraphQL incremental delivery proposal.
+//
+// The initial payload holds the data and errors of the operation, except for
+// deferred fragments and streamed list items, which subsequent payloads hold
+// in Incremental. HasNext is false for the last payload, which holds nothing
+// else if the payloads still expected were dropped.
+type IncrementalResult struct {
+	Data        interface{}
+	Errors      []gqlerrors.FormattedError
+	Incremental []*IncrementalPayload
+	HasNext     bool
+
+	// subsequent tells the payloads following the initial one, which carry
+	// Incremental rather than Data.
+	subsequent bool
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *IncrementalResult) MarshalJSON() ([]byte, error) {
+	payload := map[string]interface{}{
+		"hasNext": r.HasNext,
+	}
+	if r.subsequent {
+		if len(r.Incremental) > 0 {
+			payload["incremental"] = r.Incremental
+		}
+		return json.Marshal(payload)
+	}
+	payload["data"] = r.Data
+	if len(r.Errors) > 0 {
+		payload["errors"] = r.Errors
+	}
+	return json.Marshal(payload)
+}
+
+// IncrementalPayload holds either the data of a fragment deferred with
+// @defer, or an item of a list field streamed with @stream, along with its
+// path in the response and the label given to the directive.
+//
+// The payloads of deferred fragments and streamed items whose path was nulled
+// by an error, in the payload they belong to, are not delivered.
+type IncrementalPayload struct {
+	Data   interface{}
+	Items  []interface{}
+	Path   []interface{}
+	Label  string
+	Errors []gqlerrors.FormattedError
+
+	// isStream tells the payloads of streamed items, which carry Items rather
+	// than Data.
+	isStream bool
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *IncrementalPayload) MarshalJSON() ([]byte, error) {
+	payload := map[string]interface{}{
+		"path": r.Path,
+	}
+	if r.isStream {
+		payload["items"] = r.Items
+	} else {
+		payload["data"] = r.Data
+	}
+	if r.Label != "" {
+		payload["label"] = r.Label
+	}
+	if len(r.Errors) > 0 {
+		payload["errors"] = r.Errors
+	}
+	return json.Marshal(payload)
+}
+
+// DoIncremental is like Do, but delivers the result of operations using the
+// @defer and @stream directives incrementally: the initial payload is sent
+// on the returned channel as soon as it is complete, followed by a payload
+// for each deferred fragment and streamed list item. The channel is closed
+// after the last payload, or once the context of the request is done.
+//
+// Operations that do not use these directives are answered with a single
+// payload. Do, on the other hand, delivers deferred fragments and streamed
+// list items along with the rest of the data.
+func DoIncremental(p Params) chan *IncrementalResult {
+	AST, result := ParseAndValidate(p)
+	if result != nil {
+		return sendIncrementalResult(&IncrementalResult{
+			Errors: result.Errors,
+		})
+	}
+
+	return ExecuteIncremental(ExecuteParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           AST,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		Context:       p.Context,
+	})
+}
+
+// ExecuteIncremental is to DoIncremental what Execute is to Do: it executes
+// an operation of a document that has already been validated.
+func ExecuteIncremental(p ExecuteParams) chan *IncrementalResult {
+	eCtx, err := buildExecutionContext(BuildExecutionCtxParams{
+		Schema:        p.Schema,
+		Root:          p.Root,
+		AST:           p.AST,
+		OperationName: p.OperationName,
+		Args:          p.Args,
+		Context:       p.Context,
+	})
+	if err != nil {
+		return sendIncrementalResult(&IncrementalResult{
+			Errors: gqlerrors.FormatErrors(err),
+		})
+	}
+
+	return executeIncremental(eCtx, p.Root, p.Context)
+}
+
+// executeIncremental executes the operation of the context, sending the
+// initial payload and then those of its deferred fragments and streamed list
+// items on the returned channel.
+func executeIncremental(eCtx *ExecutionContext, root interface{}, ctx context.Context) chan *IncrementalResult {
+	publisher := &incrementalPublisher{
+		payloads: make(chan *IncrementalPayload),
+		done:     make(chan struct{}),
+	}
+	eCtx.incremental = publisher
+	eCtx.published = make(chan struct{})
+
+	var done <-chan struct{}
+	if ctx != nil {
+		done = ctx.Done()
+	}
+
+	results := make(chan *IncrementalResult)
+	go func() {
+		defer close(results)
+		defer close(publisher.done)
+
+		initial := executeRecoveringPanics(eCtx, root)
+		close(eCtx.published)
+		publisher.mutex.Lock()
+		// subsequent payloads are dropped if the initial data is null
+		hasNext := publisher.pending > 0 && initial.Data != nil
+		publisher.mutex.Unlock()
+
+		payload := &IncrementalResult{
+			Data:    initial.Data,
+			Errors:  initial.Errors,
+			HasNext: hasNext,
+		}
+		for {
+			if payload != nil {
+				select {
+				case results <- payload:
+				case <-done:
+					return
+				}
+			}
+			if !hasNext {
+				return
+			}
+			var incremental *IncrementalPayload
+			select {
+			case incremental = <-publisher.payloads:
+			case <-done:
+				return
+			}
+			publisher.mutex.Lock()
+			publisher.pending--
+			hasNext = publisher.pending > 0
+			publisher.mutex.Unlock()
+
+			// dropped payloads are skipped, unless the end of the result is
+			// still to be sent
+			payload = nil
+			if incremental != nil || !hasNext {
+				payload = &IncrementalResult{
+					HasNext:    hasNext,
+					subsequent: true,
+				}
+			}
+			if incremental != nil {
+				payload.Incremental = []*IncrementalPayload{incremental}
+			}
+		}
+	}()
+	return results
+}
+
+// sendIncrementalResult returns a closed channel holding a single payload.
+func sendIncrementalResult(result *IncrementalResult) chan *IncrementalResult {
+	results := make(chan *IncrementalResult, 1)
+	results <- result
+	close(results)
+	return results
+}
+
+// incrementalPublisher runs the deferred fragments and streamed list items of
+// an operation executed with ExecuteIncremental, and hands their payloads
+// over to be sent after the initial one.
+type incrementalPublisher struct {
+	// mutex protects pending, the number of payloads yet to be sent. It is
+	// incremented before the work producing a payload starts, so that it
+	// never drops to zero while some work is in progress.
+	mutex   sync.Mutex
+	pending int
+
+	// payloads receives the payloads to send, or nil for those dropped.
+	payloads chan *IncrementalPayload
+
+	// done is closed once no more payloads are expected.
+	done chan struct{}
+}
+
+// deferredFragment is a fragment spread or inline fragment using @defer.
+type deferredFragment struct {
+	label        string
+	selectionSet *ast.SelectionSet
+}
+
+func (pub *incrementalPublisher) add(n int) {
+	pub.mutex.Lock()
+	pub.pending += n
+	pub.mutex.Unlock()
+}
+
+// publish hands over the payload produced with eCtx to be sent, once the
+// payload of the parent context it was forked from has been. The payload is
+// dropped if the parent payload was, or if its path was nulled in it.
+func (pub *incrementalPublisher) publish(parent *ExecutionContext, eCtx *ExecutionContext, payload *IncrementalPayload) {
+	select {
+	case <-parent.published:
+	case <-pub.done:
+		return
+	}
+	if parent.dropped || parent.nulled(payload.Path) {
+		eCtx.dropped = true
+		payload = nil
+	}
+	select {
+	case pub.payloads <- payload:
+		close(eCtx.published)
+	case <-pub.done:
+	}
+}
+
+// forkPayload returns a context to execute the part of the operation
+// delivered in a subsequent payload with.
+func forkPayload(eCtx *ExecutionContext) *ExecutionContext {
+	child := eCtx.fork()
+	child.published = make(chan struct{})
+	return child
+}
+
+// deferFragments executes the deferred fragments selected on an object in the
+// background, each producing a subsequent payload.
+func (pub *incrementalPublisher) deferFragments(eCtx *ExecutionContext, runtimeType *Object, source interface{}, path *ResponsePath, deferred []*deferredFragment) {
+	if pub == nil {
+		return
+	}
+	for _, fragment := range deferred {
+		pub.add(1)
+		go func(fragment *deferredFragment) {
+			child := forkPayload(eCtx)
+			pub.publish(eCtx, child, executeDeferredFragment(child, runtimeType, source, path, fragment))
+		}(fragment)
+	}
+}
+
+func executeDeferredFragment(eCtx *ExecutionContext, runtimeType *Object, source interface{}, path *ResponsePath, fragment *deferredFragment) (payload *IncrementalPayload) {
+	payload = &IncrementalPayload{
+		Path:  path.AsArray(),
+		Label: fragment.label,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if err, ok := r.(error); ok {
+				eCtx.AppendError(gqlerrors.FormatError(err))
+			}
+			payload.Data = nil
+			payload.Errors = eCtx.Errors()
+			eCtx.nullify(path)
+		}
+	}()
+
+	deferred := []*deferredFragment{}
+	fields := collectFields(CollectFieldsParams{
+		ExeContext:   eCtx,
+		RuntimeType:  runtimeType,
+		SelectionSet: fragment.selectionSet,
+		deferred:     &deferred,
+	})
+	eCtx.incremental.deferFragments(eCtx, runtimeType, source, path, deferred)
+	result := executeFields(ExecuteFieldsParams{
+		ExecutionContext: eCtx,
+		ParentType:       runtimeType,
+		Source:           source,
+		Fields:           fields,
+		Path:             path,
+	})
+	payload.Data = result.Data
+	payload.Errors = result.Errors
+	return payload
+}
+
+// streamItems completes the items of a streamed list from the given index on
+// in the background, each producing a subsequent payload. A null item of a
+// list of non-null items ends the stream.
+func (pub *incrementalPublisher) streamItems(eCtx *ExecutionContext, itemType Type, fieldASTs []*ast.Field, info ResolveInfo, items reflect.Value, start int, label string) {
+	pub.add(items.Len() - start)
+	go func() {
+		for i := start; i < items.Len(); i++ {
+			select {
+			case <-pub.done:
+				return
+			default:
+			}
+			itemInfo := info
+			itemInfo.Path = info.Path.WithKey(i)
+			child := forkPayload(eCtx)
+			payload, ok := completeStreamItem(child, itemType, fieldASTs, itemInfo, items.Index(i).Interface(), label)
+			if !ok {
+				// the remaining items are not delivered
+				pub.add(i + 1 - items.Len())
+			}
+			pub.publish(eCtx, child, payload)
+			if !ok {
+				return
+			}
+		}
+	}()
+}
+
+func completeStreamItem(eCtx *ExecutionContext, itemType Type, fieldASTs []*ast.Field, info ResolveInfo, item interface{}, label string) (payload *IncrementalPayload, ok bool) {
+	payload = &IncrementalPayload{
+		Path:     info.Path.AsArray(),
+		Label:    label,
+		isStream: true,
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if err, isError := r.(error); isError {
+				eCtx.AppendError(gqlerrors.FormatError(err))
+			}
+			payload.Items = nil
+			payload.Errors = eCtx.Errors()
+			eCtx.nullify(info.Path)
+			ok = false
+		}
+	}()
+
+	completed := completeValueCatchingError(eCtx, itemType, fieldASTs, info, item)
+	payload.Items = []interface{}{completed}
+	payload.Errors = eCtx.Errors()
+	return payload, true
+}
+
+// nullify records that the value at the path was nulled by an error, when
+// executing incrementally.
+func (eCtx *ExecutionContext) nullify(path *ResponsePath) {
+	if eCtx.incremental == nil {
+		return
+	}
+	eCtx.errMutex.Lock()
+	eCtx.nulledPaths = append(eCtx.nulledPaths, path.AsArray())
+	eCtx.errMutex.Unlock()
+}
+
+// nulled reports whether the value at the path, or one of its parents, was
+// nulled by an error.
+func (eCtx *ExecutionContext) nulled(path []interface{}) bool {
+	eCtx.errMutex.RLock()
+	defer eCtx.errMutex.RUnlock()
+	for _, nulledPath := range eCtx.nulledPaths {
+		if len(nulledPath) > len(path) {
+			continue
+		}
+		prefix := true
+		for i, key := range nulledPath {
+			if path[i] != key {
+				prefix = false
+				break
+			}
+		}
+		if prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// deferDirective returns the label of the @defer directive among the
+// directives of a fragment, and whether the fragment is to be deferred.
+func deferDirective(eCtx *ExecutionContext, directives []*ast.Directive) (string, bool) {
+	directive := findDirective(directives, DeferDirective.Name)
+	if directive == nil {
+		return "", false
+	}
+	args, _ := getArgumentValues(DeferDirective.Args, directive.Arguments, eCtx.VariableValues)
+	if deferIf, ok := args["if"].(bool); ok && !deferIf {
+		return "", false
+	}
+	label, _ := args["label"].(string)
+	return label, true
+}
+
+// streamDirective returns the label and initial count of the @stream
+// directive of a field, and whether the field is to be streamed.
+func streamDirective(eCtx *ExecutionContext, fieldAST *ast.Field) (string, int, bool) {
+	directive := findDirective(fieldAST.Directives, StreamDirective.Name)
+	if directive == nil {
+		return "", 0, false
+	}
+	args, _ := getArgumentValues(StreamDirective.Args, directive.Arguments, eCtx.VariableValues)
+	if streamIf, ok := args["if"].(bool); ok && !streamIf {
+		return "", 0, false
+	}
+	label, _ := args["label"].(string)
+	initialCount, _ := args["initialCount"].(int)
+	if initialCount < 0 {
+		initialCount = 0
+	}
+	return label, initialCount, true
+}
+
+func findDirective(directives []*ast.Directive, name string) *ast.Directive {
+	for _, directive := range directives {
+		if directive != nil && directive.Name != nil && directive.Name.Value == name {
+			return directive
+		}
+	}
+	return nil
+}
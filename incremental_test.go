@@ -0,0 +1,310 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+	"golang.org/x/net/context"
+)
+
+type incrementalCharacter struct {
+	ID      string
+	Name    string
+	Friends []*incrementalCharacter
+}
+
+var incrementalHero = &incrementalCharacter{
+	ID:   "1",
+	Name: "Luke",
+	Friends: []*incrementalCharacter{
+		{ID: "2", Name: "Han"},
+		{ID: "3", Name: "Leia"},
+		{ID: "4", Name: "C-3PO"},
+	},
+}
+
+var incrementalSchema = func() graphql.Schema {
+	characterType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Character",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.ID),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*incrementalCharacter).ID, nil
+				},
+			},
+			"name": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*incrementalCharacter).Name, nil
+				},
+			},
+			"failing": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nil, errors.New("failed")
+				},
+			},
+			"nonNullMissing": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return nil, nil
+				},
+			},
+		},
+	})
+	characterType.AddFieldConfig("friends", &graphql.Field{
+		Type: graphql.NewList(characterType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source.(*incrementalCharacter).Friends, nil
+		},
+	})
+	characterType.AddFieldConfig("nonNullFriends", &graphql.Field{
+		Type: graphql.NewList(graphql.NewNonNull(characterType)),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			friends := p.Source.(*incrementalCharacter).Friends
+			return []interface{}{friends[0], nil, friends[2]}, nil
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hero": &graphql.Field{
+					Type: characterType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return incrementalHero, nil
+					},
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{
+			graphql.IncludeDirective,
+			graphql.SkipDirective,
+			graphql.DeferDirective,
+			graphql.StreamDirective,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return schema
+}()
+
+// doIncremental executes the query and returns the JSON encoding of each payload.
+func doIncremental(t *testing.T, query string) []string {
+	payloads := []string{}
+	for payload := range graphql.DoIncremental(graphql.Params{
+		Schema:        incrementalSchema,
+		RequestString: query,
+		Context:       context.Background(),
+	}) {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		payloads = append(payloads, string(b))
+	}
+	return payloads
+}
+
+func expectPayloads(t *testing.T, expected []string, payloads []string) {
+	if !reflect.DeepEqual(expected, payloads) {
+		t.Fatalf("wrong payloads, diff: %v", testutil.Diff(expected, payloads))
+	}
+}
+
+func TestDoIncremental_DefersInlineFragment(t *testing.T) {
+	payloads := doIncremental(t, `{ hero { id ... @defer { name } } }`)
+	expectPayloads(t, []string{
+		`{"data":{"hero":{"id":"1"}},"hasNext":true}`,
+		`{"hasNext":false,"incremental":[{"data":{"name":"Luke"},"path":["hero"]}]}`,
+	}, payloads)
+}
+
+func TestDoIncremental_DefersLabeledFragmentSpread(t *testing.T) {
+	payloads := doIncremental(t, `
+		{ hero { id ...HeroName @defer(label: "heroName") } }
+		fragment HeroName on Character { name }
+	`)
+	expectPayloads(t, []string{
+		`{"data":{"hero":{"id":"1"}},"hasNext":true}`,
+		`{"hasNext":false,"incremental":[{"data":{"name":"Luke"},"label":"heroName","path":["hero"]}]}`,
+	}, payloads)
+}
+
+func TestDoIncremental_DeliversNestedDeferredFragmentsAfterTheirParent(t *testing.T) {
+	payloads := doIncremental(t, `{
+		hero {
+			id
+			... @defer(label: "friends") {
+				friends {
+					id
+					... @defer(label: "friendName") { name }
+				}
+			}
+		}
+	}`)
+	if len(payloads) != 5 {
+		t.Fatalf("expected 5 payloads, got %v", payloads)
+	}
+	expectPayloads(t, []string{
+		`{"data":{"hero":{"id":"1"}},"hasNext":true}`,
+		`{"hasNext":true,"incremental":[{"data":{"friends":[{"id":"2"},{"id":"3"},{"id":"4"}]},"label":"friends","path":["hero"]}]}`,
+	}, payloads[:2])
+
+	// the names of the friends may be delivered in any order
+	names := map[string]bool{}
+	for _, payload := range payloads[2:] {
+		names[strings.Replace(payload, `"hasNext":false`, `"hasNext":true`, 1)] = true
+	}
+	expected := map[string]bool{
+		`{"hasNext":true,"incremental":[{"data":{"name":"Han"},"label":"friendName","path":["hero","friends",0]}]}`:   true,
+		`{"hasNext":true,"incremental":[{"data":{"name":"Leia"},"label":"friendName","path":["hero","friends",1]}]}`:  true,
+		`{"hasNext":true,"incremental":[{"data":{"name":"C-3PO"},"label":"friendName","path":["hero","friends",2]}]}`: true,
+	}
+	if !reflect.DeepEqual(expected, names) {
+		t.Fatalf("wrong payloads, diff: %v", testutil.Diff(expected, names))
+	}
+	if !strings.Contains(payloads[4], `"hasNext":false`) {
+		t.Fatalf("expected the last payload to have no next, got %v", payloads[4])
+	}
+}
+
+func TestDoIncremental_StreamsListItemsAfterInitialCount(t *testing.T) {
+	payloads := doIncremental(t, `{ hero { friends @stream(initialCount: 1, label: "friends") { name } } }`)
+	expectPayloads(t, []string{
+		`{"data":{"hero":{"friends":[{"name":"Han"}]}},"hasNext":true}`,
+		`{"hasNext":true,"incremental":[{"items":[{"name":"Leia"}],"label":"friends","path":["hero","friends",1]}]}`,
+		`{"hasNext":false,"incremental":[{"items":[{"name":"C-3PO"}],"label":"friends","path":["hero","friends",2]}]}`,
+	}, payloads)
+}
+
+func TestDoIncremental_EndsStreamOnNullNonNullItem(t *testing.T) {
+	payloads := doIncremental(t, `{ hero { nonNullFriends @stream { name } } }`)
+	expected := []string{
+		`{"data":{"hero":{"nonNullFriends":[]}},"hasNext":true}`,
+		`{"hasNext":true,"incremental":[{"items":[{"name":"Han"}],"path":["hero","nonNullFriends",0]}]}`,
+		`{"hasNext":false,"incremental":[{"errors":[{"message":"Cannot return null for non-nullable field Character.nonNullFriends.","locations":[{"line":1,"column":10}]}],"items":null,"path":["hero","nonNullFriends",1]}]}`,
+	}
+	expectPayloads(t, expected, payloads)
+}
+
+func TestDoIncremental_InlinesFragmentsNotToBeDeferred(t *testing.T) {
+	payloads := doIncremental(t, `{
+		hero {
+			id
+			... @defer(if: false) { name }
+			friends @stream(if: false) { id }
+		}
+	}`)
+	expectPayloads(t, []string{
+		`{"data":{"hero":{"friends":[{"id":"2"},{"id":"3"},{"id":"4"}],"id":"1","name":"Luke"}},"hasNext":false}`,
+	}, payloads)
+}
+
+func TestDoIncremental_ReportsErrorsOfDeferredFragments(t *testing.T) {
+	payloads := doIncremental(t, `{ hero { id ... @defer { failing } } }`)
+	expectPayloads(t, []string{
+		`{"data":{"hero":{"id":"1"}},"hasNext":true}`,
+		`{"hasNext":false,"incremental":[{"data":{"failing":null},"errors":[{"message":"failed","locations":[]}],"path":["hero"]}]}`,
+	}, payloads)
+}
+
+func TestDoIncremental_NullsDeferredFragmentOnNonNullError(t *testing.T) {
+	payloads := doIncremental(t, `{ hero { id ... @defer { name nonNullMissing } } }`)
+	expectPayloads(t, []string{
+		`{"data":{"hero":{"id":"1"}},"hasNext":true}`,
+		`{"hasNext":false,"incremental":[{"data":null,"errors":[{"message":"Cannot return null for non-nullable field Character.nonNullMissing.","locations":[{"line":1,"column":31}]}],"path":["hero"]}]}`,
+	}, payloads)
+}
+
+func TestDo_DeliversDeferredAndStreamedDataAlongWithTheRest(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        incrementalSchema,
+		RequestString: `{ hero { id ... @defer { name } friends @stream(initialCount: 1) { id } } }`,
+	})
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"hero": map[string]interface{}{
+				"id":   "1",
+				"name": "Luke",
+				"friends": []interface{}{
+					map[string]interface{}{"id": "2"},
+					map[string]interface{}{"id": "3"},
+					map[string]interface{}{"id": "4"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestDoIncremental_AnswersInvalidRequestsWithASinglePayload(t *testing.T) {
+	payloads := doIncremental(t, `{ hero { name @stream } }`)
+	expectPayloads(t, []string{
+		`{"data":null,"errors":[{"message":"Directive \"stream\" cannot be used on non-list field \"name\".","locations":[{"line":1,"column":15}]}],"hasNext":false}`,
+	}, payloads)
+}
+
+func TestDoIncremental_DropsDeferredFragmentsOfNulledObjects(t *testing.T) {
+	payloads := doIncremental(t, `{ hero { ... @defer { name } nonNullMissing } }`)
+	expectPayloads(t, []string{
+		`{"data":{"hero":null},"errors":[{"message":"Cannot return null for non-nullable field Character.nonNullMissing.","locations":[{"line":1,"column":30}]}],"hasNext":true}`,
+		`{"hasNext":false}`,
+	}, payloads)
+}
+
+func TestDoIncremental_DropsNestedPayloadsUnderPathsNulledInTheirParent(t *testing.T) {
+	payloads := doIncremental(t, `{ hero { id ... @defer { nonNullFriends { ... @defer { name } } } } }`)
+	expectPayloads(t, []string{
+		`{"data":{"hero":{"id":"1"}},"hasNext":true}`,
+		`{"hasNext":true,"incremental":[{"data":{"nonNullFriends":null},"errors":[{"message":"Cannot return null for non-nullable field Character.nonNullFriends.","locations":[{"line":1,"column":26}]}],"path":["hero"]}]}`,
+		`{"hasNext":false}`,
+	}, payloads)
+}
+
+func TestPreparedQuery_ExecutesIncrementally(t *testing.T) {
+	query := `{ hero { id ... @defer { name } friends @stream(initialCount: 2) { id } } }`
+	prepared, err := graphql.Prepare(incrementalSchema, parseDocument(t, query), "")
+	if err != nil {
+		t.Fatalf("unexpected error preparing query: %v", err)
+	}
+	expected := doIncremental(t, query)
+	for i := 0; i < 2; i++ {
+		payloads := []string{}
+		for payload := range prepared.ExecuteIncremental(graphql.PreparedParams{Context: context.Background()}) {
+			b, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			payloads = append(payloads, string(b))
+		}
+		if len(payloads) != 3 {
+			t.Fatalf("expected 3 payloads, got %v", payloads)
+		}
+		// the deferred fragment and the streamed item may be delivered in any order
+		if payloads[0] != expected[0] || !reflect.DeepEqual(sortedPayloads(payloads[1:]), sortedPayloads(expected[1:])) {
+			t.Fatalf("wrong payloads, diff: %v", testutil.Diff(expected, payloads))
+		}
+	}
+}
+
+// sortedPayloads returns the subsequent payloads sorted, ignoring whether
+// each of them is the last one.
+func sortedPayloads(payloads []string) []string {
+	sorted := []string{}
+	for _, payload := range payloads {
+		sorted = append(sorted, strings.Replace(payload, `"hasNext":false`, `"hasNext":true`, 1))
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -3,6 +3,7 @@ package graphql
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/printer"
@@ -114,6 +115,54 @@ func init() {
 				Value:       DirectiveLocationInlineFragment,
 				Description: "Location adjacent to an inline fragment.",
 			},
+			"VARIABLE_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationVariableDefinition,
+				Description: "Location adjacent to a variable definition.",
+			},
+			"SCHEMA": &EnumValueConfig{
+				Value:       DirectiveLocationSchema,
+				Description: "Location adjacent to a schema definition.",
+			},
+			"SCALAR": &EnumValueConfig{
+				Value:       DirectiveLocationScalar,
+				Description: "Location adjacent to a scalar definition.",
+			},
+			"OBJECT": &EnumValueConfig{
+				Value:       DirectiveLocationObject,
+				Description: "Location adjacent to an object type definition.",
+			},
+			"FIELD_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationFieldDefinition,
+				Description: "Location adjacent to a field definition.",
+			},
+			"ARGUMENT_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationArgumentDefinition,
+				Description: "Location adjacent to an argument definition.",
+			},
+			"INTERFACE": &EnumValueConfig{
+				Value:       DirectiveLocationInterface,
+				Description: "Location adjacent to an interface definition.",
+			},
+			"UNION": &EnumValueConfig{
+				Value:       DirectiveLocationUnion,
+				Description: "Location adjacent to a union definition.",
+			},
+			"ENUM": &EnumValueConfig{
+				Value:       DirectiveLocationEnum,
+				Description: "Location adjacent to an enum definition.",
+			},
+			"ENUM_VALUE": &EnumValueConfig{
+				Value:       DirectiveLocationEnumValue,
+				Description: "Location adjacent to an enum value definition.",
+			},
+			"INPUT_OBJECT": &EnumValueConfig{
+				Value:       DirectiveLocationInputObject,
+				Description: "Location adjacent to an input object type definition.",
+			},
+			"INPUT_FIELD_DEFINITION": &EnumValueConfig{
+				Value:       DirectiveLocationInputFieldDefinition,
+				Description: "Location adjacent to an input object field definition.",
+			},
 		},
 	})
 
@@ -209,6 +258,21 @@ func init() {
 					return nil, nil
 				},
 			},
+			"isDeprecated": &Field{
+				Type: NewNonNull(Boolean),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					switch inputVal := p.Source.(type) {
+					case *Argument:
+						return (inputVal.DeprecationReason != ""), nil
+					case *InputObjectField:
+						return (inputVal.DeprecationReason != ""), nil
+					}
+					return false, nil
+				},
+			},
+			"deprecationReason": &Field{
+				Type: String,
+			},
 		},
 	})
 
@@ -225,9 +289,16 @@ func init() {
 			},
 			"args": &Field{
 				Type: NewNonNull(NewList(NewNonNull(inputValueType))),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
 				Resolve: func(p ResolveParams) (interface{}, error) {
+					includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
 					if field, ok := p.Source.(*FieldDefinition); ok {
-						return field.Args, nil
+						return filterDeprecatedArgs(field.Args, includeDeprecated), nil
 					}
 					return []interface{}{}, nil
 				},
@@ -274,6 +345,19 @@ func init() {
 				Type: NewNonNull(NewList(
 					NewNonNull(inputValueType),
 				)),
+				Args: FieldConfigArgument{
+					"includeDeprecated": &ArgumentConfig{
+						Type:         Boolean,
+						DefaultValue: false,
+					},
+				},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
+					if dir, ok := p.Source.(*Directive); ok {
+						return filterDeprecatedArgs(dir.Args, includeDeprecated), nil
+					}
+					return []interface{}{}, nil
+				},
 			},
 			// NOTE: the following three fields are deprecated and are no longer part
 			// of the GraphQL specification.
@@ -527,11 +611,21 @@ func init() {
 	})
 	typeType.AddFieldConfig("inputFields", &Field{
 		Type: NewList(NewNonNull(inputValueType)),
+		Args: FieldConfigArgument{
+			"includeDeprecated": &ArgumentConfig{
+				Type:         Boolean,
+				DefaultValue: false,
+			},
+		},
 		Resolve: func(p ResolveParams) (interface{}, error) {
+			includeDeprecated, _ := p.Args["includeDeprecated"].(bool)
 			switch ttype := p.Source.(type) {
 			case *InputObject:
 				fields := []*InputObjectField{}
 				for _, field := range ttype.Fields() {
+					if !includeDeprecated && field.DeprecationReason != "" {
+						continue
+					}
 					fields = append(fields, field)
 				}
 				return fields, nil
@@ -644,8 +738,44 @@ func astFromValue(value interface{}, ttype Type) ast.Value {
 		return val
 	}
 
-	if valueVal.Type().Kind() == reflect.Map {
-		// TODO: implement astFromValue from Map to Value
+	if ttype, ok := ttype.(*InputObject); ok {
+		value, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fieldNames := []string{}
+		for fieldName := range ttype.Fields() {
+			if _, ok := value[fieldName]; ok {
+				fieldNames = append(fieldNames, fieldName)
+			}
+		}
+		sort.Strings(fieldNames)
+		fields := []*ast.ObjectField{}
+		for _, fieldName := range fieldNames {
+			fieldValue := astFromValue(value[fieldName], ttype.Fields()[fieldName].Type)
+			if fieldValue == nil {
+				fieldValue = ast.NewNullValue(&ast.NullValue{})
+			}
+			fields = append(fields, ast.NewObjectField(&ast.ObjectField{
+				Name: ast.NewName(&ast.Name{
+					Value: fieldName,
+				}),
+				Value: fieldValue,
+			}))
+		}
+		return ast.NewObjectValue(&ast.ObjectValue{
+			Fields: fields,
+		})
+	}
+
+	// Enum values are printed by name, given either their internal value or
+	// their name.
+	if ttype, ok := ttype.(*Enum); ok {
+		if name, ok := ttype.Serialize(value).(string); ok {
+			return ast.NewEnumValue(&ast.EnumValue{
+				Value: name,
+			})
+		}
 	}
 
 	if value, ok := value.(bool); ok {
@@ -690,3 +820,18 @@ func astFromValue(value interface{}, ttype Type) ast.Value {
 		Value: fmt.Sprintf("%v", value),
 	})
 }
+
+// filterDeprecatedArgs returns the arguments that are not deprecated, or all of
+// them if includeDeprecated is true.
+func filterDeprecatedArgs(args []*Argument, includeDeprecated bool) []*Argument {
+	if includeDeprecated {
+		return args
+	}
+	filtered := []*Argument{}
+	for _, arg := range args {
+		if arg.DeprecationReason == "" {
+			filtered = append(filtered, arg)
+		}
+	}
+	return filtered
+}
@@ -763,6 +763,11 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 							"isDeprecated":      false,
 							"deprecationReason": nil,
 						},
+						map[string]interface{}{
+							"name":              "VARIABLE_DEFINITION",
+							"isDeprecated":      false,
+							"deprecationReason": nil,
+						},
 					},
 					"possibleTypes": nil,
 				},
@@ -822,6 +827,30 @@ func TestIntrospection_ExecutesAnIntrospectionQuery(t *testing.T) {
 					"onFragment":  true,
 					"onField":     true,
 				},
+				map[string]interface{}{
+					"name": "deprecated",
+					"locations": []interface{}{
+						"FIELD_DEFINITION",
+						"ARGUMENT_DEFINITION",
+						"INPUT_FIELD_DEFINITION",
+						"ENUM_VALUE",
+					},
+					"args": []interface{}{
+						map[string]interface{}{
+							"defaultValue": `"No longer supported"`,
+							"name":         "reason",
+							"type": map[string]interface{}{
+								"kind":   "SCALAR",
+								"name":   "String",
+								"ofType": nil,
+							},
+						},
+					},
+					// deprecated, but included for coverage till removed
+					"onOperation": false,
+					"onFragment":  false,
+					"onField":     false,
+				},
 			},
 		},
 	}
@@ -1187,6 +1216,233 @@ func TestIntrospection_IdentifiesDeprecatedEnumValues(t *testing.T) {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+func TestIntrospection_IdentifiesElementsDeprecatedWithTheDeprecatedDirective(t *testing.T) {
+
+	testEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "TestEnum",
+		Values: graphql.EnumValueConfigMap{
+			"NONDEPRECATED": &graphql.EnumValueConfig{
+				Value: 0,
+			},
+			"DEPRECATED": &graphql.EnumValueConfig{
+				Value: 1,
+				Directives: []*graphql.AppliedDirective{
+					{Name: "deprecated"},
+				},
+			},
+		},
+	})
+	testType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TestType",
+		Fields: graphql.Fields{
+			"nonDeprecated": &graphql.Field{
+				Type: graphql.String,
+			},
+			"deprecated": &graphql.Field{
+				Type: graphql.String,
+				Directives: []*graphql.AppliedDirective{
+					{Name: "deprecated", Args: map[string]interface{}{"reason": "Removed in 1.0"}},
+				},
+			},
+			"testEnum": &graphql.Field{
+				Type: testEnum,
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: testType,
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        testType: __type(name: "TestType") {
+          fields(includeDeprecated: true) {
+            name
+            isDeprecated,
+            deprecationReason
+          }
+        }
+        testEnum: __type(name: "TestEnum") {
+          enumValues(includeDeprecated: true) {
+            name
+            isDeprecated,
+            deprecationReason
+          }
+        }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"testType": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{
+						"name":              "nonDeprecated",
+						"isDeprecated":      false,
+						"deprecationReason": nil,
+					},
+					map[string]interface{}{
+						"name":              "deprecated",
+						"isDeprecated":      true,
+						"deprecationReason": "Removed in 1.0",
+					},
+				},
+			},
+			"testEnum": map[string]interface{}{
+				"enumValues": []interface{}{
+					map[string]interface{}{
+						"name":              "NONDEPRECATED",
+						"isDeprecated":      false,
+						"deprecationReason": nil,
+					},
+					map[string]interface{}{
+						"name":              "DEPRECATED",
+						"isDeprecated":      true,
+						"deprecationReason": "No longer supported",
+					},
+				},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestIntrospection_IdentifiesDeprecatedArgsAndInputFields(t *testing.T) {
+
+	testInputObject := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TestInputObject",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"nonDeprecated": &graphql.InputObjectFieldConfig{
+				Type: graphql.String,
+			},
+			"deprecated": &graphql.InputObjectFieldConfig{
+				Type:              graphql.String,
+				DeprecationReason: "Removed in 1.0",
+			},
+		},
+	})
+	testType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TestType",
+		Fields: graphql.Fields{
+			"field": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"nonDeprecated": &graphql.ArgumentConfig{
+						Type: testInputObject,
+					},
+					"deprecated": &graphql.ArgumentConfig{
+						Type: graphql.String,
+						Directives: []*graphql.AppliedDirective{
+							{Name: "deprecated"},
+						},
+					},
+				},
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: testType,
+	})
+	if err != nil {
+		t.Fatalf("Error creating Schema: %v", err.Error())
+	}
+	query := `
+      {
+        testType: __type(name: "TestType") {
+          fields {
+            args(includeDeprecated: true) {
+              name
+              isDeprecated
+              deprecationReason
+            }
+            nonDeprecatedArgs: args {
+              name
+            }
+          }
+        }
+        testInputObject: __type(name: "TestInputObject") {
+          inputFields(includeDeprecated: true) {
+            name
+            isDeprecated
+            deprecationReason
+          }
+          nonDeprecatedInputFields: inputFields {
+            name
+          }
+        }
+      }
+    `
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"testType": map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{
+						"args": []interface{}{
+							map[string]interface{}{
+								"name":              "nonDeprecated",
+								"isDeprecated":      false,
+								"deprecationReason": nil,
+							},
+							map[string]interface{}{
+								"name":              "deprecated",
+								"isDeprecated":      true,
+								"deprecationReason": "No longer supported",
+							},
+						},
+						"nonDeprecatedArgs": []interface{}{
+							map[string]interface{}{
+								"name": "nonDeprecated",
+							},
+						},
+					},
+				},
+			},
+			"testInputObject": map[string]interface{}{
+				"inputFields": []interface{}{
+					map[string]interface{}{
+						"name":              "nonDeprecated",
+						"isDeprecated":      false,
+						"deprecationReason": nil,
+					},
+					map[string]interface{}{
+						"name":              "deprecated",
+						"isDeprecated":      true,
+						"deprecationReason": "Removed in 1.0",
+					},
+				},
+				"nonDeprecatedInputFields": []interface{}{
+					map[string]interface{}{
+						"name": "nonDeprecated",
+					},
+				},
+			},
+		},
+	}
+	result := g(t, graphql.Params{
+		Schema:        schema,
+		RequestString: query,
+	})
+	if !testutil.ContainSubset(result.Data.(map[string]interface{}), expected.Data.(map[string]interface{})) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+	data := result.Data.(map[string]interface{})
+	fields := data["testType"].(map[string]interface{})["fields"].([]interface{})
+	if args := fields[0].(map[string]interface{})["nonDeprecatedArgs"].([]interface{}); len(args) != 1 {
+		t.Fatalf("expected deprecated args to be omitted, got: %v", args)
+	}
+	inputFields := data["testInputObject"].(map[string]interface{})["nonDeprecatedInputFields"].([]interface{})
+	if len(inputFields) != 1 {
+		t.Fatalf("expected deprecated input fields to be omitted, got: %v", inputFields)
+	}
+}
+
 func TestIntrospection_RespectsTheIncludeDeprecatedParameterForEnumValues(t *testing.T) {
 
 	testEnum := graphql.NewEnum(graphql.EnumConfig{
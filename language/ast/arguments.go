@@ -6,10 +6,11 @@ import (
 
 // Argument implements Node
 type Argument struct {
-	Kind  string
-	Loc   *Location
-	Name  *Name
-	Value Value
+	Kind     string
+	Loc      *Location
+	Name     *Name
+	Value    Value
+	Comments *CommentGroup
 }
 
 func NewArgument(arg *Argument) *Argument {
@@ -17,10 +18,11 @@ func NewArgument(arg *Argument) *Argument {
 		arg = &Argument{}
 	}
 	return &Argument{
-		Kind:  kinds.Argument,
-		Loc:   arg.Loc,
-		Name:  arg.Name,
-		Value: arg.Value,
+		Kind:     kinds.Argument,
+		Loc:      arg.Loc,
+		Name:     arg.Name,
+		Value:    arg.Value,
+		Comments: arg.Comments,
 	}
 }
 
@@ -0,0 +1,43 @@
+package ast
+
+import (
+	"github.com/graphql-go/graphql/language/kinds"
+)
+
+// Comment implements Node
+type Comment struct {
+	Kind  string
+	Loc   *Location
+	Value string
+}
+
+func NewComment(c *Comment) *Comment {
+	if c == nil {
+		c = &Comment{}
+	}
+	return &Comment{
+		Kind:  kinds.Comment,
+		Loc:   c.Loc,
+		Value: c.Value,
+	}
+}
+
+func (c *Comment) GetKind() string {
+	return c.Kind
+}
+
+func (c *Comment) GetLoc() *Location {
+	return c.Loc
+}
+
+// CommentGroup holds the comments attached to a node, when parsing with
+// comments kept.
+type CommentGroup struct {
+	// Leading comments are on their own lines before the node.
+	Leading []*Comment
+	// Trailing comment is on the same line as the end of the node.
+	Trailing *Comment
+	// Closing comments are on their own lines after the node, when the node
+	// is the last one of its block or document.
+	Closing []*Comment
+}
@@ -33,6 +33,7 @@ type OperationDefinition struct {
 	VariableDefinitions []*VariableDefinition
 	Directives          []*Directive
 	SelectionSet        *SelectionSet
+	Comments            *CommentGroup
 }
 
 func NewOperationDefinition(op *OperationDefinition) *OperationDefinition {
@@ -47,6 +48,7 @@ func NewOperationDefinition(op *OperationDefinition) *OperationDefinition {
 		VariableDefinitions: op.VariableDefinitions,
 		Directives:          op.Directives,
 		SelectionSet:        op.SelectionSet,
+		Comments:            op.Comments,
 	}
 }
 
@@ -88,6 +90,7 @@ type FragmentDefinition struct {
 	TypeCondition       *Named
 	Directives          []*Directive
 	SelectionSet        *SelectionSet
+	Comments            *CommentGroup
 }
 
 func NewFragmentDefinition(fd *FragmentDefinition) *FragmentDefinition {
@@ -103,6 +106,7 @@ func NewFragmentDefinition(fd *FragmentDefinition) *FragmentDefinition {
 		TypeCondition:       fd.TypeCondition,
 		Directives:          fd.Directives,
 		SelectionSet:        fd.SelectionSet,
+		Comments:            fd.Comments,
 	}
 }
 
@@ -137,6 +141,8 @@ type VariableDefinition struct {
 	Variable     *Variable
 	Type         Type
 	DefaultValue Value
+	Directives   []*Directive
+	Comments     *CommentGroup
 }
 
 func NewVariableDefinition(vd *VariableDefinition) *VariableDefinition {
@@ -149,6 +155,8 @@ func NewVariableDefinition(vd *VariableDefinition) *VariableDefinition {
 		Variable:     vd.Variable,
 		Type:         vd.Type,
 		DefaultValue: vd.DefaultValue,
+		Directives:   vd.Directives,
+		Comments:     vd.Comments,
 	}
 }
 
@@ -165,6 +173,7 @@ type TypeExtensionDefinition struct {
 	Kind       string
 	Loc        *Location
 	Definition *ObjectDefinition
+	Comments   *CommentGroup
 }
 
 func NewTypeExtensionDefinition(def *TypeExtensionDefinition) *TypeExtensionDefinition {
@@ -175,6 +184,7 @@ func NewTypeExtensionDefinition(def *TypeExtensionDefinition) *TypeExtensionDefi
 		Kind:       kinds.TypeExtensionDefinition,
 		Loc:        def.Loc,
 		Definition: def.Definition,
+		Comments:   def.Comments,
 	}
 }
 
@@ -198,13 +208,255 @@ func (def *TypeExtensionDefinition) GetOperation() string {
 	return ""
 }
 
+// SchemaExtensionDefinition implements Node, Definition
+type SchemaExtensionDefinition struct {
+	Kind       string
+	Loc        *Location
+	Definition *SchemaDefinition
+	Comments   *CommentGroup
+}
+
+func NewSchemaExtensionDefinition(def *SchemaExtensionDefinition) *SchemaExtensionDefinition {
+	if def == nil {
+		def = &SchemaExtensionDefinition{}
+	}
+	return &SchemaExtensionDefinition{
+		Kind:       kinds.SchemaExtensionDefinition,
+		Loc:        def.Loc,
+		Definition: def.Definition,
+		Comments:   def.Comments,
+	}
+}
+
+func (def *SchemaExtensionDefinition) GetKind() string {
+	return def.Kind
+}
+
+func (def *SchemaExtensionDefinition) GetLoc() *Location {
+	return def.Loc
+}
+
+func (def *SchemaExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
+	return []*VariableDefinition{}
+}
+
+func (def *SchemaExtensionDefinition) GetSelectionSet() *SelectionSet {
+	return &SelectionSet{}
+}
+
+func (def *SchemaExtensionDefinition) GetOperation() string {
+	return ""
+}
+
+// ScalarExtensionDefinition implements Node, Definition
+type ScalarExtensionDefinition struct {
+	Kind       string
+	Loc        *Location
+	Definition *ScalarDefinition
+	Comments   *CommentGroup
+}
+
+func NewScalarExtensionDefinition(def *ScalarExtensionDefinition) *ScalarExtensionDefinition {
+	if def == nil {
+		def = &ScalarExtensionDefinition{}
+	}
+	return &ScalarExtensionDefinition{
+		Kind:       kinds.ScalarExtensionDefinition,
+		Loc:        def.Loc,
+		Definition: def.Definition,
+		Comments:   def.Comments,
+	}
+}
+
+func (def *ScalarExtensionDefinition) GetKind() string {
+	return def.Kind
+}
+
+func (def *ScalarExtensionDefinition) GetLoc() *Location {
+	return def.Loc
+}
+
+func (def *ScalarExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
+	return []*VariableDefinition{}
+}
+
+func (def *ScalarExtensionDefinition) GetSelectionSet() *SelectionSet {
+	return &SelectionSet{}
+}
+
+func (def *ScalarExtensionDefinition) GetOperation() string {
+	return ""
+}
+
+// InterfaceExtensionDefinition implements Node, Definition
+type InterfaceExtensionDefinition struct {
+	Kind       string
+	Loc        *Location
+	Definition *InterfaceDefinition
+	Comments   *CommentGroup
+}
+
+func NewInterfaceExtensionDefinition(def *InterfaceExtensionDefinition) *InterfaceExtensionDefinition {
+	if def == nil {
+		def = &InterfaceExtensionDefinition{}
+	}
+	return &InterfaceExtensionDefinition{
+		Kind:       kinds.InterfaceExtensionDefinition,
+		Loc:        def.Loc,
+		Definition: def.Definition,
+		Comments:   def.Comments,
+	}
+}
+
+func (def *InterfaceExtensionDefinition) GetKind() string {
+	return def.Kind
+}
+
+func (def *InterfaceExtensionDefinition) GetLoc() *Location {
+	return def.Loc
+}
+
+func (def *InterfaceExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
+	return []*VariableDefinition{}
+}
+
+func (def *InterfaceExtensionDefinition) GetSelectionSet() *SelectionSet {
+	return &SelectionSet{}
+}
+
+func (def *InterfaceExtensionDefinition) GetOperation() string {
+	return ""
+}
+
+// UnionExtensionDefinition implements Node, Definition
+type UnionExtensionDefinition struct {
+	Kind       string
+	Loc        *Location
+	Definition *UnionDefinition
+	Comments   *CommentGroup
+}
+
+func NewUnionExtensionDefinition(def *UnionExtensionDefinition) *UnionExtensionDefinition {
+	if def == nil {
+		def = &UnionExtensionDefinition{}
+	}
+	return &UnionExtensionDefinition{
+		Kind:       kinds.UnionExtensionDefinition,
+		Loc:        def.Loc,
+		Definition: def.Definition,
+		Comments:   def.Comments,
+	}
+}
+
+func (def *UnionExtensionDefinition) GetKind() string {
+	return def.Kind
+}
+
+func (def *UnionExtensionDefinition) GetLoc() *Location {
+	return def.Loc
+}
+
+func (def *UnionExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
+	return []*VariableDefinition{}
+}
+
+func (def *UnionExtensionDefinition) GetSelectionSet() *SelectionSet {
+	return &SelectionSet{}
+}
+
+func (def *UnionExtensionDefinition) GetOperation() string {
+	return ""
+}
+
+// EnumExtensionDefinition implements Node, Definition
+type EnumExtensionDefinition struct {
+	Kind       string
+	Loc        *Location
+	Definition *EnumDefinition
+	Comments   *CommentGroup
+}
+
+func NewEnumExtensionDefinition(def *EnumExtensionDefinition) *EnumExtensionDefinition {
+	if def == nil {
+		def = &EnumExtensionDefinition{}
+	}
+	return &EnumExtensionDefinition{
+		Kind:       kinds.EnumExtensionDefinition,
+		Loc:        def.Loc,
+		Definition: def.Definition,
+		Comments:   def.Comments,
+	}
+}
+
+func (def *EnumExtensionDefinition) GetKind() string {
+	return def.Kind
+}
+
+func (def *EnumExtensionDefinition) GetLoc() *Location {
+	return def.Loc
+}
+
+func (def *EnumExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
+	return []*VariableDefinition{}
+}
+
+func (def *EnumExtensionDefinition) GetSelectionSet() *SelectionSet {
+	return &SelectionSet{}
+}
+
+func (def *EnumExtensionDefinition) GetOperation() string {
+	return ""
+}
+
+// InputObjectExtensionDefinition implements Node, Definition
+type InputObjectExtensionDefinition struct {
+	Kind       string
+	Loc        *Location
+	Definition *InputObjectDefinition
+	Comments   *CommentGroup
+}
+
+func NewInputObjectExtensionDefinition(def *InputObjectExtensionDefinition) *InputObjectExtensionDefinition {
+	if def == nil {
+		def = &InputObjectExtensionDefinition{}
+	}
+	return &InputObjectExtensionDefinition{
+		Kind:       kinds.InputObjectExtensionDefinition,
+		Loc:        def.Loc,
+		Definition: def.Definition,
+		Comments:   def.Comments,
+	}
+}
+
+func (def *InputObjectExtensionDefinition) GetKind() string {
+	return def.Kind
+}
+
+func (def *InputObjectExtensionDefinition) GetLoc() *Location {
+	return def.Loc
+}
+
+func (def *InputObjectExtensionDefinition) GetVariableDefinitions() []*VariableDefinition {
+	return []*VariableDefinition{}
+}
+
+func (def *InputObjectExtensionDefinition) GetSelectionSet() *SelectionSet {
+	return &SelectionSet{}
+}
+
+func (def *InputObjectExtensionDefinition) GetOperation() string {
+	return ""
+}
+
 // DirectiveDefinition implements Node, Definition
 type DirectiveDefinition struct {
-	Kind      string
-	Loc       *Location
-	Name      *Name
-	Arguments []*InputValueDefinition
-	Locations []*Name
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Arguments   []*InputValueDefinition
+	Locations   []*Name
+	Comments    *CommentGroup
 }
 
 func NewDirectiveDefinition(def *DirectiveDefinition) *DirectiveDefinition {
@@ -212,11 +464,13 @@ func NewDirectiveDefinition(def *DirectiveDefinition) *DirectiveDefinition {
 		def = &DirectiveDefinition{}
 	}
 	return &DirectiveDefinition{
-		Kind:      kinds.DirectiveDefinition,
-		Loc:       def.Loc,
-		Name:      def.Name,
-		Arguments: def.Arguments,
-		Locations: def.Locations,
+		Kind:        kinds.DirectiveDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Arguments:   def.Arguments,
+		Locations:   def.Locations,
+		Comments:    def.Comments,
 	}
 }
 
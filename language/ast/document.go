@@ -9,6 +9,7 @@ type Document struct {
 	Kind        string
 	Loc         *Location
 	Definitions []Node
+	Comments    []*Comment
 }
 
 func NewDocument(d *Document) *Document {
@@ -19,6 +20,7 @@ func NewDocument(d *Document) *Document {
 		Kind:        kinds.Document,
 		Loc:         d.Loc,
 		Definitions: d.Definitions,
+		Comments:    d.Comments,
 	}
 }
 
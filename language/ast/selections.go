@@ -22,6 +22,7 @@ type Field struct {
 	Arguments    []*Argument
 	Directives   []*Directive
 	SelectionSet *SelectionSet
+	Comments     *CommentGroup
 }
 
 func NewField(f *Field) *Field {
@@ -36,6 +37,7 @@ func NewField(f *Field) *Field {
 		Arguments:    f.Arguments,
 		Directives:   f.Directives,
 		SelectionSet: f.SelectionSet,
+		Comments:     f.Comments,
 	}
 }
 
@@ -57,6 +59,7 @@ type FragmentSpread struct {
 	Loc        *Location
 	Name       *Name
 	Directives []*Directive
+	Comments   *CommentGroup
 }
 
 func NewFragmentSpread(fs *FragmentSpread) *FragmentSpread {
@@ -68,6 +71,7 @@ func NewFragmentSpread(fs *FragmentSpread) *FragmentSpread {
 		Loc:        fs.Loc,
 		Name:       fs.Name,
 		Directives: fs.Directives,
+		Comments:   fs.Comments,
 	}
 }
 
@@ -90,6 +94,7 @@ type InlineFragment struct {
 	TypeCondition *Named
 	Directives    []*Directive
 	SelectionSet  *SelectionSet
+	Comments      *CommentGroup
 }
 
 func NewInlineFragment(f *InlineFragment) *InlineFragment {
@@ -102,6 +107,7 @@ func NewInlineFragment(f *InlineFragment) *InlineFragment {
 		TypeCondition: f.TypeCondition,
 		Directives:    f.Directives,
 		SelectionSet:  f.SelectionSet,
+		Comments:      f.Comments,
 	}
 }
 
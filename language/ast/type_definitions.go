@@ -30,13 +30,21 @@ type TypeSystemDefinition interface {
 var _ TypeSystemDefinition = (*SchemaDefinition)(nil)
 var _ TypeSystemDefinition = (TypeDefinition)(nil)
 var _ TypeSystemDefinition = (*TypeExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*SchemaExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*ScalarExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*InterfaceExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*UnionExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*EnumExtensionDefinition)(nil)
+var _ TypeSystemDefinition = (*InputObjectExtensionDefinition)(nil)
 var _ TypeSystemDefinition = (*DirectiveDefinition)(nil)
 
 // SchemaDefinition implements Node, Definition
 type SchemaDefinition struct {
 	Kind           string
 	Loc            *Location
+	Directives     []*Directive
 	OperationTypes []*OperationTypeDefinition
+	Comments       *CommentGroup
 }
 
 func NewSchemaDefinition(def *SchemaDefinition) *SchemaDefinition {
@@ -46,7 +54,9 @@ func NewSchemaDefinition(def *SchemaDefinition) *SchemaDefinition {
 	return &SchemaDefinition{
 		Kind:           kinds.SchemaDefinition,
 		Loc:            def.Loc,
+		Directives:     def.Directives,
 		OperationTypes: def.OperationTypes,
+		Comments:       def.Comments,
 	}
 }
 
@@ -76,6 +86,7 @@ type OperationTypeDefinition struct {
 	Loc       *Location
 	Operation string
 	Type      *Named
+	Comments  *CommentGroup
 }
 
 func NewOperationTypeDefinition(def *OperationTypeDefinition) *OperationTypeDefinition {
@@ -87,6 +98,7 @@ func NewOperationTypeDefinition(def *OperationTypeDefinition) *OperationTypeDefi
 		Loc:       def.Loc,
 		Operation: def.Operation,
 		Type:      def.Type,
+		Comments:  def.Comments,
 	}
 }
 
@@ -100,9 +112,12 @@ func (def *OperationTypeDefinition) GetLoc() *Location {
 
 // ScalarDefinition implements Node, Definition
 type ScalarDefinition struct {
-	Kind string
-	Loc  *Location
-	Name *Name
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Comments    *CommentGroup
 }
 
 func NewScalarDefinition(def *ScalarDefinition) *ScalarDefinition {
@@ -110,9 +125,12 @@ func NewScalarDefinition(def *ScalarDefinition) *ScalarDefinition {
 		def = &ScalarDefinition{}
 	}
 	return &ScalarDefinition{
-		Kind: kinds.ScalarDefinition,
-		Loc:  def.Loc,
-		Name: def.Name,
+		Kind:        kinds.ScalarDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Directives:  def.Directives,
+		Comments:    def.Comments,
 	}
 }
 
@@ -142,11 +160,14 @@ func (def *ScalarDefinition) GetOperation() string {
 
 // ObjectDefinition implements Node, Definition
 type ObjectDefinition struct {
-	Kind       string
-	Loc        *Location
-	Name       *Name
-	Interfaces []*Named
-	Fields     []*FieldDefinition
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Interfaces  []*Named
+	Directives  []*Directive
+	Fields      []*FieldDefinition
+	Comments    *CommentGroup
 }
 
 func NewObjectDefinition(def *ObjectDefinition) *ObjectDefinition {
@@ -154,11 +175,14 @@ func NewObjectDefinition(def *ObjectDefinition) *ObjectDefinition {
 		def = &ObjectDefinition{}
 	}
 	return &ObjectDefinition{
-		Kind:       kinds.ObjectDefinition,
-		Loc:        def.Loc,
-		Name:       def.Name,
-		Interfaces: def.Interfaces,
-		Fields:     def.Fields,
+		Kind:        kinds.ObjectDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Interfaces:  def.Interfaces,
+		Directives:  def.Directives,
+		Fields:      def.Fields,
+		Comments:    def.Comments,
 	}
 }
 
@@ -188,11 +212,14 @@ func (def *ObjectDefinition) GetOperation() string {
 
 // FieldDefinition implements Node
 type FieldDefinition struct {
-	Kind      string
-	Loc       *Location
-	Name      *Name
-	Arguments []*InputValueDefinition
-	Type      Type
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Arguments   []*InputValueDefinition
+	Type        Type
+	Directives  []*Directive
+	Comments    *CommentGroup
 }
 
 func NewFieldDefinition(def *FieldDefinition) *FieldDefinition {
@@ -200,11 +227,14 @@ func NewFieldDefinition(def *FieldDefinition) *FieldDefinition {
 		def = &FieldDefinition{}
 	}
 	return &FieldDefinition{
-		Kind:      kinds.FieldDefinition,
-		Loc:       def.Loc,
-		Name:      def.Name,
-		Arguments: def.Arguments,
-		Type:      def.Type,
+		Kind:        kinds.FieldDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Arguments:   def.Arguments,
+		Type:        def.Type,
+		Directives:  def.Directives,
+		Comments:    def.Comments,
 	}
 }
 
@@ -220,9 +250,12 @@ func (def *FieldDefinition) GetLoc() *Location {
 type InputValueDefinition struct {
 	Kind         string
 	Loc          *Location
+	Description  *StringValue
 	Name         *Name
 	Type         Type
 	DefaultValue Value
+	Directives   []*Directive
+	Comments     *CommentGroup
 }
 
 func NewInputValueDefinition(def *InputValueDefinition) *InputValueDefinition {
@@ -232,9 +265,12 @@ func NewInputValueDefinition(def *InputValueDefinition) *InputValueDefinition {
 	return &InputValueDefinition{
 		Kind:         kinds.InputValueDefinition,
 		Loc:          def.Loc,
+		Description:  def.Description,
 		Name:         def.Name,
 		Type:         def.Type,
 		DefaultValue: def.DefaultValue,
+		Directives:   def.Directives,
+		Comments:     def.Comments,
 	}
 }
 
@@ -248,10 +284,14 @@ func (def *InputValueDefinition) GetLoc() *Location {
 
 // InterfaceDefinition implements Node, Definition
 type InterfaceDefinition struct {
-	Kind   string
-	Loc    *Location
-	Name   *Name
-	Fields []*FieldDefinition
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Interfaces  []*Named
+	Directives  []*Directive
+	Fields      []*FieldDefinition
+	Comments    *CommentGroup
 }
 
 func NewInterfaceDefinition(def *InterfaceDefinition) *InterfaceDefinition {
@@ -259,10 +299,14 @@ func NewInterfaceDefinition(def *InterfaceDefinition) *InterfaceDefinition {
 		def = &InterfaceDefinition{}
 	}
 	return &InterfaceDefinition{
-		Kind:   kinds.InterfaceDefinition,
-		Loc:    def.Loc,
-		Name:   def.Name,
-		Fields: def.Fields,
+		Kind:        kinds.InterfaceDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Interfaces:  def.Interfaces,
+		Directives:  def.Directives,
+		Fields:      def.Fields,
+		Comments:    def.Comments,
 	}
 }
 
@@ -292,10 +336,13 @@ func (def *InterfaceDefinition) GetOperation() string {
 
 // UnionDefinition implements Node, Definition
 type UnionDefinition struct {
-	Kind  string
-	Loc   *Location
-	Name  *Name
-	Types []*Named
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Types       []*Named
+	Comments    *CommentGroup
 }
 
 func NewUnionDefinition(def *UnionDefinition) *UnionDefinition {
@@ -303,10 +350,13 @@ func NewUnionDefinition(def *UnionDefinition) *UnionDefinition {
 		def = &UnionDefinition{}
 	}
 	return &UnionDefinition{
-		Kind:  kinds.UnionDefinition,
-		Loc:   def.Loc,
-		Name:  def.Name,
-		Types: def.Types,
+		Kind:        kinds.UnionDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Directives:  def.Directives,
+		Types:       def.Types,
+		Comments:    def.Comments,
 	}
 }
 
@@ -336,10 +386,13 @@ func (def *UnionDefinition) GetOperation() string {
 
 // EnumDefinition implements Node, Definition
 type EnumDefinition struct {
-	Kind   string
-	Loc    *Location
-	Name   *Name
-	Values []*EnumValueDefinition
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Values      []*EnumValueDefinition
+	Comments    *CommentGroup
 }
 
 func NewEnumDefinition(def *EnumDefinition) *EnumDefinition {
@@ -347,10 +400,13 @@ func NewEnumDefinition(def *EnumDefinition) *EnumDefinition {
 		def = &EnumDefinition{}
 	}
 	return &EnumDefinition{
-		Kind:   kinds.EnumDefinition,
-		Loc:    def.Loc,
-		Name:   def.Name,
-		Values: def.Values,
+		Kind:        kinds.EnumDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Directives:  def.Directives,
+		Values:      def.Values,
+		Comments:    def.Comments,
 	}
 }
 
@@ -380,9 +436,12 @@ func (def *EnumDefinition) GetOperation() string {
 
 // EnumValueDefinition implements Node, Definition
 type EnumValueDefinition struct {
-	Kind string
-	Loc  *Location
-	Name *Name
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Comments    *CommentGroup
 }
 
 func NewEnumValueDefinition(def *EnumValueDefinition) *EnumValueDefinition {
@@ -390,9 +449,12 @@ func NewEnumValueDefinition(def *EnumValueDefinition) *EnumValueDefinition {
 		def = &EnumValueDefinition{}
 	}
 	return &EnumValueDefinition{
-		Kind: kinds.EnumValueDefinition,
-		Loc:  def.Loc,
-		Name: def.Name,
+		Kind:        kinds.EnumValueDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Directives:  def.Directives,
+		Comments:    def.Comments,
 	}
 }
 
@@ -406,10 +468,13 @@ func (def *EnumValueDefinition) GetLoc() *Location {
 
 // InputObjectDefinition implements Node, Definition
 type InputObjectDefinition struct {
-	Kind   string
-	Loc    *Location
-	Name   *Name
-	Fields []*InputValueDefinition
+	Kind        string
+	Loc         *Location
+	Description *StringValue
+	Name        *Name
+	Directives  []*Directive
+	Fields      []*InputValueDefinition
+	Comments    *CommentGroup
 }
 
 func NewInputObjectDefinition(def *InputObjectDefinition) *InputObjectDefinition {
@@ -417,10 +482,13 @@ func NewInputObjectDefinition(def *InputObjectDefinition) *InputObjectDefinition
 		def = &InputObjectDefinition{}
 	}
 	return &InputObjectDefinition{
-		Kind:   kinds.InputObjectDefinition,
-		Loc:    def.Loc,
-		Name:   def.Name,
-		Fields: def.Fields,
+		Kind:        kinds.InputObjectDefinition,
+		Loc:         def.Loc,
+		Description: def.Description,
+		Name:        def.Name,
+		Directives:  def.Directives,
+		Fields:      def.Fields,
+		Comments:    def.Comments,
 	}
 }
 
@@ -16,15 +16,17 @@ var _ Value = (*IntValue)(nil)
 var _ Value = (*FloatValue)(nil)
 var _ Value = (*StringValue)(nil)
 var _ Value = (*BooleanValue)(nil)
+var _ Value = (*NullValue)(nil)
 var _ Value = (*EnumValue)(nil)
 var _ Value = (*ListValue)(nil)
 var _ Value = (*ObjectValue)(nil)
 
 // Variable implements Node, Value
 type Variable struct {
-	Kind string
-	Loc  *Location
-	Name *Name
+	Kind     string
+	Loc      *Location
+	Name     *Name
+	Comments *CommentGroup
 }
 
 func NewVariable(v *Variable) *Variable {
@@ -32,9 +34,10 @@ func NewVariable(v *Variable) *Variable {
 		v = &Variable{}
 	}
 	return &Variable{
-		Kind: kinds.Variable,
-		Loc:  v.Loc,
-		Name: v.Name,
+		Kind:     kinds.Variable,
+		Loc:      v.Loc,
+		Name:     v.Name,
+		Comments: v.Comments,
 	}
 }
 
@@ -57,9 +60,10 @@ func (v *Variable) GetName() interface{} {
 
 // IntValue implements Node, Value
 type IntValue struct {
-	Kind  string
-	Loc   *Location
-	Value string
+	Kind     string
+	Loc      *Location
+	Value    string
+	Comments *CommentGroup
 }
 
 func NewIntValue(v *IntValue) *IntValue {
@@ -67,9 +71,10 @@ func NewIntValue(v *IntValue) *IntValue {
 		v = &IntValue{}
 	}
 	return &IntValue{
-		Kind:  kinds.IntValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.IntValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -87,9 +92,10 @@ func (v *IntValue) GetValue() interface{} {
 
 // FloatValue implements Node, Value
 type FloatValue struct {
-	Kind  string
-	Loc   *Location
-	Value string
+	Kind     string
+	Loc      *Location
+	Value    string
+	Comments *CommentGroup
 }
 
 func NewFloatValue(v *FloatValue) *FloatValue {
@@ -97,9 +103,10 @@ func NewFloatValue(v *FloatValue) *FloatValue {
 		v = &FloatValue{}
 	}
 	return &FloatValue{
-		Kind:  kinds.FloatValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.FloatValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -120,6 +127,10 @@ type StringValue struct {
 	Kind  string
 	Loc   *Location
 	Value string
+
+	// Block tells whether the value is written as a block string.
+	Block    bool
+	Comments *CommentGroup
 }
 
 func NewStringValue(v *StringValue) *StringValue {
@@ -127,9 +138,11 @@ func NewStringValue(v *StringValue) *StringValue {
 		v = &StringValue{}
 	}
 	return &StringValue{
-		Kind:  kinds.StringValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.StringValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Block:    v.Block,
+		Comments: v.Comments,
 	}
 }
 
@@ -147,9 +160,10 @@ func (v *StringValue) GetValue() interface{} {
 
 // BooleanValue implements Node, Value
 type BooleanValue struct {
-	Kind  string
-	Loc   *Location
-	Value bool
+	Kind     string
+	Loc      *Location
+	Value    bool
+	Comments *CommentGroup
 }
 
 func NewBooleanValue(v *BooleanValue) *BooleanValue {
@@ -157,9 +171,10 @@ func NewBooleanValue(v *BooleanValue) *BooleanValue {
 		v = &BooleanValue{}
 	}
 	return &BooleanValue{
-		Kind:  kinds.BooleanValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.BooleanValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -175,11 +190,42 @@ func (v *BooleanValue) GetValue() interface{} {
 	return v.Value
 }
 
+// NullValue implements Node, Value
+type NullValue struct {
+	Kind     string
+	Loc      *Location
+	Comments *CommentGroup
+}
+
+func NewNullValue(v *NullValue) *NullValue {
+	if v == nil {
+		v = &NullValue{}
+	}
+	return &NullValue{
+		Kind:     kinds.NullValue,
+		Loc:      v.Loc,
+		Comments: v.Comments,
+	}
+}
+
+func (v *NullValue) GetKind() string {
+	return v.Kind
+}
+
+func (v *NullValue) GetLoc() *Location {
+	return v.Loc
+}
+
+func (v *NullValue) GetValue() interface{} {
+	return nil
+}
+
 // EnumValue implements Node, Value
 type EnumValue struct {
-	Kind  string
-	Loc   *Location
-	Value string
+	Kind     string
+	Loc      *Location
+	Value    string
+	Comments *CommentGroup
 }
 
 func NewEnumValue(v *EnumValue) *EnumValue {
@@ -187,9 +233,10 @@ func NewEnumValue(v *EnumValue) *EnumValue {
 		v = &EnumValue{}
 	}
 	return &EnumValue{
-		Kind:  kinds.EnumValue,
-		Loc:   v.Loc,
-		Value: v.Value,
+		Kind:     kinds.EnumValue,
+		Loc:      v.Loc,
+		Value:    v.Value,
+		Comments: v.Comments,
 	}
 }
 
@@ -207,9 +254,10 @@ func (v *EnumValue) GetValue() interface{} {
 
 // ListValue implements Node, Value
 type ListValue struct {
-	Kind   string
-	Loc    *Location
-	Values []Value
+	Kind     string
+	Loc      *Location
+	Values   []Value
+	Comments *CommentGroup
 }
 
 func NewListValue(v *ListValue) *ListValue {
@@ -217,9 +265,10 @@ func NewListValue(v *ListValue) *ListValue {
 		v = &ListValue{}
 	}
 	return &ListValue{
-		Kind:   kinds.ListValue,
-		Loc:    v.Loc,
-		Values: v.Values,
+		Kind:     kinds.ListValue,
+		Loc:      v.Loc,
+		Values:   v.Values,
+		Comments: v.Comments,
 	}
 }
 
@@ -243,9 +292,10 @@ func (v *ListValue) GetValues() interface{} {
 
 // ObjectValue implements Node, Value
 type ObjectValue struct {
-	Kind   string
-	Loc    *Location
-	Fields []*ObjectField
+	Kind     string
+	Loc      *Location
+	Fields   []*ObjectField
+	Comments *CommentGroup
 }
 
 func NewObjectValue(v *ObjectValue) *ObjectValue {
@@ -253,9 +303,10 @@ func NewObjectValue(v *ObjectValue) *ObjectValue {
 		v = &ObjectValue{}
 	}
 	return &ObjectValue{
-		Kind:   kinds.ObjectValue,
-		Loc:    v.Loc,
-		Fields: v.Fields,
+		Kind:     kinds.ObjectValue,
+		Loc:      v.Loc,
+		Fields:   v.Fields,
+		Comments: v.Comments,
 	}
 }
 
@@ -274,10 +325,11 @@ func (v *ObjectValue) GetValue() interface{} {
 
 // ObjectField implements Node, Value
 type ObjectField struct {
-	Kind  string
-	Name  *Name
-	Loc   *Location
-	Value Value
+	Kind     string
+	Name     *Name
+	Loc      *Location
+	Value    Value
+	Comments *CommentGroup
 }
 
 func NewObjectField(f *ObjectField) *ObjectField {
@@ -285,10 +337,11 @@ func NewObjectField(f *ObjectField) *ObjectField {
 		f = &ObjectField{}
 	}
 	return &ObjectField{
-		Kind:  kinds.ObjectField,
-		Loc:   f.Loc,
-		Name:  f.Name,
-		Value: f.Value,
+		Kind:     kinds.ObjectField,
+		Loc:      f.Loc,
+		Name:     f.Name,
+		Value:    f.Value,
+		Comments: f.Comments,
 	}
 }
 
@@ -2,6 +2,8 @@ package lexer
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/source"
@@ -26,6 +28,8 @@ const (
 	INT
 	FLOAT
 	STRING
+	BLOCK_STRING
+	COMMENT
 )
 
 var TokenKind map[int]int
@@ -52,6 +56,8 @@ func init() {
 	TokenKind[INT] = INT
 	TokenKind[FLOAT] = FLOAT
 	TokenKind[STRING] = STRING
+	TokenKind[BLOCK_STRING] = BLOCK_STRING
+	TokenKind[COMMENT] = COMMENT
 	tokenDescription[TokenKind[EOF]] = "EOF"
 	tokenDescription[TokenKind[BANG]] = "!"
 	tokenDescription[TokenKind[DOLLAR]] = "$"
@@ -70,114 +76,335 @@ func init() {
 	tokenDescription[TokenKind[INT]] = "Int"
 	tokenDescription[TokenKind[FLOAT]] = "Float"
 	tokenDescription[TokenKind[STRING]] = "String"
+	tokenDescription[TokenKind[BLOCK_STRING]] = "BlockString"
+	tokenDescription[TokenKind[COMMENT]] = "Comment"
 }
 
 // Token is a representation of a lexed Token. Value only appears for non-punctuation
-// tokens: NAME, INT, FLOAT, and STRING.
+// tokens: NAME, INT, FLOAT, STRING, BLOCK_STRING and COMMENT. Start and End are
+// offsets in characters of the source, and Line and Column, both starting at 1,
+// locate the start of the token.
 type Token struct {
-	Kind  int
-	Start int
-	End   int
-	Value string
+	Kind   int
+	Start  int
+	End    int
+	Value  string
+	Line   int
+	Column int
 }
 
 func (t *Token) String() string {
 	return fmt.Sprintf("%s", tokenDescription[t.Kind])
 }
 
-type Lexer func(resetPosition int) (Token, error)
+// Lexer reads the tokens of a source one after the other. It works on the
+// bytes of the body, tracking the character offset and the line of its
+// position as it goes, and the values of its tokens share the memory of the
+// body, unless they hold escape sequences.
+type Lexer struct {
+	source *source.Source
+	body   string
 
-func Lex(s *source.Source) Lexer {
-	var prevPosition int
-	return func(resetPosition int) (Token, error) {
-		if resetPosition == 0 {
-			resetPosition = prevPosition
-		}
-		token, err := readToken(s, resetPosition)
-		if err != nil {
-			return token, err
-		}
-		prevPosition = token.End
-		return token, nil
+	// byte position of the next character to lex, along with its offset in
+	// characters, its line and the offset the line starts at
+	position  int
+	offset    int
+	line      int
+	lineStart int
+
+	// whether a token was lexed, and whether a line ended since the last one
+	lexed   bool
+	newline bool
+
+	// comments skipped before the current token, and whether the first one
+	// is on the line of the previous token; then those skipped so far while
+	// lexing the next token
+	comments      []Token
+	inline        bool
+	pending       []Token
+	pendingInline bool
+
+	// the token read ahead by Peek, if any
+	peeked    lexedToken
+	hasPeeked bool
+}
+
+type lexedToken struct {
+	token    Token
+	comments []Token
+	inline   bool
+	err      error
+}
+
+func NewLexer(s *source.Source) *Lexer {
+	return &Lexer{
+		source: s,
+		body:   s.Body,
+		line:   1,
+	}
+}
+
+// Next returns the next token of the source, the EOF token once the body is
+// exhausted. After an error, the lexer stays where the failing token starts.
+func (l *Lexer) Next() (Token, error) {
+	next := l.peeked
+	if l.hasPeeked {
+		l.hasPeeked = false
+	} else {
+		next = l.read()
+	}
+	if next.err == nil {
+		l.comments = next.comments
+		l.inline = next.inline
+	}
+	return next.token, next.err
+}
+
+// Peek returns the token Next will return, without consuming it.
+func (l *Lexer) Peek() (Token, error) {
+	if !l.hasPeeked {
+		l.peeked = l.read()
+		l.hasPeeked = true
+	}
+	return l.peeked.token, l.peeked.err
+}
+
+// Comments returns the comments skipped before the token last returned by
+// Next, and whether the first of them is on the line the previous token ends.
+func (l *Lexer) Comments() ([]Token, bool) {
+	return l.comments, l.inline
+}
+
+// Seek moves the lexer to the given character offset of the source, the next
+// token being lexed from there. Seeking backwards starts over from the
+// beginning of the source.
+func (l *Lexer) Seek(position int) {
+	l.hasPeeked = false
+	if position < l.offset {
+		*l = *NewLexer(l.source)
+	}
+	for l.offset < position && l.position < len(l.body) {
+		l.advance()
+	}
+}
+
+func (l *Lexer) read() lexedToken {
+	token, err := l.readToken()
+	if err != nil {
+		return lexedToken{token: token, err: err}
+	}
+	next := lexedToken{
+		token:    token,
+		comments: l.pending,
+		inline:   l.pendingInline,
+	}
+	l.pending = nil
+	l.pendingInline = false
+	l.lexed = true
+	l.newline = false
+	return next
+}
+
+// Moves the lexer past the character at its position.
+func (l *Lexer) advance() {
+	code := l.body[l.position]
+	size := 1
+	if code >= utf8.RuneSelf {
+		_, size = utf8.DecodeRuneInString(l.body[l.position:])
+	}
+	l.position += size
+	l.offset++
+	// \r\n is a single line terminator, ending at \n
+	if code == '\n' || code == '\r' && (l.position == len(l.body) || l.body[l.position] != '\n') {
+		l.line++
+		l.lineStart = l.offset
+		l.newline = true
+	}
+}
+
+// Makes a token starting at the position of the lexer and ending at the
+// given byte position, moving the lexer past it.
+func (l *Lexer) token(kind int, end int, value string) Token {
+	token := Token{
+		Kind:   kind,
+		Start:  l.offset,
+		Value:  value,
+		Line:   l.line,
+		Column: l.offset - l.lineStart + 1,
 	}
+	for l.position < end {
+		l.advance()
+	}
+	token.End = l.offset
+	return token
 }
 
-func runeStringValueAt(body string, start, end int) string {
-	// convert body string to runes, to handle unicode
-	bodyRunes := []rune(body)
-	return string(bodyRunes[start:end])
+// Returns a syntax error located at the given byte position, which follows
+// the position of the lexer.
+func (l *Lexer) errorAt(position int, description string) error {
+	offset := l.offset + utf8.RuneCountInString(l.body[l.position:position])
+	return gqlerrors.NewSyntaxError(l.source, offset, description)
+}
+
+// Returns the character at the given byte position, or -1 past the end of
+// the body.
+func (l *Lexer) charCodeAt(position int) rune {
+	if position >= len(l.body) {
+		return -1
+	}
+	if code := l.body[position]; code < utf8.RuneSelf {
+		return rune(code)
+	}
+	code, _ := utf8.DecodeRuneInString(l.body[position:])
+	return code
+}
+
+func (l *Lexer) readToken() (Token, error) {
+	l.skipIgnored()
+	position := l.position
+	if position >= len(l.body) {
+		return l.token(TokenKind[EOF], position, ""), nil
+	}
+	code := l.charCodeAt(position)
+
+	// SourceCharacter
+	if code < 0x0020 && code != 0x0009 && code != 0x000A && code != 0x000D {
+		return Token{}, l.errorAt(position, fmt.Sprintf(`Invalid character %v`, printCharCode(code)))
+	}
+
+	switch code {
+	// !
+	case 33:
+		return l.token(TokenKind[BANG], position+1, ""), nil
+	// $
+	case 36:
+		return l.token(TokenKind[DOLLAR], position+1, ""), nil
+	// (
+	case 40:
+		return l.token(TokenKind[PAREN_L], position+1, ""), nil
+	// )
+	case 41:
+		return l.token(TokenKind[PAREN_R], position+1, ""), nil
+	// .
+	case 46:
+		if l.charCodeAt(position+1) == 46 && l.charCodeAt(position+2) == 46 {
+			return l.token(TokenKind[SPREAD], position+3, ""), nil
+		}
+		break
+	// :
+	case 58:
+		return l.token(TokenKind[COLON], position+1, ""), nil
+	// =
+	case 61:
+		return l.token(TokenKind[EQUALS], position+1, ""), nil
+	// @
+	case 64:
+		return l.token(TokenKind[AT], position+1, ""), nil
+	// [
+	case 91:
+		return l.token(TokenKind[BRACKET_L], position+1, ""), nil
+	// ]
+	case 93:
+		return l.token(TokenKind[BRACKET_R], position+1, ""), nil
+	// {
+	case 123:
+		return l.token(TokenKind[BRACE_L], position+1, ""), nil
+	// |
+	case 124:
+		return l.token(TokenKind[PIPE], position+1, ""), nil
+	// }
+	case 125:
+		return l.token(TokenKind[BRACE_R], position+1, ""), nil
+	// A-Z
+	case 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
+		82, 83, 84, 85, 86, 87, 88, 89, 90:
+		return l.readName(position), nil
+	// _
+	// a-z
+	case 95, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110,
+		111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122:
+		return l.readName(position), nil
+	// -
+	// 0-9
+	case 45, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57:
+		return l.readNumber(position, code)
+	// "
+	case 34:
+		if l.charCodeAt(position+1) == 34 && l.charCodeAt(position+2) == 34 {
+			return l.readBlockString(position)
+		}
+		return l.readString(position)
+	}
+	description := fmt.Sprintf("Unexpected character %v.", printCharCode(code))
+	return Token{}, l.errorAt(position, description)
 }
 
 // Reads an alphanumeric + underscore name from the source.
 // [_A-Za-z][_0-9A-Za-z]*
-func readName(source *source.Source, position int) Token {
-	body := source.Body
-	bodyLength := len(body)
+func (l *Lexer) readName(position int) Token {
+	body := l.body
 	end := position + 1
-	for {
-		code := charCodeAt(body, end)
-		if (end != bodyLength) && code != 0 &&
-			(code == 95 || // _
-				code >= 48 && code <= 57 || // 0-9
-				code >= 65 && code <= 90 || // A-Z
-				code >= 97 && code <= 122) { // a-z
+	for end < len(body) {
+		code := body[end]
+		if code == 95 || // _
+			code >= 48 && code <= 57 || // 0-9
+			code >= 65 && code <= 90 || // A-Z
+			code >= 97 && code <= 122 { // a-z
 			end++
 			continue
-		} else {
-			break
 		}
+		break
 	}
-	return makeToken(TokenKind[NAME], position, end, runeStringValueAt(body, position, end))
+	return l.token(TokenKind[NAME], end, body[position:end])
 }
 
 // Reads a number token from the source file, either a float
 // or an int depending on whether a decimal point appears.
 // Int:   -?(0|[1-9][0-9]*)
 // Float: -?(0|[1-9][0-9]*)(\.[0-9]+)?((E|e)(+|-)?[0-9]+)?
-func readNumber(s *source.Source, start int, firstCode rune) (Token, error) {
+func (l *Lexer) readNumber(start int, firstCode rune) (Token, error) {
 	code := firstCode
-	body := s.Body
 	position := start
 	isFloat := false
 	if code == 45 { // -
 		position++
-		code = charCodeAt(body, position)
+		code = l.charCodeAt(position)
 	}
 	if code == 48 { // 0
 		position++
-		code = charCodeAt(body, position)
+		code = l.charCodeAt(position)
 		if code >= 48 && code <= 57 {
 			description := fmt.Sprintf("Invalid number, unexpected digit after 0: %v.", printCharCode(code))
-			return Token{}, gqlerrors.NewSyntaxError(s, position, description)
+			return Token{}, l.errorAt(position, description)
 		}
 	} else {
-		p, err := readDigits(s, position, code)
+		p, err := l.readDigits(position, code)
 		if err != nil {
 			return Token{}, err
 		}
 		position = p
-		code = charCodeAt(body, position)
+		code = l.charCodeAt(position)
 	}
 	if code == 46 { // .
 		isFloat = true
 		position++
-		code = charCodeAt(body, position)
-		p, err := readDigits(s, position, code)
+		code = l.charCodeAt(position)
+		p, err := l.readDigits(position, code)
 		if err != nil {
 			return Token{}, err
 		}
 		position = p
-		code = charCodeAt(body, position)
+		code = l.charCodeAt(position)
 	}
 	if code == 69 || code == 101 { // E e
 		isFloat = true
 		position++
-		code = charCodeAt(body, position)
+		code = l.charCodeAt(position)
 		if code == 43 || code == 45 { // + -
 			position++
-			code = charCodeAt(body, position)
+			code = l.charCodeAt(position)
 		}
-		p, err := readDigits(s, position, code)
+		p, err := l.readDigits(position, code)
 		if err != nil {
 			return Token{}, err
 		}
@@ -187,110 +414,227 @@ func readNumber(s *source.Source, start int, firstCode rune) (Token, error) {
 	if isFloat {
 		kind = TokenKind[FLOAT]
 	}
-	return makeToken(kind, start, position, runeStringValueAt(body, start, position)), nil
+	return l.token(kind, position, l.body[start:position]), nil
 }
 
 // Returns the new position in the source after reading digits.
-func readDigits(s *source.Source, start int, firstCode rune) (int, error) {
-	body := s.Body
+func (l *Lexer) readDigits(start int, firstCode rune) (int, error) {
 	position := start
 	code := firstCode
 	if code >= 48 && code <= 57 { // 0 - 9
-		for {
-			if code >= 48 && code <= 57 { // 0 - 9
-				position++
-				code = charCodeAt(body, position)
-				continue
-			} else {
-				break
-			}
+		for code >= 48 && code <= 57 { // 0 - 9
+			position++
+			code = l.charCodeAt(position)
 		}
 		return position, nil
 	}
-	var description string
-	description = fmt.Sprintf("Invalid number, expected digit but got: %v.", printCharCode(code))
-	return position, gqlerrors.NewSyntaxError(s, position, description)
+	description := fmt.Sprintf("Invalid number, expected digit but got: %v.", printCharCode(code))
+	return position, l.errorAt(position, description)
 }
 
-func readString(s *source.Source, start int) (Token, error) {
-	body := s.Body
+// Reads a string token from the source file. Its value is a slice of the
+// body, unless it holds escape sequences.
+//
+// "([^"\\\u000A\u000D]|(\\(u[0-9a-fA-F]{4}|["\\/bfnrt])))*"
+func (l *Lexer) readString(start int) (Token, error) {
+	body := l.body
 	position := start + 1
 	chunkStart := position
-	var code rune
-	var value string
-	for {
-		code = charCodeAt(body, position)
-		if position < len(body) &&
-			// not LineTerminator
-			code != 0x000A && code != 0x000D &&
-			// not Quote (")
-			code != 34 {
-
-			// SourceCharacter
-			if code < 0x0020 && code != 0x0009 {
-				return Token{}, gqlerrors.NewSyntaxError(s, position, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(code)))
+	var value []byte
+	for position < len(body) {
+		code := body[position]
+		// LineTerminator
+		if code == 0x000A || code == 0x000D {
+			break
+		}
+		// Quote (")
+		if code == 34 {
+			if value == nil {
+				return l.token(TokenKind[STRING], position+1, body[chunkStart:position]), nil
 			}
-			position++
-			if code == 92 { // \
-				value += body[chunkStart : position-1]
-				code = charCodeAt(body, position)
-				switch code {
-				case 34:
-					value += "\""
-					break
-				case 47:
-					value += "\\/"
-					break
-				case 92:
-					value += "\\"
-					break
-				case 98:
-					value += "\b"
-					break
-				case 102:
-					value += "\f"
-					break
-				case 110:
-					value += "\n"
-					break
-				case 114:
-					value += "\r"
-					break
-				case 116:
-					value += "\t"
-					break
-				case 117: // u
-					charCode := uniCharCode(
-						charCodeAt(body, position+1),
-						charCodeAt(body, position+2),
-						charCodeAt(body, position+3),
-						charCodeAt(body, position+4),
-					)
-					if charCode < 0 {
-						return Token{}, gqlerrors.NewSyntaxError(s, position,
-							fmt.Sprintf("Invalid character escape sequence: "+
-								"\\u%v", body[position+1:position+5]))
-					}
-					value += fmt.Sprintf("%c", charCode)
-					position += 4
-					break
-				default:
-					return Token{}, gqlerrors.NewSyntaxError(s, position,
-						fmt.Sprintf(`Invalid character escape sequence: \\%c.`, code))
+			value = append(value, body[chunkStart:position]...)
+			return l.token(TokenKind[STRING], position+1, string(value)), nil
+		}
+		// SourceCharacter
+		if code < 0x0020 && code != 0x0009 {
+			return Token{}, l.errorAt(position, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(rune(code))))
+		}
+		position++
+		if code != 92 { // \
+			continue
+		}
+		value = append(value, body[chunkStart:position-1]...)
+		switch code := l.charCodeAt(position); code {
+		case 34:
+			value = append(value, '"')
+		case 47:
+			value = append(value, `\/`...)
+		case 92:
+			value = append(value, '\\')
+		case 98:
+			value = append(value, '\b')
+		case 102:
+			value = append(value, '\f')
+		case 110:
+			value = append(value, '\n')
+		case 114:
+			value = append(value, '\r')
+		case 116:
+			value = append(value, '\t')
+		case 117: // u
+			charCode := uniCharCode(
+				l.charCodeAt(position+1),
+				l.charCodeAt(position+2),
+				l.charCodeAt(position+3),
+				l.charCodeAt(position+4),
+			)
+			if charCode < 0 {
+				end := position + 5
+				if end > len(body) {
+					end = len(body)
 				}
-				position++
-				chunkStart = position
+				return Token{}, l.errorAt(position,
+					fmt.Sprintf("Invalid character escape sequence: "+
+						"\\u%v", body[position+1:end]))
 			}
+			value = append(value, string(charCode)...)
+			position += 4
+		default:
+			return Token{}, l.errorAt(position,
+				fmt.Sprintf(`Invalid character escape sequence: \\%c.`, code))
+		}
+		position++
+		chunkStart = position
+	}
+	return Token{}, l.errorAt(position, "Unterminated string.")
+}
+
+// Reads a block string token from the source file.
+//
+// """("?"?(\\"""|\\(?!=""")|[^"\\]))*"""
+func (l *Lexer) readBlockString(start int) (Token, error) {
+	body := l.body
+	position := start + 3
+	chunkStart := position
+	var rawValue string
+	for position < len(body) {
+		code := body[position]
+		// Closing Triple-Quote (""")
+		if code == 34 && strings.HasPrefix(body[position:], `"""`) {
+			rawValue += body[chunkStart:position]
+			return l.token(TokenKind[BLOCK_STRING], position+3, blockStringValue(rawValue)), nil
+		}
+		// SourceCharacter
+		if code < 0x0020 && code != 0x0009 && code != 0x000A && code != 0x000D {
+			return Token{}, l.errorAt(position, fmt.Sprintf(`Invalid character within String: %v.`, printCharCode(rune(code))))
+		}
+		// Escape Triple-Quote (\""")
+		if code == 92 && strings.HasPrefix(body[position+1:], `"""`) {
+			rawValue += body[chunkStart:position] + `"""`
+			position += 4
+			chunkStart = position
 			continue
-		} else {
-			break
+		}
+		position++
+	}
+	return Token{}, l.errorAt(position, "Unterminated string.")
+}
+
+// Moves the lexer past the ignored characters at its position, collecting
+// the comments among them.
+func (l *Lexer) skipIgnored() {
+	body := l.body
+	for l.position < len(body) {
+		code := body[l.position]
+		switch {
+		// White Space, Line Terminator, Comma
+		case code == 0x0009 || code == 0x0020 || code == 0x000A || code == 0x000D || code == 0x002C:
+			l.advance()
+		// BOM
+		case code == 0xEF && strings.HasPrefix(body[l.position:], "\uFEFF"):
+			l.advance()
+		// #
+		case code == 35:
+			end := l.position + 1
+			// SourceCharacter but not LineTerminator
+			for end < len(body) && (body[end] > 0x001F || body[end] == 0x0009) {
+				end++
+			}
+			if len(l.pending) == 0 {
+				l.pendingInline = l.lexed && !l.newline
+			}
+			l.pending = append(l.pending, l.token(TokenKind[COMMENT], end, body[l.position+1:end]))
+		default:
+			return
+		}
+	}
+}
+
+// LexFunc lexes the token following the given character offset of a source,
+// or the token following the previous one when the offset is zero.
+type LexFunc func(resetPosition int) (Token, error)
+
+// Lex returns a LexFunc over the tokens of the source.
+func Lex(s *source.Source) LexFunc {
+	lexer := NewLexer(s)
+	return func(resetPosition int) (Token, error) {
+		if resetPosition != 0 {
+			lexer.Seek(resetPosition)
+		}
+		return lexer.Next()
+	}
+}
+
+// ReadComments returns the comments found in the ignored characters of the
+// source starting at startPosition, up to the next token.
+//
+// Deprecated: use the comments a Lexer reports through Comments.
+func ReadComments(s *source.Source, startPosition int) []Token {
+	lexer := NewLexer(s)
+	lexer.Seek(startPosition)
+	lexer.skipIgnored()
+	return lexer.pending
+}
+
+// blockStringValue produces the value of a block string from its raw content,
+// as the spec's BlockStringValue: the common indentation of all lines but the
+// first is removed, as are leading and trailing blank lines.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.Replace(strings.Replace(raw, "\r\n", "\n", -1), "\r", "\n", -1), "\n")
+
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		indent := leadingWhitespace(line)
+		if indent < len(line) && (commonIndent == -1 || indent < commonIndent) {
+			commonIndent = indent
 		}
 	}
-	if code != 34 { // quote (")
-		return Token{}, gqlerrors.NewSyntaxError(s, position, "Unterminated string.")
+	if commonIndent > 0 {
+		for i, line := range lines[1:] {
+			if len(line) < commonIndent {
+				lines[i+1] = ""
+			} else {
+				lines[i+1] = line[commonIndent:]
+			}
+		}
+	}
+
+	for len(lines) > 0 && leadingWhitespace(lines[0]) == len(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && leadingWhitespace(lines[len(lines)-1]) == len(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// leadingWhitespace returns the number of spaces and tabs a line starts with.
+func leadingWhitespace(line string) int {
+	i := 0
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
 	}
-	value += runeStringValueAt(body, chunkStart, position)
-	return makeToken(TokenKind[STRING], start, position+1, value), nil
+	return i
 }
 
 // Converts four hexidecimal chars to the integer that the
@@ -320,10 +664,6 @@ func char2hex(a rune) int {
 	return -1
 }
 
-func makeToken(kind int, start int, end int, value string) Token {
-	return Token{Kind: kind, Start: start, End: end, Value: value}
-}
-
 func printCharCode(code rune) string {
 	// NaN/undefined represents access beyond the end of the file.
 	if code < 0 {
@@ -336,148 +676,6 @@ func printCharCode(code rune) string {
 	// Otherwise print the escaped form. e.g. `"\\u0007"`
 	return fmt.Sprintf(`"\\u%04X"`, code)
 }
-
-func readToken(s *source.Source, fromPosition int) (Token, error) {
-	body := s.Body
-	bodyLength := len(body)
-	position := positionAfterWhitespace(body, fromPosition)
-	if position >= bodyLength {
-		return makeToken(TokenKind[EOF], position, position, ""), nil
-	}
-	code := charCodeAt(body, position)
-
-	// SourceCharacter
-	if code < 0x0020 && code != 0x0009 && code != 0x000A && code != 0x000D {
-		return Token{}, gqlerrors.NewSyntaxError(s, position, fmt.Sprintf(`Invalid character %v`, printCharCode(code)))
-	}
-
-	switch code {
-	// !
-	case 33:
-		return makeToken(TokenKind[BANG], position, position+1, ""), nil
-	// $
-	case 36:
-		return makeToken(TokenKind[DOLLAR], position, position+1, ""), nil
-	// (
-	case 40:
-		return makeToken(TokenKind[PAREN_L], position, position+1, ""), nil
-	// )
-	case 41:
-		return makeToken(TokenKind[PAREN_R], position, position+1, ""), nil
-	// .
-	case 46:
-		if charCodeAt(body, position+1) == 46 && charCodeAt(body, position+2) == 46 {
-			return makeToken(TokenKind[SPREAD], position, position+3, ""), nil
-		}
-		break
-	// :
-	case 58:
-		return makeToken(TokenKind[COLON], position, position+1, ""), nil
-	// =
-	case 61:
-		return makeToken(TokenKind[EQUALS], position, position+1, ""), nil
-	// @
-	case 64:
-		return makeToken(TokenKind[AT], position, position+1, ""), nil
-	// [
-	case 91:
-		return makeToken(TokenKind[BRACKET_L], position, position+1, ""), nil
-	// ]
-	case 93:
-		return makeToken(TokenKind[BRACKET_R], position, position+1, ""), nil
-	// {
-	case 123:
-		return makeToken(TokenKind[BRACE_L], position, position+1, ""), nil
-	// |
-	case 124:
-		return makeToken(TokenKind[PIPE], position, position+1, ""), nil
-	// }
-	case 125:
-		return makeToken(TokenKind[BRACE_R], position, position+1, ""), nil
-	// A-Z
-	case 65, 66, 67, 68, 69, 70, 71, 72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
-		82, 83, 84, 85, 86, 87, 88, 89, 90:
-		return readName(s, position), nil
-	// _
-	// a-z
-	case 95, 97, 98, 99, 100, 101, 102, 103, 104, 105, 106, 107, 108, 109, 110,
-		111, 112, 113, 114, 115, 116, 117, 118, 119, 120, 121, 122:
-		return readName(s, position), nil
-	// -
-	// 0-9
-	case 45, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57:
-		token, err := readNumber(s, position, code)
-		if err != nil {
-			return token, err
-		}
-		return token, nil
-	// "
-	case 34:
-		token, err := readString(s, position)
-		if err != nil {
-			return token, err
-		}
-		return token, nil
-	}
-	description := fmt.Sprintf("Unexpected character %v.", printCharCode(code))
-	return Token{}, gqlerrors.NewSyntaxError(s, position, description)
-}
-
-func charCodeAt(body string, position int) rune {
-	r := []rune(body)
-	if len(r) > position {
-		return r[position]
-	}
-	return -1
-
-}
-
-// Reads from body starting at startPosition until it finds a non-whitespace
-// or commented character, then returns the position of that character for lexing.
-// lexing.
-func positionAfterWhitespace(body string, startPosition int) int {
-	bodyLength := len(body)
-	position := startPosition
-	for {
-		if position < bodyLength {
-			code := charCodeAt(body, position)
-
-			// Skip Ignored
-			if code == 0xFEFF || // BOM
-				// White Space
-				code == 0x0009 || // tab
-				code == 0x0020 || // space
-				// Line Terminator
-				code == 0x000A || // new line
-				code == 0x000D || // carriage return
-				// Comma
-				code == 0x002C {
-				position++
-			} else if code == 35 { // #
-				position++
-				for {
-					code := charCodeAt(body, position)
-					if position < bodyLength &&
-						code != 0 &&
-						// SourceCharacter but not LineTerminator
-						(code > 0x001F || code == 0x0009) && code != 0x000A && code != 0x000D {
-						position++
-						continue
-					} else {
-						break
-					}
-				}
-			} else {
-				break
-			}
-			continue
-		} else {
-			break
-		}
-	}
-	return position
-}
-
 func GetTokenDesc(token Token) string {
 	if token.Value == "" {
 		return GetTokenKindDesc(token.Kind)
@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"io/ioutil"
 	"reflect"
 	"testing"
 
@@ -43,10 +44,12 @@ func TestAcceptsBOMHeader(t *testing.T) {
 		{
 			Body: "\uFEFF foo",
 			Expected: Token{
-				Kind:  TokenKind[NAME],
-				Start: 2,
-				End:   5,
-				Value: "foo",
+				Kind:   TokenKind[NAME],
+				Start:  2,
+				End:    5,
+				Value:  "foo",
+				Line:   1,
+				Column: 3,
 			},
 		},
 	}
@@ -70,10 +73,12 @@ func TestSkipsWhiteSpace(t *testing.T) {
 
 `,
 			Expected: Token{
-				Kind:  TokenKind[NAME],
-				Start: 6,
-				End:   9,
-				Value: "foo",
+				Kind:   TokenKind[NAME],
+				Start:  6,
+				End:    9,
+				Value:  "foo",
+				Line:   3,
+				Column: 5,
 			},
 		},
 		{
@@ -82,19 +87,23 @@ func TestSkipsWhiteSpace(t *testing.T) {
     foo#comment
 `,
 			Expected: Token{
-				Kind:  TokenKind[NAME],
-				Start: 18,
-				End:   21,
-				Value: "foo",
+				Kind:   TokenKind[NAME],
+				Start:  18,
+				End:    21,
+				Value:  "foo",
+				Line:   3,
+				Column: 5,
 			},
 		},
 		{
 			Body: `,,,foo,,,`,
 			Expected: Token{
-				Kind:  TokenKind[NAME],
-				Start: 3,
-				End:   6,
-				Value: "foo",
+				Kind:   TokenKind[NAME],
+				Start:  3,
+				End:    6,
+				Value:  "foo",
+				Line:   1,
+				Column: 4,
 			},
 		},
 	}
@@ -130,55 +139,67 @@ func TestLexesStrings(t *testing.T) {
 		{
 			Body: "\"simple\"",
 			Expected: Token{
-				Kind:  TokenKind[STRING],
-				Start: 0,
-				End:   8,
-				Value: "simple",
+				Kind:   TokenKind[STRING],
+				Start:  0,
+				End:    8,
+				Value:  "simple",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "\" white space \"",
 			Expected: Token{
-				Kind:  TokenKind[STRING],
-				Start: 0,
-				End:   15,
-				Value: " white space ",
+				Kind:   TokenKind[STRING],
+				Start:  0,
+				End:    15,
+				Value:  " white space ",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "\"quote \\\"\"",
 			Expected: Token{
-				Kind:  TokenKind[STRING],
-				Start: 0,
-				End:   10,
-				Value: `quote "`,
+				Kind:   TokenKind[STRING],
+				Start:  0,
+				End:    10,
+				Value:  `quote "`,
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "\"escaped \\n\\r\\b\\t\\f\"",
 			Expected: Token{
-				Kind:  TokenKind[STRING],
-				Start: 0,
-				End:   20,
-				Value: "escaped \n\r\b\t\f",
+				Kind:   TokenKind[STRING],
+				Start:  0,
+				End:    20,
+				Value:  "escaped \n\r\b\t\f",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "\"slashes \\\\ \\/\"",
 			Expected: Token{
-				Kind:  TokenKind[STRING],
-				Start: 0,
-				End:   15,
-				Value: "slashes \\ \\/",
+				Kind:   TokenKind[STRING],
+				Start:  0,
+				End:    15,
+				Value:  "slashes \\ \\/",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "\"unicode \\u1234\\u5678\\u90AB\\uCDEF\"",
 			Expected: Token{
-				Kind:  TokenKind[STRING],
-				Start: 0,
-				End:   34,
-				Value: "unicode \u1234\u5678\u90AB\uCDEF",
+				Kind:   TokenKind[STRING],
+				Start:  0,
+				End:    34,
+				Value:  "unicode \u1234\u5678\u90AB\uCDEF",
+				Line:   1,
+				Column: 1,
 			},
 		},
 	}
@@ -313,150 +334,339 @@ func TestLexReportsUsefulStringErrors(t *testing.T) {
 	}
 }
 
+func TestLexesBlockStrings(t *testing.T) {
+	tests := []Test{
+		{
+			Body: `"""simple"""`,
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    12,
+				Value:  "simple",
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Body: `""" white space """`,
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    19,
+				Value:  " white space ",
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Body: `"""contains " quote"""`,
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    22,
+				Value:  `contains " quote`,
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Body: `"""contains \""" triplequote"""`,
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    31,
+				Value:  `contains """ triplequote`,
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Body: "\"\"\"multi\nline\"\"\"",
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    16,
+				Value:  "multi\nline",
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Body: "\"\"\"multi\rline\r\nnormalized\"\"\"",
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    28,
+				Value:  "multi\nline\nnormalized",
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Body: `"""unescaped \n\r\b\t\f\u1234"""`,
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    32,
+				Value:  `unescaped \n\r\b\t\f\u1234`,
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Body: `"""slashes \\ \/"""`,
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    19,
+				Value:  `slashes \\ \/`,
+				Line:   1,
+				Column: 1,
+			},
+		},
+		{
+			Body: `"""
+
+        spans
+          multiple
+            lines
+
+        """`,
+			Expected: Token{
+				Kind:   TokenKind[BLOCK_STRING],
+				Start:  0,
+				End:    68,
+				Value:  "spans\n  multiple\n    lines",
+				Line:   1,
+				Column: 1,
+			},
+		},
+	}
+	for _, test := range tests {
+		token, err := Lex(&source.Source{Body: test.Body})(0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(token, test.Expected) {
+			t.Fatalf("unexpected token, expected: %v, got: %v", test.Expected, token)
+		}
+	}
+}
+
+func TestLexReportsUsefulBlockStringErrors(t *testing.T) {
+	tests := []Test{
+		{
+			Body: `"""`,
+			Expected: `Syntax Error GraphQL (1:4) Unterminated string.
+
+1: """
+      ^
+`,
+		},
+		{
+			Body: `"""no end quote`,
+			Expected: `Syntax Error GraphQL (1:16) Unterminated string.
+
+1: """no end quote
+                  ^
+`,
+		},
+		{
+			Body: "\"\"\"contains unescaped \u0007 control char\"\"\"",
+			Expected: `Syntax Error GraphQL (1:23) Invalid character within String: "\\u0007".
+
+1: """contains unescaped \u0007 control char"""
+                         ^
+`,
+		},
+	}
+	for _, test := range tests {
+		_, err := Lex(createSource(test.Body))(0)
+		if err == nil {
+			t.Fatalf("unexpected nil error\nexpected:\n%v\n\ngot:\n%v", test.Expected, err)
+		}
+		if err.Error() != test.Expected {
+			t.Fatalf("unexpected error.\nexpected:\n%v\n\ngot:\n%v", test.Expected, err.Error())
+		}
+	}
+}
+
 func TestLexesNumbers(t *testing.T) {
 	tests := []Test{
 		{
 			Body: "4",
 			Expected: Token{
-				Kind:  TokenKind[INT],
-				Start: 0,
-				End:   1,
-				Value: "4",
+				Kind:   TokenKind[INT],
+				Start:  0,
+				End:    1,
+				Value:  "4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "4.123",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   5,
-				Value: "4.123",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    5,
+				Value:  "4.123",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "-4",
 			Expected: Token{
-				Kind:  TokenKind[INT],
-				Start: 0,
-				End:   2,
-				Value: "-4",
+				Kind:   TokenKind[INT],
+				Start:  0,
+				End:    2,
+				Value:  "-4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "9",
 			Expected: Token{
-				Kind:  TokenKind[INT],
-				Start: 0,
-				End:   1,
-				Value: "9",
+				Kind:   TokenKind[INT],
+				Start:  0,
+				End:    1,
+				Value:  "9",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "0",
 			Expected: Token{
-				Kind:  TokenKind[INT],
-				Start: 0,
-				End:   1,
-				Value: "0",
+				Kind:   TokenKind[INT],
+				Start:  0,
+				End:    1,
+				Value:  "0",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "-4.123",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   6,
-				Value: "-4.123",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    6,
+				Value:  "-4.123",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "0.123",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   5,
-				Value: "0.123",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    5,
+				Value:  "0.123",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "123e4",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   5,
-				Value: "123e4",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    5,
+				Value:  "123e4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "123E4",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   5,
-				Value: "123E4",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    5,
+				Value:  "123E4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "123e-4",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   6,
-				Value: "123e-4",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    6,
+				Value:  "123e-4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "123e+4",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   6,
-				Value: "123e+4",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    6,
+				Value:  "123e+4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "-1.123e4",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   8,
-				Value: "-1.123e4",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    8,
+				Value:  "-1.123e4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "-1.123E4",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   8,
-				Value: "-1.123E4",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    8,
+				Value:  "-1.123E4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "-1.123e-4",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   9,
-				Value: "-1.123e-4",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    9,
+				Value:  "-1.123e-4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "-1.123e+4",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   9,
-				Value: "-1.123e+4",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    9,
+				Value:  "-1.123e+4",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "-1.123e4567",
 			Expected: Token{
-				Kind:  TokenKind[FLOAT],
-				Start: 0,
-				End:   11,
-				Value: "-1.123e4567",
+				Kind:   TokenKind[FLOAT],
+				Start:  0,
+				End:    11,
+				Value:  "-1.123e4567",
+				Line:   1,
+				Column: 1,
 			},
 		},
 	}
@@ -555,118 +765,144 @@ func TestLexesPunctuation(t *testing.T) {
 		{
 			Body: "!",
 			Expected: Token{
-				Kind:  TokenKind[BANG],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[BANG],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "$",
 			Expected: Token{
-				Kind:  TokenKind[DOLLAR],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[DOLLAR],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "(",
 			Expected: Token{
-				Kind:  TokenKind[PAREN_L],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[PAREN_L],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: ")",
 			Expected: Token{
-				Kind:  TokenKind[PAREN_R],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[PAREN_R],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "...",
 			Expected: Token{
-				Kind:  TokenKind[SPREAD],
-				Start: 0,
-				End:   3,
-				Value: "",
+				Kind:   TokenKind[SPREAD],
+				Start:  0,
+				End:    3,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: ":",
 			Expected: Token{
-				Kind:  TokenKind[COLON],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[COLON],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "=",
 			Expected: Token{
-				Kind:  TokenKind[EQUALS],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[EQUALS],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "@",
 			Expected: Token{
-				Kind:  TokenKind[AT],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[AT],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "[",
 			Expected: Token{
-				Kind:  TokenKind[BRACKET_L],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[BRACKET_L],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "]",
 			Expected: Token{
-				Kind:  TokenKind[BRACKET_R],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[BRACKET_R],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "{",
 			Expected: Token{
-				Kind:  TokenKind[BRACE_L],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[BRACE_L],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "|",
 			Expected: Token{
-				Kind:  TokenKind[PIPE],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[PIPE],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 		{
 			Body: "}",
 			Expected: Token{
-				Kind:  TokenKind[BRACE_R],
-				Start: 0,
-				End:   1,
-				Value: "",
+				Kind:   TokenKind[BRACE_R],
+				Start:  0,
+				End:    1,
+				Value:  "",
+				Line:   1,
+				Column: 1,
 			},
 		},
 	}
@@ -735,10 +971,12 @@ func TestLexRerportsUsefulInformationForDashesInNames(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	firstTokenExpected := Token{
-		Kind:  TokenKind[NAME],
-		Start: 0,
-		End:   1,
-		Value: "a",
+		Kind:   TokenKind[NAME],
+		Start:  0,
+		End:    1,
+		Value:  "a",
+		Line:   1,
+		Column: 1,
 	}
 	if !reflect.DeepEqual(firstToken, firstTokenExpected) {
 		t.Fatalf("unexpected token, expected: %v, got: %v", firstTokenExpected, firstToken)
@@ -756,3 +994,125 @@ func TestLexRerportsUsefulInformationForDashesInNames(t *testing.T) {
 		t.Fatalf("unexpected error, token:%v\nexpected:\n%v\n\ngot:\n%v", token, errExpected, err.Error())
 	}
 }
+
+func TestLexerTracksLinesAndColumns(t *testing.T) {
+	lexer := NewLexer(createSource("{\r\n  \"é\" ,\r  name\n\"\"\"\nblock\n\"\"\" }"))
+	expected := []Token{
+		{Kind: TokenKind[BRACE_L], Start: 0, End: 1, Line: 1, Column: 1},
+		{Kind: TokenKind[STRING], Start: 5, End: 8, Value: "é", Line: 2, Column: 3},
+		{Kind: TokenKind[NAME], Start: 13, End: 17, Value: "name", Line: 3, Column: 3},
+		{Kind: TokenKind[BLOCK_STRING], Start: 18, End: 31, Value: "block", Line: 4, Column: 1},
+		{Kind: TokenKind[BRACE_R], Start: 32, End: 33, Line: 6, Column: 5},
+		{Kind: TokenKind[EOF], Start: 33, End: 33, Line: 6, Column: 6},
+	}
+	for _, expectedToken := range expected {
+		peeked, err := lexer.Peek()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		token, err := lexer.Next()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(peeked, token) {
+			t.Fatalf("unexpected peeked token, expected: %v, got: %v", token, peeked)
+		}
+		if !reflect.DeepEqual(token, expectedToken) {
+			t.Fatalf("unexpected token, expected: %#v, got: %#v", expectedToken, token)
+		}
+	}
+}
+
+func TestLexerCollectsComments(t *testing.T) {
+	lexer := NewLexer(createSource("# first\n{ # inline\n  # next\n  a }"))
+	expected := []struct {
+		Comments []string
+		Inline   bool
+	}{
+		{[]string{" first"}, false},
+		{[]string{" inline", " next"}, true},
+		{nil, false},
+	}
+	for _, test := range expected {
+		if _, err := lexer.Next(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		comments, inline := lexer.Comments()
+		values := []string(nil)
+		for _, comment := range comments {
+			if comment.Kind != TokenKind[COMMENT] {
+				t.Fatalf("unexpected comment kind: %v", comment.Kind)
+			}
+			values = append(values, comment.Value)
+		}
+		if !reflect.DeepEqual(values, test.Comments) || inline != test.Inline {
+			t.Fatalf("unexpected comments, expected: %v %v, got: %v %v", test.Comments, test.Inline, values, inline)
+		}
+	}
+}
+
+func TestLexerSeeksPositions(t *testing.T) {
+	lexer := NewLexer(createSource("a\n b ? c"))
+	if _, err := lexer.Next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := lexer.Next(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := lexer.Next(); err == nil {
+		t.Fatalf("unexpected nil error")
+	}
+	lexer.Seek(6)
+	token, err := lexer.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := Token{Kind: TokenKind[NAME], Start: 7, End: 8, Value: "c", Line: 2, Column: 6}
+	if !reflect.DeepEqual(token, expected) {
+		t.Fatalf("unexpected token, expected: %v, got: %v", expected, token)
+	}
+	lexer.Seek(1)
+	token, err = lexer.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = Token{Kind: TokenKind[NAME], Start: 3, End: 4, Value: "b", Line: 2, Column: 2}
+	if !reflect.DeepEqual(token, expected) {
+		t.Fatalf("unexpected token, expected: %v, got: %v", expected, token)
+	}
+}
+
+func BenchmarkLexKitchenSink(b *testing.B) {
+	body, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		b.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	s := createSource(string(body))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lexer := NewLexer(s)
+		for {
+			token, err := lexer.Next()
+			if err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+			if token.Kind == TokenKind[EOF] {
+				break
+			}
+		}
+	}
+}
+
+func TestReadComments(t *testing.T) {
+	s := createSource("a # inline\n# next\n? b")
+	comments := ReadComments(s, 1)
+	values := []string{}
+	for _, comment := range comments {
+		values = append(values, comment.Value)
+	}
+	expected := []string{" inline", " next"}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("unexpected comments, expected: %v, got: %v", expected, values)
+	}
+}
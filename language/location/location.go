@@ -1,8 +1,6 @@
 package location
 
 import (
-	"regexp"
-
 	"github.com/graphql-go/graphql/language/source"
 )
 
@@ -11,25 +9,12 @@ type SourceLocation struct {
 	Column int `json:"column"`
 }
 
+// GetLocation returns the location of the character at the given offset of
+// the source, its column being counted in the ColumnUnit of the source.
 func GetLocation(s *source.Source, position int) SourceLocation {
-	body := ""
-	if s != nil {
-		body = s.Body
-	}
-	line := 1
-	column := position + 1
-	lineRegexp := regexp.MustCompile("\r\n|[\n\r]")
-	matches := lineRegexp.FindAllStringIndex(body, -1)
-	for _, match := range matches {
-		matchIndex := match[0]
-		if matchIndex < position {
-			line++
-			l := len(s.Body[match[0]:match[1]])
-			column = position + 1 - (matchIndex + l)
-			continue
-		} else {
-			break
-		}
+	if s == nil {
+		return SourceLocation{Line: 1, Column: position + 1}
 	}
+	line, column := s.Location(position)
 	return SourceLocation{Line: line, Column: column}
 }
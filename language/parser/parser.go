@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/graphql-go/graphql/gqlerrors"
 	"github.com/graphql-go/graphql/language/ast"
@@ -14,6 +15,17 @@ type parseFn func(parser *Parser) (interface{}, error)
 type ParseOptions struct {
 	NoLocation bool
 	NoSource   bool
+	// KeepComments attaches the comments of the source to the nodes they
+	// surround, and lists them all in the document.
+	KeepComments bool
+
+	// Limits guarding against malicious documents; zero means no limit.
+	// MaxTokens is the maximum number of tokens of the document, MaxDepth the
+	// maximum nesting of its selection sets, lists, objects and list types,
+	// and MaxBodySize the maximum size of its body in bytes.
+	MaxTokens   int
+	MaxDepth    int
+	MaxBodySize int
 }
 
 type ParseParams struct {
@@ -22,11 +34,32 @@ type ParseParams struct {
 }
 
 type Parser struct {
-	LexToken lexer.Lexer
-	Source   *source.Source
-	Options  ParseOptions
-	PrevEnd  int
-	Token    lexer.Token
+	Lexer   *lexer.Lexer
+	Source  *source.Source
+	Options ParseOptions
+	PrevEnd int
+	Token   lexer.Token
+
+	// LexToken lexes the tokens of the source independently of the parser.
+	//
+	// Deprecated: the parser reads its tokens from Lexer.
+	LexToken lexer.LexFunc
+
+	// comments read so far, those not attached to a node yet, and the one
+	// on the line of the previous token, if any
+	comments        []*ast.Comment
+	pendingComments []*ast.Comment
+	inlineComment   *ast.Comment
+
+	// tokens read so far, and the current nesting depth
+	tokenCount int
+	depth      int
+
+	// when tolerant, the syntax errors recorded so far, and whether a limit
+	// was exceeded, which aborts parsing
+	tolerant      bool
+	errors        []error
+	limitExceeded bool
 }
 
 func Parse(p ParseParams) (*ast.Document, error) {
@@ -38,7 +71,7 @@ func Parse(p ParseParams) (*ast.Document, error) {
 		body, _ := p.Source.(string)
 		sourceObj = source.NewSource(&source.Source{Body: body})
 	}
-	parser, err := makeParser(sourceObj, p.Options)
+	parser, err := makeParser(sourceObj, p.Options, false)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +82,32 @@ func Parse(p ParseParams) (*ast.Document, error) {
 	return doc, nil
 }
 
+// ParseTolerant parses the source like Parse, but does not stop at the first
+// syntax error: it records the error and goes on, inserting placeholders for
+// missing tokens (a missing name being a name with an empty value, located
+// at the end of the previous token) and skipping unexpected ones. It returns
+// a best-effort document along with all the syntax errors, the document
+// being nil only when a limit set in the options is exceeded.
+func ParseTolerant(p ParseParams) (*ast.Document, []error) {
+	var sourceObj *source.Source
+	switch p.Source.(type) {
+	case *source.Source:
+		sourceObj = p.Source.(*source.Source)
+	default:
+		body, _ := p.Source.(string)
+		sourceObj = source.NewSource(&source.Source{Body: body})
+	}
+	parser, err := makeParser(sourceObj, p.Options, true)
+	if err != nil {
+		return nil, append(parser.errors, err)
+	}
+	doc, err := parseDocument(parser)
+	if err != nil {
+		return nil, append(parser.errors, err)
+	}
+	return doc, parser.errors
+}
+
 // TODO: test and expose parseValue as a public
 func parseValue(p ParseParams) (ast.Value, error) {
 	var value ast.Value
@@ -60,7 +119,7 @@ func parseValue(p ParseParams) (ast.Value, error) {
 		body, _ := p.Source.(string)
 		sourceObj = source.NewSource(&source.Source{Body: body})
 	}
-	parser, err := makeParser(sourceObj, p.Options)
+	parser, err := makeParser(sourceObj, p.Options, false)
 	if err != nil {
 		return value, err
 	}
@@ -83,19 +142,30 @@ func parseName(parser *Parser) (*ast.Name, error) {
 	}), nil
 }
 
-func makeParser(s *source.Source, opts ParseOptions) (*Parser, error) {
-	lexToken := lexer.Lex(s)
-	token, err := lexToken(0)
-	if err != nil {
-		return &Parser{}, err
+func makeParser(s *source.Source, opts ParseOptions, tolerant bool) (*Parser, error) {
+	if opts.MaxBodySize > 0 && len(s.Body) > opts.MaxBodySize {
+		position := utf8.RuneCountInString(s.Body[:opts.MaxBodySize])
+		description := fmt.Sprintf("Document exceeds the maximum size of %v bytes.", opts.MaxBodySize)
+		return &Parser{}, gqlerrors.NewSyntaxError(s, position, description)
 	}
-	return &Parser{
-		LexToken: lexToken,
+	parser := &Parser{
+		Lexer:    lexer.NewLexer(s),
+		LexToken: lexer.Lex(s),
 		Source:   s,
 		Options:  opts,
 		PrevEnd:  0,
-		Token:    token,
-	}, nil
+		tolerant: tolerant,
+	}
+	token, err := lexToken(parser, parser.Lexer.Next)
+	if err != nil {
+		return parser, err
+	}
+	parser.Token = token
+	if err := countToken(parser); err != nil {
+		return parser, err
+	}
+	readComments(parser)
+	return parser, nil
 }
 
 /* Implements the parsing rules in the Document section. */
@@ -109,103 +179,150 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
 		} else if skp {
 			break
 		}
-		if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
-			node, err := parseOperationDefinition(parser)
-			if err != nil {
+		definitionStart := parser.Token.Start
+		node, err := parseDefinition(parser)
+		if err != nil {
+			if !recoverFrom(parser, err) {
 				return nil, err
 			}
-			nodes = append(nodes, node)
-		} else if peek(parser, lexer.TokenKind[lexer.NAME]) {
-			switch parser.Token.Value {
-			case "query":
-				fallthrough
-			case "mutation":
-				fallthrough
-			case "subscription": // Note: subscription is an experimental non-spec addition.
-				node, err := parseOperationDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "fragment":
-				node, err := parseFragmentDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-
-			// Note: the Type System IDL is an experimental non-spec addition.
-			case "schema":
-				node, err := parseSchemaDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "scalar":
-				node, err := parseScalarTypeDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "type":
-				node, err := parseObjectTypeDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "interface":
-				node, err := parseInterfaceTypeDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "union":
-				node, err := parseUnionTypeDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "enum":
-				node, err := parseEnumTypeDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "input":
-				node, err := parseInputObjectTypeDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "extend":
-				node, err := parseTypeExtensionDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			case "directive":
-				node, err := parseDirectiveDefinition(parser)
-				if err != nil {
-					return nil, err
-				}
-				nodes = append(nodes, node)
-			default:
-				if err := unexpected(parser, lexer.Token{}); err != nil {
-					return nil, err
-				}
-			}
-		} else {
-			if err := unexpected(parser, lexer.Token{}); err != nil {
+			if err := skipToDefinition(parser, definitionStart); err != nil {
 				return nil, err
 			}
+			continue
 		}
+		nodes = append(nodes, node)
 	}
 	return ast.NewDocument(&ast.Document{
 		Loc:         loc(parser, start),
 		Definitions: nodes,
+		Comments:    parser.comments,
 	}), nil
 }
 
+/**
+ * Definition :
+ *   - OperationDefinition
+ *   - FragmentDefinition
+ *   - TypeSystemDefinition
+ */
+func parseDefinition(parser *Parser) (ast.Node, error) {
+	if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+		return parseOperationDefinition(parser)
+	}
+	if peek(parser, lexer.TokenKind[lexer.NAME]) || peekDescription(parser) {
+		keyword := parser.Token
+		if peekDescription(parser) {
+			// only type system definitions may have a description
+			token, err := lookahead(parser)
+			if err != nil {
+				return nil, err
+			}
+			switch token.Value {
+			case "scalar", "type", "interface", "union", "enum", "input", "directive":
+				keyword = token
+			default:
+				return nil, unexpected(parser, lexer.Token{})
+			}
+		}
+		switch keyword.Value {
+		case "query":
+			fallthrough
+		case "mutation":
+			fallthrough
+		case "subscription": // Note: subscription is an experimental non-spec addition.
+			return parseOperationDefinition(parser)
+		case "fragment":
+			return parseFragmentDefinition(parser)
+
+		// Note: the Type System IDL is an experimental non-spec addition.
+		case "schema":
+			return parseSchemaDefinition(parser)
+		case "scalar":
+			return parseScalarTypeDefinition(parser)
+		case "type":
+			return parseObjectTypeDefinition(parser)
+		case "interface":
+			return parseInterfaceTypeDefinition(parser)
+		case "union":
+			return parseUnionTypeDefinition(parser)
+		case "enum":
+			return parseEnumTypeDefinition(parser)
+		case "input":
+			return parseInputObjectTypeDefinition(parser)
+		case "extend":
+			return parseExtensionDefinition(parser)
+		case "directive":
+			return parseDirectiveDefinition(parser)
+		}
+	}
+	return nil, unexpected(parser, lexer.Token{})
+}
+
+// Skips the tokens of a definition which could not be parsed, up to the
+// start of the next one.
+func skipToDefinition(parser *Parser, definitionStart int) error {
+	for {
+		if parser.Token.Start == definitionStart || !peekDefinition(parser) {
+			if peek(parser, lexer.TokenKind[lexer.EOF]) {
+				return nil
+			}
+			if err := advance(parser); err != nil {
+				return err
+			}
+			continue
+		}
+		return nil
+	}
+}
+
+// Determines if the next token may start a definition
+func peekDefinition(parser *Parser) bool {
+	if peek(parser, lexer.TokenKind[lexer.BRACE_L]) || peekDescription(parser) {
+		return true
+	}
+	if !peek(parser, lexer.TokenKind[lexer.NAME]) {
+		return false
+	}
+	switch parser.Token.Value {
+	case "query", "mutation", "subscription", "fragment", "schema", "scalar",
+		"type", "interface", "union", "enum", "input", "extend", "directive":
+		return true
+	}
+	return false
+}
+
+/**
+ * TypeSystemExtension :
+ *   - SchemaExtension
+ *   - TypeExtension
+ */
+func parseExtensionDefinition(parser *Parser) (ast.Node, error) {
+	token, err := lookahead(parser)
+	if err != nil {
+		return nil, err
+	}
+	switch token.Value {
+	case "schema":
+		return parseSchemaExtensionDefinition(parser)
+	case "scalar":
+		return parseScalarExtensionDefinition(parser)
+	case "type":
+		return parseTypeExtensionDefinition(parser)
+	case "interface":
+		return parseInterfaceExtensionDefinition(parser)
+	case "union":
+		return parseUnionExtensionDefinition(parser)
+	case "enum":
+		return parseEnumExtensionDefinition(parser)
+	case "input":
+		return parseInputObjectExtensionDefinition(parser)
+	}
+	if err := advance(parser); err != nil {
+		return nil, err
+	}
+	return nil, unexpected(parser, token)
+}
+
 /* Implements the parsing rules in the Operations section. */
 
 /**
@@ -215,6 +332,7 @@ func parseDocument(parser *Parser) (*ast.Document, error) {
  */
 func parseOperationDefinition(parser *Parser) (*ast.OperationDefinition, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
 		selectionSet, err := parseSelectionSet(parser)
 		if err != nil {
@@ -225,6 +343,7 @@ func parseOperationDefinition(parser *Parser) (*ast.OperationDefinition, error)
 			Directives:   []*ast.Directive{},
 			SelectionSet: selectionSet,
 			Loc:          loc(parser, start),
+			Comments:     trailingComments(parser, comments),
 		}), nil
 	}
 	operation, err := parseOperationType(parser)
@@ -255,6 +374,7 @@ func parseOperationDefinition(parser *Parser) (*ast.OperationDefinition, error)
 		Directives:          directives,
 		SelectionSet:        selectionSet,
 		Loc:                 loc(parser, start),
+		Comments:            trailingComments(parser, comments),
 	}), nil
 }
 
@@ -299,10 +419,11 @@ func parseVariableDefinitions(parser *Parser) ([]*ast.VariableDefinition, error)
 }
 
 /**
- * VariableDefinition : Variable : Type DefaultValue?
+ * VariableDefinition : Variable : Type DefaultValue? Directives?
  */
 func parseVariableDefinition(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	variable, err := parseVariable(parser)
 	if err != nil {
 		return nil, err
@@ -325,11 +446,17 @@ func parseVariableDefinition(parser *Parser) (interface{}, error) {
 		}
 		defaultValue = dv
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewVariableDefinition(&ast.VariableDefinition{
 		Variable:     variable,
 		Type:         ttype,
 		DefaultValue: defaultValue,
+		Directives:   directives,
 		Loc:          loc(parser, start),
+		Comments:     trailingComments(parser, comments),
 	}), nil
 }
 
@@ -357,6 +484,10 @@ func parseVariable(parser *Parser) (*ast.Variable, error) {
  */
 func parseSelectionSet(parser *Parser) (*ast.SelectionSet, error) {
 	start := parser.Token.Start
+	if err := enterNesting(parser); err != nil {
+		return nil, err
+	}
+	defer leaveNesting(parser)
 	iSelections, err := many(parser, lexer.TokenKind[lexer.BRACE_L], parseSelection, lexer.TokenKind[lexer.BRACE_R])
 	if err != nil {
 		return nil, err
@@ -396,6 +527,7 @@ func parseSelection(parser *Parser) (interface{}, error) {
  */
 func parseField(parser *Parser) (*ast.Field, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	nameOrAlias, err := parseName(parser)
 	if err != nil {
 		return nil, err
@@ -439,6 +571,7 @@ func parseField(parser *Parser) (*ast.Field, error) {
 		Directives:   directives,
 		SelectionSet: selectionSet,
 		Loc:          loc(parser, start),
+		Comments:     trailingComments(parser, comments),
 	}), nil
 }
 
@@ -467,6 +600,7 @@ func parseArguments(parser *Parser) ([]*ast.Argument, error) {
  */
 func parseArgument(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
@@ -480,9 +614,10 @@ func parseArgument(parser *Parser) (interface{}, error) {
 		return nil, err
 	}
 	return ast.NewArgument(&ast.Argument{
-		Name:  name,
-		Value: value,
-		Loc:   loc(parser, start),
+		Name:     name,
+		Value:    value,
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
 	}), nil
 }
 
@@ -497,6 +632,7 @@ func parseArgument(parser *Parser) (interface{}, error) {
  */
 func parseFragment(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	_, err := expect(parser, lexer.TokenKind[lexer.SPREAD])
 	if err != nil {
 		return nil, err
@@ -514,6 +650,7 @@ func parseFragment(parser *Parser) (interface{}, error) {
 			Name:       name,
 			Directives: directives,
 			Loc:        loc(parser, start),
+			Comments:   trailingComments(parser, comments),
 		}), nil
 	}
 	var typeCondition *ast.Named
@@ -541,6 +678,7 @@ func parseFragment(parser *Parser) (interface{}, error) {
 		Directives:    directives,
 		SelectionSet:  selectionSet,
 		Loc:           loc(parser, start),
+		Comments:      trailingComments(parser, comments),
 	}), nil
 }
 
@@ -552,6 +690,7 @@ func parseFragment(parser *Parser) (interface{}, error) {
  */
 func parseFragmentDefinition(parser *Parser) (*ast.FragmentDefinition, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	_, err := expectKeyWord(parser, "fragment")
 	if err != nil {
 		return nil, err
@@ -582,6 +721,7 @@ func parseFragmentDefinition(parser *Parser) (*ast.FragmentDefinition, error) {
 		Directives:    directives,
 		SelectionSet:  selectionSet,
 		Loc:           loc(parser, start),
+		Comments:      trailingComments(parser, comments),
 	}), nil
 }
 
@@ -604,12 +744,15 @@ func parseFragmentName(parser *Parser) (*ast.Name, error) {
  *   - FloatValue
  *   - StringValue
  *   - BooleanValue
+ *   - NullValue
  *   - EnumValue
  *   - ListValue[?Const]
  *   - ObjectValue[?Const]
  *
  * BooleanValue : one of `true` `false`
  *
+ * NullValue : `null`
+ *
  * EnumValue : Name but not `true`, `false` or `null`
  */
 func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
@@ -635,12 +778,13 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 			Value: token.Value,
 			Loc:   loc(parser, token.Start),
 		}), nil
-	case lexer.TokenKind[lexer.STRING]:
+	case lexer.TokenKind[lexer.STRING], lexer.TokenKind[lexer.BLOCK_STRING]:
 		if err := advance(parser); err != nil {
 			return nil, err
 		}
 		return ast.NewStringValue(&ast.StringValue{
 			Value: token.Value,
+			Block: token.Kind == lexer.TokenKind[lexer.BLOCK_STRING],
 			Loc:   loc(parser, token.Start),
 		}), nil
 	case lexer.TokenKind[lexer.NAME]:
@@ -656,15 +800,21 @@ func parseValueLiteral(parser *Parser, isConst bool) (ast.Value, error) {
 				Value: value,
 				Loc:   loc(parser, token.Start),
 			}), nil
-		} else if token.Value != "null" {
+		} else if token.Value == "null" {
 			if err := advance(parser); err != nil {
 				return nil, err
 			}
-			return ast.NewEnumValue(&ast.EnumValue{
-				Value: token.Value,
-				Loc:   loc(parser, token.Start),
+			return ast.NewNullValue(&ast.NullValue{
+				Loc: loc(parser, token.Start),
 			}), nil
 		}
+		if err := advance(parser); err != nil {
+			return nil, err
+		}
+		return ast.NewEnumValue(&ast.EnumValue{
+			Value: token.Value,
+			Loc:   loc(parser, token.Start),
+		}), nil
 	case lexer.TokenKind[lexer.DOLLAR]:
 		if !isConst {
 			return parseVariable(parser)
@@ -684,8 +834,35 @@ func parseConstValue(parser *Parser) (interface{}, error) {
 	return value, nil
 }
 
-func parseValueValue(parser *Parser) (interface{}, error) {
-	return parseValueLiteral(parser, false)
+// Parses an item of a list value along with its comments.
+func parseListItem(parser *Parser, isConst bool) (interface{}, error) {
+	comments := leadingComments(parser)
+	value, err := parseValueLiteral(parser, isConst)
+	if err != nil {
+		return nil, err
+	}
+	group := trailingComments(parser, comments)
+	switch value := value.(type) {
+	case *ast.Variable:
+		value.Comments = group
+	case *ast.IntValue:
+		value.Comments = group
+	case *ast.FloatValue:
+		value.Comments = group
+	case *ast.StringValue:
+		value.Comments = group
+	case *ast.BooleanValue:
+		value.Comments = group
+	case *ast.NullValue:
+		value.Comments = group
+	case *ast.EnumValue:
+		value.Comments = group
+	case *ast.ListValue:
+		value.Comments = group
+	case *ast.ObjectValue:
+		value.Comments = group
+	}
+	return value, nil
 }
 
 /**
@@ -695,11 +872,12 @@ func parseValueValue(parser *Parser) (interface{}, error) {
  */
 func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
 	start := parser.Token.Start
-	var item parseFn
-	if isConst {
-		item = parseConstValue
-	} else {
-		item = parseValueValue
+	if err := enterNesting(parser); err != nil {
+		return nil, err
+	}
+	defer leaveNesting(parser)
+	item := func(parser *Parser) (interface{}, error) {
+		return parseListItem(parser, isConst)
 	}
 	iValues, err := any(parser, lexer.TokenKind[lexer.BRACKET_L], item, lexer.TokenKind[lexer.BRACKET_R])
 	if err != nil {
@@ -722,6 +900,10 @@ func parseList(parser *Parser, isConst bool) (*ast.ListValue, error) {
  */
 func parseObject(parser *Parser, isConst bool) (*ast.ObjectValue, error) {
 	start := parser.Token.Start
+	if err := enterNesting(parser); err != nil {
+		return nil, err
+	}
+	defer leaveNesting(parser)
 	_, err := expect(parser, lexer.TokenKind[lexer.BRACE_L])
 	if err != nil {
 		return nil, err
@@ -750,6 +932,7 @@ func parseObject(parser *Parser, isConst bool) (*ast.ObjectValue, error) {
  */
 func parseObjectField(parser *Parser, isConst bool) (*ast.ObjectField, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
@@ -763,9 +946,10 @@ func parseObjectField(parser *Parser, isConst bool) (*ast.ObjectField, error) {
 		return nil, err
 	}
 	return ast.NewObjectField(&ast.ObjectField{
-		Name:  name,
-		Value: value,
-		Loc:   loc(parser, start),
+		Name:     name,
+		Value:    value,
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
 	}), nil
 }
 
@@ -824,6 +1008,12 @@ func parseDirective(parser *Parser) (*ast.Directive, error) {
 func parseType(parser *Parser) (ast.Type, error) {
 	start := parser.Token.Start
 	var ttype ast.Type
+	if peek(parser, lexer.TokenKind[lexer.BRACKET_L]) {
+		if err := enterNesting(parser); err != nil {
+			return nil, err
+		}
+		defer leaveNesting(parser)
+	}
 	if skp, err := skip(parser, lexer.TokenKind[lexer.BRACKET_L]); err != nil {
 		return nil, err
 	} else if skp {
@@ -883,10 +1073,29 @@ func parseNamed(parser *Parser) (*ast.Named, error) {
  */
 func parseSchemaDefinition(parser *Parser) (*ast.SchemaDefinition, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	_, err := expectKeyWord(parser, "schema")
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	operationTypes, err := parseOperationTypeDefinitions(parser)
+	if err != nil {
+		return nil, err
+	}
+	def := ast.NewSchemaDefinition(&ast.SchemaDefinition{
+		Directives:     directives,
+		OperationTypes: operationTypes,
+		Loc:            loc(parser, start),
+		Comments:       trailingComments(parser, comments),
+	})
+	return def, nil
+}
+
+func parseOperationTypeDefinitions(parser *Parser) ([]*ast.OperationTypeDefinition, error) {
 	operationTypesI, err := many(
 		parser,
 		lexer.TokenKind[lexer.BRACE_L],
@@ -902,15 +1111,12 @@ func parseSchemaDefinition(parser *Parser) (*ast.SchemaDefinition, error) {
 			operationTypes = append(operationTypes, op)
 		}
 	}
-	def := ast.NewSchemaDefinition(&ast.SchemaDefinition{
-		OperationTypes: operationTypes,
-		Loc:            loc(parser, start),
-	})
-	return def, nil
+	return operationTypes, nil
 }
 
 func parseOperationTypeDefinition(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	operation, err := parseOperationType(parser)
 	if err != nil {
 		return nil, err
@@ -927,15 +1133,36 @@ func parseOperationTypeDefinition(parser *Parser) (interface{}, error) {
 		Operation: operation,
 		Type:      ttype,
 		Loc:       loc(parser, start),
+		Comments:  trailingComments(parser, comments),
 	}), nil
 }
 
 /**
- * ScalarTypeDefinition : scalar Name
+ * Description : StringValue
+ */
+func parseDescription(parser *Parser) (*ast.StringValue, error) {
+	if !peekDescription(parser) {
+		return nil, nil
+	}
+	value, err := parseValueLiteral(parser, true)
+	if err != nil {
+		return nil, err
+	}
+	description, _ := value.(*ast.StringValue)
+	return description, nil
+}
+
+/**
+ * ScalarTypeDefinition : Description? scalar Name Directives?
  */
 func parseScalarTypeDefinition(parser *Parser) (*ast.ScalarDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "scalar")
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "scalar")
 	if err != nil {
 		return nil, err
 	}
@@ -943,19 +1170,32 @@ func parseScalarTypeDefinition(parser *Parser) (*ast.ScalarDefinition, error) {
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	def := ast.NewScalarDefinition(&ast.ScalarDefinition{
-		Name: name,
-		Loc:  loc(parser, start),
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
 	})
 	return def, nil
 }
 
 /**
- * ObjectTypeDefinition : type Name ImplementsInterfaces? { FieldDefinition+ }
+ * ObjectTypeDefinition :
+ *   - Description? type Name ImplementsInterfaces? Directives? { FieldDefinition+ }
  */
 func parseObjectTypeDefinition(parser *Parser) (*ast.ObjectDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "type")
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "type")
 	if err != nil {
 		return nil, err
 	}
@@ -967,6 +1207,29 @@ func parseObjectTypeDefinition(parser *Parser) (*ast.ObjectDefinition, error) {
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseFieldDefinitions(parser)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewObjectDefinition(&ast.ObjectDefinition{
+		Description: description,
+		Name:        name,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
+		Interfaces:  interfaces,
+		Directives:  directives,
+		Fields:      fields,
+	}), nil
+}
+
+/**
+ * FieldsDefinition : { FieldDefinition* }
+ */
+func parseFieldDefinitions(parser *Parser) ([]*ast.FieldDefinition, error) {
 	iFields, err := any(parser, lexer.TokenKind[lexer.BRACE_L], parseFieldDefinition, lexer.TokenKind[lexer.BRACE_R])
 	if err != nil {
 		return nil, err
@@ -977,12 +1240,7 @@ func parseObjectTypeDefinition(parser *Parser) (*ast.ObjectDefinition, error) {
 			fields = append(fields, iField.(*ast.FieldDefinition))
 		}
 	}
-	return ast.NewObjectDefinition(&ast.ObjectDefinition{
-		Name:       name,
-		Loc:        loc(parser, start),
-		Interfaces: interfaces,
-		Fields:     fields,
-	}), nil
+	return fields, nil
 }
 
 /**
@@ -1000,7 +1258,7 @@ func parseImplementsInterfaces(parser *Parser) ([]*ast.Named, error) {
 				return types, err
 			}
 			types = append(types, ttype)
-			if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+			if !peek(parser, lexer.TokenKind[lexer.NAME]) {
 				break
 			}
 		}
@@ -1009,10 +1267,15 @@ func parseImplementsInterfaces(parser *Parser) ([]*ast.Named, error) {
 }
 
 /**
- * FieldDefinition : Name ArgumentsDefinition? : Type
+ * FieldDefinition : Description? Name ArgumentsDefinition? : Type Directives?
  */
 func parseFieldDefinition(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
@@ -1029,11 +1292,18 @@ func parseFieldDefinition(parser *Parser) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewFieldDefinition(&ast.FieldDefinition{
-		Name:      name,
-		Arguments: args,
-		Type:      ttype,
-		Loc:       loc(parser, start),
+		Description: description,
+		Name:        name,
+		Arguments:   args,
+		Type:        ttype,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
 	}), nil
 }
 
@@ -1059,10 +1329,15 @@ func parseArgumentDefs(parser *Parser) ([]*ast.InputValueDefinition, error) {
 }
 
 /**
- * InputValueDefinition : Name : Type DefaultValue?
+ * InputValueDefinition : Description? Name : Type DefaultValue? Directives?
  */
 func parseInputValueDef(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
@@ -1087,20 +1362,33 @@ func parseInputValueDef(parser *Parser) (interface{}, error) {
 			defaultValue = val
 		}
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewInputValueDefinition(&ast.InputValueDefinition{
+		Description:  description,
 		Name:         name,
 		Type:         ttype,
 		DefaultValue: defaultValue,
+		Directives:   directives,
 		Loc:          loc(parser, start),
+		Comments:     trailingComments(parser, comments),
 	}), nil
 }
 
 /**
- * InterfaceTypeDefinition : interface Name { FieldDefinition+ }
+ * InterfaceTypeDefinition :
+ *   - Description? interface Name ImplementsInterfaces? Directives? { FieldDefinition+ }
  */
 func parseInterfaceTypeDefinition(parser *Parser) (*ast.InterfaceDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "interface")
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "interface")
 	if err != nil {
 		return nil, err
 	}
@@ -1108,29 +1396,40 @@ func parseInterfaceTypeDefinition(parser *Parser) (*ast.InterfaceDefinition, err
 	if err != nil {
 		return nil, err
 	}
-	iFields, err := any(parser, lexer.TokenKind[lexer.BRACE_L], parseFieldDefinition, lexer.TokenKind[lexer.BRACE_R])
+	interfaces, err := parseImplementsInterfaces(parser)
 	if err != nil {
 		return nil, err
 	}
-	fields := []*ast.FieldDefinition{}
-	for _, iField := range iFields {
-		if iField != nil {
-			fields = append(fields, iField.(*ast.FieldDefinition))
-		}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseFieldDefinitions(parser)
+	if err != nil {
+		return nil, err
 	}
 	return ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
-		Name:   name,
-		Loc:    loc(parser, start),
-		Fields: fields,
+		Description: description,
+		Name:        name,
+		Interfaces:  interfaces,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
+		Fields:      fields,
 	}), nil
 }
 
 /**
- * UnionTypeDefinition : union Name = UnionMembers
+ * UnionTypeDefinition : Description? union Name Directives? = UnionMembers
  */
 func parseUnionTypeDefinition(parser *Parser) (*ast.UnionDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "union")
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "union")
 	if err != nil {
 		return nil, err
 	}
@@ -1138,6 +1437,10 @@ func parseUnionTypeDefinition(parser *Parser) (*ast.UnionDefinition, error) {
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	_, err = expect(parser, lexer.TokenKind[lexer.EQUALS])
 	if err != nil {
 		return nil, err
@@ -1147,9 +1450,12 @@ func parseUnionTypeDefinition(parser *Parser) (*ast.UnionDefinition, error) {
 		return nil, err
 	}
 	return ast.NewUnionDefinition(&ast.UnionDefinition{
-		Name:  name,
-		Loc:   loc(parser, start),
-		Types: types,
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
+		Types:       types,
 	}), nil
 }
 
@@ -1176,11 +1482,16 @@ func parseUnionMembers(parser *Parser) ([]*ast.Named, error) {
 }
 
 /**
- * EnumTypeDefinition : enum Name { EnumValueDefinition+ }
+ * EnumTypeDefinition : Description? enum Name Directives? { EnumValueDefinition+ }
  */
 func parseEnumTypeDefinition(parser *Parser) (*ast.EnumDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "enum")
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "enum")
 	if err != nil {
 		return nil, err
 	}
@@ -1188,6 +1499,28 @@ func parseEnumTypeDefinition(parser *Parser) (*ast.EnumDefinition, error) {
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseEnumValueDefinitions(parser)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewEnumDefinition(&ast.EnumDefinition{
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
+		Values:      values,
+	}), nil
+}
+
+/**
+ * EnumValuesDefinition : { EnumValueDefinition* }
+ */
+func parseEnumValueDefinitions(parser *Parser) ([]*ast.EnumValueDefinition, error) {
 	iEnumValueDefs, err := any(parser, lexer.TokenKind[lexer.BRACE_L], parseEnumValueDefinition, lexer.TokenKind[lexer.BRACE_R])
 	if err != nil {
 		return nil, err
@@ -1198,36 +1531,49 @@ func parseEnumTypeDefinition(parser *Parser) (*ast.EnumDefinition, error) {
 			values = append(values, iEnumValueDef.(*ast.EnumValueDefinition))
 		}
 	}
-	return ast.NewEnumDefinition(&ast.EnumDefinition{
-		Name:   name,
-		Loc:    loc(parser, start),
-		Values: values,
-	}), nil
+	return values, nil
 }
 
 /**
- * EnumValueDefinition : EnumValue
+ * EnumValueDefinition : Description? EnumValue Directives?
  *
  * EnumValue : Name
  */
 func parseEnumValueDefinition(parser *Parser) (interface{}, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
 	name, err := parseName(parser)
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
 	return ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
-		Name: name,
-		Loc:  loc(parser, start),
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
 	}), nil
 }
 
 /**
- * InputObjectTypeDefinition : input Name { InputValueDefinition+ }
+ * InputObjectTypeDefinition : Description? input Name Directives? { InputValueDefinition+ }
  */
 func parseInputObjectTypeDefinition(parser *Parser) (*ast.InputObjectDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "input")
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "input")
 	if err != nil {
 		return nil, err
 	}
@@ -1235,6 +1581,28 @@ func parseInputObjectTypeDefinition(parser *Parser) (*ast.InputObjectDefinition,
 	if err != nil {
 		return nil, err
 	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseInputFieldDefinitions(parser)
+	if err != nil {
+		return nil, err
+	}
+	return ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
+		Description: description,
+		Name:        name,
+		Directives:  directives,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
+		Fields:      fields,
+	}), nil
+}
+
+/**
+ * InputFieldsDefinition : { InputValueDefinition* }
+ */
+func parseInputFieldDefinitions(parser *Parser) ([]*ast.InputValueDefinition, error) {
 	iInputValueDefinitions, err := any(parser, lexer.TokenKind[lexer.BRACE_L], parseInputValueDef, lexer.TokenKind[lexer.BRACE_R])
 	if err != nil {
 		return nil, err
@@ -1245,40 +1613,343 @@ func parseInputObjectTypeDefinition(parser *Parser) (*ast.InputObjectDefinition,
 			fields = append(fields, iInputValueDefinition.(*ast.InputValueDefinition))
 		}
 	}
-	return ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
-		Name:   name,
-		Loc:    loc(parser, start),
-		Fields: fields,
-	}), nil
+	return fields, nil
 }
 
 /**
- * TypeExtensionDefinition : extend ObjectTypeDefinition
+ * TypeExtensionDefinition :
+ *   - extend type Name ImplementsInterfaces? Directives? { FieldDefinition* }
+ *   - extend type Name ImplementsInterfaces? Directives
+ *   - extend type Name ImplementsInterfaces
  */
 func parseTypeExtensionDefinition(parser *Parser) (*ast.TypeExtensionDefinition, error) {
 	start := parser.Token.Start
+	comments := leadingComments(parser)
 	_, err := expectKeyWord(parser, "extend")
 	if err != nil {
 		return nil, err
 	}
-
-	definition, err := parseObjectTypeDefinition(parser)
+	defStart := parser.Token.Start
+	_, err = expectKeyWord(parser, "type")
 	if err != nil {
 		return nil, err
 	}
-	return ast.NewTypeExtensionDefinition(&ast.TypeExtensionDefinition{
-		Loc:        loc(parser, start),
-		Definition: definition,
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := parseImplementsInterfaces(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	fields := []*ast.FieldDefinition{}
+	if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+		fields, err = parseFieldDefinitions(parser)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(interfaces) == 0 && len(directives) == 0 {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return ast.NewTypeExtensionDefinition(&ast.TypeExtensionDefinition{
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
+		Definition: ast.NewObjectDefinition(&ast.ObjectDefinition{
+			Name:       name,
+			Loc:        loc(parser, defStart),
+			Interfaces: interfaces,
+			Directives: directives,
+			Fields:     fields,
+		}),
+	}), nil
+}
+
+/**
+ * SchemaExtensionDefinition :
+ *   - extend schema Directives? { OperationTypeDefinition+ }
+ *   - extend schema Directives
+ */
+func parseSchemaExtensionDefinition(parser *Parser) (*ast.SchemaExtensionDefinition, error) {
+	start := parser.Token.Start
+	comments := leadingComments(parser)
+	_, err := expectKeyWord(parser, "extend")
+	if err != nil {
+		return nil, err
+	}
+	defStart := parser.Token.Start
+	_, err = expectKeyWord(parser, "schema")
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	operationTypes := []*ast.OperationTypeDefinition{}
+	if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+		operationTypes, err = parseOperationTypeDefinitions(parser)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(directives) == 0 {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return ast.NewSchemaExtensionDefinition(&ast.SchemaExtensionDefinition{
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
+		Definition: ast.NewSchemaDefinition(&ast.SchemaDefinition{
+			Loc:            loc(parser, defStart),
+			Directives:     directives,
+			OperationTypes: operationTypes,
+		}),
+	}), nil
+}
+
+/**
+ * ScalarExtensionDefinition : extend scalar Name Directives
+ */
+func parseScalarExtensionDefinition(parser *Parser) (*ast.ScalarExtensionDefinition, error) {
+	start := parser.Token.Start
+	comments := leadingComments(parser)
+	_, err := expectKeyWord(parser, "extend")
+	if err != nil {
+		return nil, err
+	}
+	defStart := parser.Token.Start
+	_, err = expectKeyWord(parser, "scalar")
+	if err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	if len(directives) == 0 {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return ast.NewScalarExtensionDefinition(&ast.ScalarExtensionDefinition{
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
+		Definition: ast.NewScalarDefinition(&ast.ScalarDefinition{
+			Name:       name,
+			Loc:        loc(parser, defStart),
+			Directives: directives,
+		}),
+	}), nil
+}
+
+/**
+ * InterfaceExtensionDefinition :
+ *   - extend interface Name ImplementsInterfaces? Directives? { FieldDefinition* }
+ *   - extend interface Name ImplementsInterfaces? Directives
+ *   - extend interface Name ImplementsInterfaces
+ */
+func parseInterfaceExtensionDefinition(parser *Parser) (*ast.InterfaceExtensionDefinition, error) {
+	start := parser.Token.Start
+	comments := leadingComments(parser)
+	_, err := expectKeyWord(parser, "extend")
+	if err != nil {
+		return nil, err
+	}
+	defStart := parser.Token.Start
+	_, err = expectKeyWord(parser, "interface")
+	if err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := parseImplementsInterfaces(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	fields := []*ast.FieldDefinition{}
+	if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+		fields, err = parseFieldDefinitions(parser)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(interfaces) == 0 && len(directives) == 0 {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return ast.NewInterfaceExtensionDefinition(&ast.InterfaceExtensionDefinition{
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
+		Definition: ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+			Name:       name,
+			Loc:        loc(parser, defStart),
+			Interfaces: interfaces,
+			Directives: directives,
+			Fields:     fields,
+		}),
+	}), nil
+}
+
+/**
+ * UnionExtensionDefinition :
+ *   - extend union Name Directives? = UnionMembers
+ *   - extend union Name Directives
+ */
+func parseUnionExtensionDefinition(parser *Parser) (*ast.UnionExtensionDefinition, error) {
+	start := parser.Token.Start
+	comments := leadingComments(parser)
+	_, err := expectKeyWord(parser, "extend")
+	if err != nil {
+		return nil, err
+	}
+	defStart := parser.Token.Start
+	_, err = expectKeyWord(parser, "union")
+	if err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	types := []*ast.Named{}
+	if skp, err := skip(parser, lexer.TokenKind[lexer.EQUALS]); err != nil {
+		return nil, err
+	} else if skp {
+		types, err = parseUnionMembers(parser)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(directives) == 0 {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return ast.NewUnionExtensionDefinition(&ast.UnionExtensionDefinition{
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
+		Definition: ast.NewUnionDefinition(&ast.UnionDefinition{
+			Name:       name,
+			Loc:        loc(parser, defStart),
+			Directives: directives,
+			Types:      types,
+		}),
+	}), nil
+}
+
+/**
+ * EnumExtensionDefinition :
+ *   - extend enum Name Directives? { EnumValueDefinition* }
+ *   - extend enum Name Directives
+ */
+func parseEnumExtensionDefinition(parser *Parser) (*ast.EnumExtensionDefinition, error) {
+	start := parser.Token.Start
+	comments := leadingComments(parser)
+	_, err := expectKeyWord(parser, "extend")
+	if err != nil {
+		return nil, err
+	}
+	defStart := parser.Token.Start
+	_, err = expectKeyWord(parser, "enum")
+	if err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	values := []*ast.EnumValueDefinition{}
+	if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+		values, err = parseEnumValueDefinitions(parser)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(directives) == 0 {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return ast.NewEnumExtensionDefinition(&ast.EnumExtensionDefinition{
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
+		Definition: ast.NewEnumDefinition(&ast.EnumDefinition{
+			Name:       name,
+			Loc:        loc(parser, defStart),
+			Directives: directives,
+			Values:     values,
+		}),
+	}), nil
+}
+
+/**
+ * InputObjectExtensionDefinition :
+ *   - extend input Name Directives? { InputValueDefinition* }
+ *   - extend input Name Directives
+ */
+func parseInputObjectExtensionDefinition(parser *Parser) (*ast.InputObjectExtensionDefinition, error) {
+	start := parser.Token.Start
+	comments := leadingComments(parser)
+	_, err := expectKeyWord(parser, "extend")
+	if err != nil {
+		return nil, err
+	}
+	defStart := parser.Token.Start
+	_, err = expectKeyWord(parser, "input")
+	if err != nil {
+		return nil, err
+	}
+	name, err := parseName(parser)
+	if err != nil {
+		return nil, err
+	}
+	directives, err := parseDirectives(parser)
+	if err != nil {
+		return nil, err
+	}
+	fields := []*ast.InputValueDefinition{}
+	if peek(parser, lexer.TokenKind[lexer.BRACE_L]) {
+		fields, err = parseInputFieldDefinitions(parser)
+		if err != nil {
+			return nil, err
+		}
+	} else if len(directives) == 0 {
+		return nil, unexpected(parser, lexer.Token{})
+	}
+	return ast.NewInputObjectExtensionDefinition(&ast.InputObjectExtensionDefinition{
+		Loc:      loc(parser, start),
+		Comments: trailingComments(parser, comments),
+		Definition: ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
+			Name:       name,
+			Loc:        loc(parser, defStart),
+			Directives: directives,
+			Fields:     fields,
+		}),
 	}), nil
 }
 
 /**
  * DirectiveDefinition :
- *   - directive @ Name ArgumentsDefinition? on DirectiveLocations
+ *   - Description? directive @ Name ArgumentsDefinition? on DirectiveLocations
  */
 func parseDirectiveDefinition(parser *Parser) (*ast.DirectiveDefinition, error) {
 	start := parser.Token.Start
-	_, err := expectKeyWord(parser, "directive")
+	comments := leadingComments(parser)
+	description, err := parseDescription(parser)
+	if err != nil {
+		return nil, err
+	}
+	_, err = expectKeyWord(parser, "directive")
 	if err != nil {
 		return nil, err
 	}
@@ -1304,10 +1975,12 @@ func parseDirectiveDefinition(parser *Parser) (*ast.DirectiveDefinition, error)
 	}
 
 	return ast.NewDirectiveDefinition(&ast.DirectiveDefinition{
-		Loc:       loc(parser, start),
-		Name:      name,
-		Arguments: args,
-		Locations: locations,
+		Description: description,
+		Loc:         loc(parser, start),
+		Comments:    trailingComments(parser, comments),
+		Name:        name,
+		Arguments:   args,
+		Locations:   locations,
 	}), nil
 }
 
@@ -1359,21 +2032,170 @@ func loc(parser *Parser, start int) *ast.Location {
 
 // Moves the internal parser object to the next lexed token.
 func advance(parser *Parser) error {
-	prevEnd := parser.Token.End
-	parser.PrevEnd = prevEnd
-	token, err := parser.LexToken(prevEnd)
+	parser.PrevEnd = parser.Token.End
+	token, err := lexToken(parser, parser.Lexer.Next)
 	if err != nil {
 		return err
 	}
 	parser.Token = token
+	if err := countToken(parser); err != nil {
+		return err
+	}
+	readComments(parser)
+	return nil
+}
+
+// Lexes a token with the given method of the lexer. When tolerant, characters
+// which cannot be lexed are recorded as errors and skipped.
+func lexToken(parser *Parser, read func() (lexer.Token, error)) (lexer.Token, error) {
+	for {
+		token, err := read()
+		if err == nil || !parser.tolerant {
+			return token, err
+		}
+		syntaxError, ok := err.(*gqlerrors.Error)
+		if !ok || len(syntaxError.Positions) == 0 {
+			return token, err
+		}
+		parser.errors = append(parser.errors, err)
+		parser.Lexer.Seek(syntaxError.Positions[0] + 1)
+	}
+}
+
+// Records a syntax error when tolerant, returning whether parsing may go on.
+func recoverFrom(parser *Parser, err error) bool {
+	if !parser.tolerant || parser.limitExceeded {
+		return false
+	}
+	parser.errors = append(parser.errors, err)
+	return true
+}
+
+// Returns a placeholder for a missing token of the given kind, located at the
+// end of the previous token.
+func missingToken(parser *Parser, kind int, value string) lexer.Token {
+	return lexer.Token{
+		Kind:  kind,
+		Start: parser.PrevEnd,
+		End:   parser.PrevEnd,
+		Value: value,
+	}
+}
+
+// Determines if the next token closes a block, or ends the document
+func peekClosing(parser *Parser) bool {
+	return peek(parser, lexer.TokenKind[lexer.BRACE_R]) ||
+		peek(parser, lexer.TokenKind[lexer.PAREN_R]) ||
+		peek(parser, lexer.TokenKind[lexer.BRACKET_R]) ||
+		peek(parser, lexer.TokenKind[lexer.EOF])
+}
+
+// Counts the current token, failing when the document has more tokens than
+// allowed.
+func countToken(parser *Parser) error {
+	if parser.Token.Kind == lexer.TokenKind[lexer.EOF] {
+		return nil
+	}
+	parser.tokenCount++
+	if parser.Options.MaxTokens > 0 && parser.tokenCount > parser.Options.MaxTokens {
+		parser.limitExceeded = true
+		description := fmt.Sprintf("Document contains more than %v tokens. Parsing aborted.", parser.Options.MaxTokens)
+		return gqlerrors.NewSyntaxError(parser.Source, parser.Token.Start, description)
+	}
+	return nil
+}
+
+// Enters a nested selection set, list, object or list type, failing when the
+// document is nested deeper than allowed.
+func enterNesting(parser *Parser) error {
+	parser.depth++
+	if parser.Options.MaxDepth > 0 && parser.depth > parser.Options.MaxDepth {
+		parser.limitExceeded = true
+		description := fmt.Sprintf("Document exceeds the maximum nesting depth of %v.", parser.Options.MaxDepth)
+		return gqlerrors.NewSyntaxError(parser.Source, parser.Token.Start, description)
+	}
 	return nil
 }
 
+// Leaves a nesting entered with enterNesting.
+func leaveNesting(parser *Parser) {
+	parser.depth--
+}
+
+// Reads the comments preceding the current token, when comments are kept.
+func readComments(parser *Parser) {
+	if !parser.Options.KeepComments {
+		return
+	}
+	parser.inlineComment = nil
+	tokens, inline := parser.Lexer.Comments()
+	for i, token := range tokens {
+		comment := ast.NewComment(&ast.Comment{
+			Value: token.Value,
+		})
+		if !parser.Options.NoLocation {
+			comment.Loc = ast.NewLocation(&ast.Location{
+				Start: token.Start,
+				End:   token.End,
+			})
+			if !parser.Options.NoSource {
+				comment.Loc.Source = parser.Source
+			}
+		}
+		if i == 0 && inline {
+			parser.inlineComment = comment
+		}
+		parser.comments = append(parser.comments, comment)
+		parser.pendingComments = append(parser.pendingComments, comment)
+	}
+}
+
+// Takes the comments preceding a node about to be parsed.
+func leadingComments(parser *Parser) []*ast.Comment {
+	comments := parser.pendingComments
+	parser.pendingComments = nil
+	return comments
+}
+
+// Groups the leading comments of the node just parsed with the comment on
+// the line it ends, and with the comments closing its block or document.
+func trailingComments(parser *Parser, leading []*ast.Comment) *ast.CommentGroup {
+	group := &ast.CommentGroup{Leading: leading}
+	pending := []*ast.Comment{}
+	for _, comment := range parser.pendingComments {
+		if comment == parser.inlineComment {
+			group.Trailing = comment
+			continue
+		}
+		pending = append(pending, comment)
+	}
+	parser.inlineComment = nil
+	if peekClosing(parser) {
+		group.Closing = pending
+		pending = nil
+	}
+	parser.pendingComments = pending
+	if len(group.Leading) == 0 && group.Trailing == nil && len(group.Closing) == 0 {
+		return nil
+	}
+	return group
+}
+
 // Determines if the next token is of a given kind
 func peek(parser *Parser, Kind int) bool {
 	return parser.Token.Kind == Kind
 }
 
+// Determines if the next token is a description, i.e. a string or block string
+func peekDescription(parser *Parser) bool {
+	return peek(parser, lexer.TokenKind[lexer.STRING]) || peek(parser, lexer.TokenKind[lexer.BLOCK_STRING])
+}
+
+// Returns the token following the current one, without advancing the parser
+func lookahead(parser *Parser) (lexer.Token, error) {
+	return lexToken(parser, parser.Lexer.Peek)
+}
+
 // If the next token is of the given kind, return true after advancing
 // the parser. Otherwise, do not change the parser state and return false.
 func skip(parser *Parser, Kind int) (bool, error) {
@@ -1385,7 +2207,8 @@ func skip(parser *Parser, Kind int) (bool, error) {
 }
 
 // If the next token is of the given kind, return that token after advancing
-// the parser. Otherwise, do not change the parser state and return error.
+// the parser. Otherwise, do not change the parser state and return error, or
+// when tolerant, record the error and return a placeholder token.
 func expect(parser *Parser, kind int) (lexer.Token, error) {
 	token := parser.Token
 	if token.Kind == kind {
@@ -1393,11 +2216,16 @@ func expect(parser *Parser, kind int) (lexer.Token, error) {
 		return token, err
 	}
 	descp := fmt.Sprintf("Expected %s, found %s", lexer.GetTokenKindDesc(kind), lexer.GetTokenDesc(token))
-	return token, gqlerrors.NewSyntaxError(parser.Source, token.Start, descp)
+	err := gqlerrors.NewSyntaxError(parser.Source, token.Start, descp)
+	if recoverFrom(parser, err) {
+		return missingToken(parser, kind, ""), nil
+	}
+	return token, err
 }
 
 // If the next token is a keyword with the given value, return that token after
-// advancing the parser. Otherwise, do not change the parser state and return false.
+// advancing the parser. Otherwise, do not change the parser state and return
+// error, or when tolerant, record the error and return a placeholder token.
 func expectKeyWord(parser *Parser, value string) (lexer.Token, error) {
 	token := parser.Token
 	if token.Kind == lexer.TokenKind[lexer.NAME] && token.Value == value {
@@ -1405,7 +2233,11 @@ func expectKeyWord(parser *Parser, value string) (lexer.Token, error) {
 		return token, err
 	}
 	descp := fmt.Sprintf("Expected \"%s\", found %s", value, lexer.GetTokenDesc(token))
-	return token, gqlerrors.NewSyntaxError(parser.Source, token.Start, descp)
+	err := gqlerrors.NewSyntaxError(parser.Source, token.Start, descp)
+	if recoverFrom(parser, err) {
+		return missingToken(parser, lexer.TokenKind[lexer.NAME], value), nil
+	}
+	return token, err
 }
 
 // Helper function for creating an error when an unexpected lexed token
@@ -1427,8 +2259,9 @@ func unexpected(parser *Parser, atToken lexer.Token) error {
 // to the next lex token after the closing token.
 func any(parser *Parser, openKind int, parseFn parseFn, closeKind int) ([]interface{}, error) {
 	var nodes []interface{}
+	opened := peek(parser, openKind)
 	_, err := expect(parser, openKind)
-	if err != nil {
+	if err != nil || !opened {
 		return nodes, nil
 	}
 	for {
@@ -1437,11 +2270,14 @@ func any(parser *Parser, openKind int, parseFn parseFn, closeKind int) ([]interf
 		} else if skp {
 			break
 		}
-		n, err := parseFn(parser)
+		if parser.tolerant && peekClosing(parser) {
+			_, err := expect(parser, closeKind)
+			return nodes, err
+		}
+		nodes, err = parseItem(parser, parseFn, nodes)
 		if err != nil {
 			return nodes, err
 		}
-		nodes = append(nodes, n)
 	}
 	return nodes, nil
 }
@@ -1451,27 +2287,54 @@ func any(parser *Parser, openKind int, parseFn parseFn, closeKind int) ([]interf
 // and ends with a lex token of closeKind. Advances the parser
 // to the next lex token after the closing token.
 func many(parser *Parser, openKind int, parseFn parseFn, closeKind int) ([]interface{}, error) {
+	opened := peek(parser, openKind)
 	_, err := expect(parser, openKind)
 	if err != nil {
 		return nil, err
 	}
 	var nodes []interface{}
-	node, err := parseFn(parser)
+	if !opened {
+		return nodes, nil
+	}
+	nodes, err = parseItem(parser, parseFn, nodes)
 	if err != nil {
 		return nodes, err
 	}
-	nodes = append(nodes, node)
 	for {
 		if skp, err := skip(parser, closeKind); err != nil {
 			return nil, err
 		} else if skp {
 			break
 		}
-		node, err := parseFn(parser)
+		if parser.tolerant && peekClosing(parser) {
+			_, err := expect(parser, closeKind)
+			return nodes, err
+		}
+		nodes, err = parseItem(parser, parseFn, nodes)
 		if err != nil {
 			return nodes, err
 		}
-		nodes = append(nodes, node)
 	}
 	return nodes, nil
 }
+
+// Parses an item of a list with the parseFn. When tolerant, an item which
+// could not be parsed is left out, and the token it stopped at is skipped if
+// nothing was parsed, unless it closes a block.
+func parseItem(parser *Parser, parseFn parseFn, nodes []interface{}) ([]interface{}, error) {
+	start := parser.Token.Start
+	node, err := parseFn(parser)
+	if err != nil && !recoverFrom(parser, err) {
+		return nodes, err
+	}
+	if parser.tolerant && parser.Token.Start == start {
+		if peekClosing(parser) {
+			return nodes, nil
+		}
+		return nodes, advance(parser)
+	}
+	if err != nil {
+		return nodes, nil
+	}
+	return append(nodes, node), nil
+}
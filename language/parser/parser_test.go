@@ -180,13 +180,19 @@ func TestDoesNotAcceptFragmentsSpreadOfOn(t *testing.T) {
 	testErrorMessage(t, test)
 }
 
-func TestDoesNotAllowNullAsValue(t *testing.T) {
-	test := errorMessageTest{
-		`{ fieldWithNullableStringInput(input: null) }'`,
-		`Syntax Error GraphQL (1:39) Unexpected Name "null"`,
-		false,
+func TestParsesNullAsValue(t *testing.T) {
+	document, err := Parse(ParseParams{Source: `{ fieldWithNullableStringInput(input: null) }`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	field := document.Definitions[0].(*ast.OperationDefinition).SelectionSet.Selections[0].(*ast.Field)
+	value, ok := field.Arguments[0].Value.(*ast.NullValue)
+	if !ok {
+		t.Fatalf("expected a null value, got: %v", field.Arguments[0].Value)
+	}
+	if value.Kind != "NullValue" || value.Loc.Start != 38 || value.Loc.End != 42 {
+		t.Fatalf("unexpected null value: %v", value)
 	}
-	testErrorMessage(t, test)
 }
 
 func TestParsesMultiByteCharacters(t *testing.T) {
@@ -559,3 +565,258 @@ func toError(err error) *gqlerrors.Error {
 		return nil
 	}
 }
+
+func TestParseKeepsComments(t *testing.T) {
+	source := `# leading
+{
+  a # trailing
+  # closing
+}
+`
+	document, err := Parse(ParseParams{
+		Source: source,
+		Options: ParseOptions{
+			KeepComments: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(document.Comments) != 3 {
+		t.Fatalf("expected 3 comments, got: %v", len(document.Comments))
+	}
+	leading := document.Comments[0]
+	if leading.Value != " leading" || leading.Loc.Start != 0 || leading.Loc.End != 9 {
+		t.Fatalf("unexpected leading comment: %v", leading)
+	}
+	operation := document.Definitions[0].(*ast.OperationDefinition)
+	if operation.Comments == nil || !reflect.DeepEqual(operation.Comments.Leading, []*ast.Comment{leading}) {
+		t.Fatalf("expected leading comment on operation, got: %v", operation.Comments)
+	}
+	field := operation.SelectionSet.Selections[0].(*ast.Field)
+	expected := &ast.CommentGroup{
+		Trailing: document.Comments[1],
+		Closing:  []*ast.Comment{document.Comments[2]},
+	}
+	if !reflect.DeepEqual(field.Comments, expected) {
+		t.Fatalf("unexpected field comments, expected: %v, got: %v", expected, field.Comments)
+	}
+}
+
+func TestParseDiscardsCommentsByDefault(t *testing.T) {
+	document, err := Parse(ParseParams{Source: "# comment\n{ a }"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	operation := document.Definitions[0].(*ast.OperationDefinition)
+	if document.Comments != nil || operation.Comments != nil {
+		t.Fatalf("expected no comments, got: %v, %v", document.Comments, operation.Comments)
+	}
+}
+
+func TestParsesDirectivesOnVariableDefinitions(t *testing.T) {
+	document, err := Parse(ParseParams{
+		Source: `query Q($a: Int = 1 @onVariable) { f }`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	operation := document.Definitions[0].(*ast.OperationDefinition)
+	directives := operation.VariableDefinitions[0].Directives
+	if len(directives) != 1 || directives[0].Name.Value != "onVariable" {
+		t.Fatalf("expected directive onVariable, got: %v", directives)
+	}
+	expected := &ast.Location{Start: 20, End: 31, Source: directives[0].Loc.Source}
+	if !reflect.DeepEqual(directives[0].Loc, expected) {
+		t.Fatalf("unexpected directive location, expected: %v, got: %v", expected, directives[0].Loc)
+	}
+}
+
+func TestParsesDirectivesOnSchemaDefinition(t *testing.T) {
+	document, err := Parse(ParseParams{
+		Source: `schema @onSchema { query: Query }`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	schema := document.Definitions[0].(*ast.SchemaDefinition)
+	if len(schema.Directives) != 1 || schema.Directives[0].Name.Value != "onSchema" {
+		t.Fatalf("expected directive onSchema, got: %v", schema.Directives)
+	}
+	if len(schema.OperationTypes) != 1 {
+		t.Fatalf("expected one operation type, got: %v", schema.OperationTypes)
+	}
+}
+
+func TestParseLimitsBodySize(t *testing.T) {
+	_, err := Parse(ParseParams{
+		Source:  `{ field }`,
+		Options: ParseOptions{MaxBodySize: 9},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = Parse(ParseParams{
+		Source:  `{ field, other }`,
+		Options: ParseOptions{MaxBodySize: 9},
+	})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:10) Document exceeds the maximum size of 9 bytes.`)
+}
+
+func TestParseLimitsTokenCount(t *testing.T) {
+	_, err := Parse(ParseParams{
+		Source:  `{ a b }`,
+		Options: ParseOptions{MaxTokens: 4},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = Parse(ParseParams{
+		Source:  `{ a b c }`,
+		Options: ParseOptions{MaxTokens: 4},
+	})
+	checkErrorMessage(t, err, `Syntax Error GraphQL (1:9) Document contains more than 4 tokens. Parsing aborted.`)
+}
+
+func TestParseLimitsNestingDepth(t *testing.T) {
+	_, err := Parse(ParseParams{
+		Source:  `query ($a: [[Int]]) { a { b(arg: [{c: 1}]) } }`,
+		Options: ParseOptions{MaxDepth: 4},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []errorMessageTest{
+		{
+			`{ a { b { c } } }`,
+			`Syntax Error GraphQL (1:9) Document exceeds the maximum nesting depth of 2.`,
+			false,
+		},
+		{
+			`{ a(arg: [[[1]]]) }`,
+			`Syntax Error GraphQL (1:11) Document exceeds the maximum nesting depth of 2.`,
+			false,
+		},
+		{
+			`{ a(arg: {b: {c: 1}}) }`,
+			`Syntax Error GraphQL (1:14) Document exceeds the maximum nesting depth of 2.`,
+			false,
+		},
+		{
+			`query ($a: [[[Int]]]) { a }`,
+			`Syntax Error GraphQL (1:14) Document exceeds the maximum nesting depth of 2.`,
+			false,
+		},
+	}
+	for _, test := range tests {
+		_, err := Parse(ParseParams{
+			Source:  test.source,
+			Options: ParseOptions{MaxDepth: 2},
+		})
+		checkErrorMessage(t, err, test.expectedMessage)
+	}
+}
+
+func TestParseTolerantRecoversFromUnclosedBraces(t *testing.T) {
+	document, errs := ParseTolerant(ParseParams{Source: `{ dog { name`})
+	expectedMessages := []string{
+		`Syntax Error GraphQL (1:13) Expected }, found EOF`,
+		`Syntax Error GraphQL (1:13) Expected }, found EOF`,
+	}
+	if len(errs) != len(expectedMessages) {
+		t.Fatalf("expected %v errors, got: %v", len(expectedMessages), errs)
+	}
+	for i, err := range errs {
+		checkErrorMessage(t, err, expectedMessages[i])
+	}
+	operation := document.Definitions[0].(*ast.OperationDefinition)
+	dog := operation.SelectionSet.Selections[0].(*ast.Field)
+	name := dog.SelectionSet.Selections[0].(*ast.Field)
+	if dog.Name.Value != "dog" || name.Name.Value != "name" {
+		t.Fatalf("unexpected fields: %v, %v", dog.Name, name.Name)
+	}
+}
+
+func TestParseTolerantInsertsMissingNames(t *testing.T) {
+	document, errs := ParseTolerant(ParseParams{Source: `{ dog { ... on { name } } }`})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got: %v", errs)
+	}
+	checkErrorMessage(t, errs[0], `Syntax Error GraphQL (1:16) Expected Name, found {`)
+	operation := document.Definitions[0].(*ast.OperationDefinition)
+	dog := operation.SelectionSet.Selections[0].(*ast.Field)
+	fragment := dog.SelectionSet.Selections[0].(*ast.InlineFragment)
+	expected := ast.NewName(&ast.Name{
+		Value: "",
+		Loc: ast.NewLocation(&ast.Location{
+			Start:  14,
+			End:    14,
+			Source: fragment.TypeCondition.Name.Loc.Source,
+		}),
+	})
+	if !reflect.DeepEqual(fragment.TypeCondition.Name, expected) {
+		t.Fatalf("unexpected placeholder, expected: %v, got: %v", expected, fragment.TypeCondition.Name)
+	}
+	if len(fragment.SelectionSet.Selections) != 1 {
+		t.Fatalf("expected the selections of the fragment, got: %v", fragment.SelectionSet.Selections)
+	}
+}
+
+func TestParseTolerantSkipsUnexpectedTokens(t *testing.T) {
+	document, errs := ParseTolerant(ParseParams{Source: `{ a ? ! b(c: ) } garbage fragment F on T { d }`})
+	expectedMessages := []string{
+		`Syntax Error GraphQL (1:5) Unexpected character "?".`,
+		`Syntax Error GraphQL (1:7) Expected Name, found !`,
+		`Syntax Error GraphQL (1:14) Unexpected )`,
+		`Syntax Error GraphQL (1:18) Unexpected Name "garbage"`,
+	}
+	if len(errs) != len(expectedMessages) {
+		t.Fatalf("expected %v errors, got: %v", len(expectedMessages), errs)
+	}
+	for i, err := range errs {
+		checkErrorMessage(t, err, expectedMessages[i])
+	}
+	if len(document.Definitions) != 2 {
+		t.Fatalf("expected 2 definitions, got: %v", document.Definitions)
+	}
+	operation := document.Definitions[0].(*ast.OperationDefinition)
+	names := []string{}
+	for _, selection := range operation.SelectionSet.Selections {
+		names = append(names, selection.(*ast.Field).Name.Value)
+	}
+	if !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Fatalf("unexpected fields: %v", names)
+	}
+	if _, ok := document.Definitions[1].(*ast.FragmentDefinition); !ok {
+		t.Fatalf("expected a fragment definition, got: %v", document.Definitions[1])
+	}
+}
+
+func TestParseTolerantStopsAtLimits(t *testing.T) {
+	document, errs := ParseTolerant(ParseParams{
+		Source:  `{ a { b { c } } }`,
+		Options: ParseOptions{MaxDepth: 2},
+	})
+	if document != nil {
+		t.Fatalf("expected no document, got: %v", document)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got: %v", errs)
+	}
+	checkErrorMessage(t, errs[0], `Syntax Error GraphQL (1:9) Document exceeds the maximum nesting depth of 2.`)
+}
+
+func BenchmarkParseKitchenSink(b *testing.B) {
+	body, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		b.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	source := string(body)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(ParseParams{Source: source}); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
@@ -40,7 +40,8 @@ type Hello {
 		Loc: testLoc(1, 31),
 		Definitions: []ast.Node{
 			ast.NewObjectDefinition(&ast.ObjectDefinition{
-				Loc: testLoc(1, 31),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(1, 31),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(6, 11),
@@ -48,7 +49,8 @@ type Hello {
 				Interfaces: []*ast.Named{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: testLoc(16, 29),
+						Directives: []*ast.Directive{},
+						Loc:        testLoc(16, 29),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   testLoc(16, 21),
@@ -84,7 +86,8 @@ extend type Hello {
 			ast.NewTypeExtensionDefinition(&ast.TypeExtensionDefinition{
 				Loc: testLoc(1, 38),
 				Definition: ast.NewObjectDefinition(&ast.ObjectDefinition{
-					Loc: testLoc(8, 38),
+					Directives: []*ast.Directive{},
+					Loc:        testLoc(8, 38),
 					Name: ast.NewName(&ast.Name{
 						Value: "Hello",
 						Loc:   testLoc(13, 18),
@@ -92,7 +95,8 @@ extend type Hello {
 					Interfaces: []*ast.Named{},
 					Fields: []*ast.FieldDefinition{
 						ast.NewFieldDefinition(&ast.FieldDefinition{
-							Loc: testLoc(23, 36),
+							Directives: []*ast.Directive{},
+							Loc:        testLoc(23, 36),
 							Name: ast.NewName(&ast.Name{
 								Value: "world",
 								Loc:   testLoc(23, 28),
@@ -127,7 +131,8 @@ type Hello {
 		Loc: testLoc(1, 32),
 		Definitions: []ast.Node{
 			ast.NewObjectDefinition(&ast.ObjectDefinition{
-				Loc: testLoc(1, 32),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(1, 32),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(6, 11),
@@ -135,7 +140,8 @@ type Hello {
 				Interfaces: []*ast.Named{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: testLoc(16, 30),
+						Directives: []*ast.Directive{},
+						Loc:        testLoc(16, 30),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   testLoc(16, 21),
@@ -169,7 +175,8 @@ func TestSchemaParser_SimpleTypeInheritingInterface(t *testing.T) {
 		Loc: testLoc(0, 31),
 		Definitions: []ast.Node{
 			ast.NewObjectDefinition(&ast.ObjectDefinition{
-				Loc: testLoc(0, 31),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(0, 31),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(5, 10),
@@ -199,7 +206,8 @@ func TestSchemaParser_SimpleTypeInheritingMultipleInterfaces(t *testing.T) {
 		Loc: testLoc(0, 33),
 		Definitions: []ast.Node{
 			ast.NewObjectDefinition(&ast.ObjectDefinition{
-				Loc: testLoc(0, 33),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(0, 33),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(5, 10),
@@ -236,13 +244,15 @@ func TestSchemaParser_SingleValueEnum(t *testing.T) {
 		Loc: testLoc(0, 20),
 		Definitions: []ast.Node{
 			ast.NewEnumDefinition(&ast.EnumDefinition{
-				Loc: testLoc(0, 20),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(0, 20),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(5, 10),
 				}),
 				Values: []*ast.EnumValueDefinition{
 					ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+						Directives: []*ast.Directive{},
 						Name: ast.NewName(&ast.Name{
 							Value: "WORLD",
 							Loc:   testLoc(13, 18),
@@ -265,13 +275,15 @@ func TestSchemaParser_DoubleValueEnum(t *testing.T) {
 		Loc: testLoc(0, 22),
 		Definitions: []ast.Node{
 			ast.NewEnumDefinition(&ast.EnumDefinition{
-				Loc: testLoc(0, 22),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(0, 22),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(5, 10),
 				}),
 				Values: []*ast.EnumValueDefinition{
 					ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+						Directives: []*ast.Directive{},
 						Name: ast.NewName(&ast.Name{
 							Value: "WO",
 							Loc:   testLoc(13, 15),
@@ -279,6 +291,7 @@ func TestSchemaParser_DoubleValueEnum(t *testing.T) {
 						Loc: testLoc(13, 15),
 					}),
 					ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+						Directives: []*ast.Directive{},
 						Name: ast.NewName(&ast.Name{
 							Value: "RLD",
 							Loc:   testLoc(17, 20),
@@ -304,14 +317,17 @@ interface Hello {
 		Loc: testLoc(1, 36),
 		Definitions: []ast.Node{
 			ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
-				Loc: testLoc(1, 36),
+				Directives: []*ast.Directive{},
+				Interfaces: []*ast.Named{},
+				Loc:        testLoc(1, 36),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(11, 16),
 				}),
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: testLoc(21, 34),
+						Directives: []*ast.Directive{},
+						Loc:        testLoc(21, 34),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   testLoc(21, 26),
@@ -344,7 +360,8 @@ type Hello {
 		Loc: testLoc(1, 46),
 		Definitions: []ast.Node{
 			ast.NewObjectDefinition(&ast.ObjectDefinition{
-				Loc: testLoc(1, 46),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(1, 46),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(6, 11),
@@ -352,14 +369,16 @@ type Hello {
 				Interfaces: []*ast.Named{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: testLoc(16, 44),
+						Directives: []*ast.Directive{},
+						Loc:        testLoc(16, 44),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   testLoc(16, 21),
 						}),
 						Arguments: []*ast.InputValueDefinition{
 							ast.NewInputValueDefinition(&ast.InputValueDefinition{
-								Loc: testLoc(22, 35),
+								Directives: []*ast.Directive{},
+								Loc:        testLoc(22, 35),
 								Name: ast.NewName(&ast.Name{
 									Value: "flag",
 									Loc:   testLoc(22, 26),
@@ -401,7 +420,8 @@ type Hello {
 		Loc: testLoc(1, 53),
 		Definitions: []ast.Node{
 			ast.NewObjectDefinition(&ast.ObjectDefinition{
-				Loc: testLoc(1, 53),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(1, 53),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(6, 11),
@@ -409,14 +429,16 @@ type Hello {
 				Interfaces: []*ast.Named{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: testLoc(16, 51),
+						Directives: []*ast.Directive{},
+						Loc:        testLoc(16, 51),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   testLoc(16, 21),
 						}),
 						Arguments: []*ast.InputValueDefinition{
 							ast.NewInputValueDefinition(&ast.InputValueDefinition{
-								Loc: testLoc(22, 42),
+								Directives: []*ast.Directive{},
+								Loc:        testLoc(22, 42),
 								Name: ast.NewName(&ast.Name{
 									Value: "flag",
 									Loc:   testLoc(22, 26),
@@ -461,7 +483,8 @@ type Hello {
 		Loc: testLoc(1, 49),
 		Definitions: []ast.Node{
 			ast.NewObjectDefinition(&ast.ObjectDefinition{
-				Loc: testLoc(1, 49),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(1, 49),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(6, 11),
@@ -469,14 +492,16 @@ type Hello {
 				Interfaces: []*ast.Named{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: testLoc(16, 47),
+						Directives: []*ast.Directive{},
+						Loc:        testLoc(16, 47),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   testLoc(16, 21),
 						}),
 						Arguments: []*ast.InputValueDefinition{
 							ast.NewInputValueDefinition(&ast.InputValueDefinition{
-								Loc: testLoc(22, 38),
+								Directives: []*ast.Directive{},
+								Loc:        testLoc(22, 38),
 								Name: ast.NewName(&ast.Name{
 									Value: "things",
 									Loc:   testLoc(22, 28),
@@ -521,7 +546,8 @@ type Hello {
 		Loc: testLoc(1, 61),
 		Definitions: []ast.Node{
 			ast.NewObjectDefinition(&ast.ObjectDefinition{
-				Loc: testLoc(1, 61),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(1, 61),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(6, 11),
@@ -529,14 +555,16 @@ type Hello {
 				Interfaces: []*ast.Named{},
 				Fields: []*ast.FieldDefinition{
 					ast.NewFieldDefinition(&ast.FieldDefinition{
-						Loc: testLoc(16, 59),
+						Directives: []*ast.Directive{},
+						Loc:        testLoc(16, 59),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   testLoc(16, 21),
 						}),
 						Arguments: []*ast.InputValueDefinition{
 							ast.NewInputValueDefinition(&ast.InputValueDefinition{
-								Loc: testLoc(22, 37),
+								Directives: []*ast.Directive{},
+								Loc:        testLoc(22, 37),
 								Name: ast.NewName(&ast.Name{
 									Value: "argOne",
 									Loc:   testLoc(22, 28),
@@ -551,7 +579,8 @@ type Hello {
 								DefaultValue: nil,
 							}),
 							ast.NewInputValueDefinition(&ast.InputValueDefinition{
-								Loc: testLoc(39, 50),
+								Directives: []*ast.Directive{},
+								Loc:        testLoc(39, 50),
 								Name: ast.NewName(&ast.Name{
 									Value: "argTwo",
 									Loc:   testLoc(39, 45),
@@ -590,7 +619,8 @@ func TestSchemaParser_SimpleUnion(t *testing.T) {
 		Loc: testLoc(0, 19),
 		Definitions: []ast.Node{
 			ast.NewUnionDefinition(&ast.UnionDefinition{
-				Loc: testLoc(0, 19),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(0, 19),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(6, 11),
@@ -619,7 +649,8 @@ func TestSchemaParser_UnionWithTwoTypes(t *testing.T) {
 		Loc: testLoc(0, 22),
 		Definitions: []ast.Node{
 			ast.NewUnionDefinition(&ast.UnionDefinition{
-				Loc: testLoc(0, 22),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(0, 22),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(6, 11),
@@ -655,7 +686,8 @@ func TestSchemaParser_Scalar(t *testing.T) {
 		Loc: testLoc(0, 12),
 		Definitions: []ast.Node{
 			ast.NewScalarDefinition(&ast.ScalarDefinition{
-				Loc: testLoc(0, 12),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(0, 12),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(7, 12),
@@ -678,14 +710,16 @@ input Hello {
 		Loc: testLoc(1, 32),
 		Definitions: []ast.Node{
 			ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
-				Loc: testLoc(1, 32),
+				Directives: []*ast.Directive{},
+				Loc:        testLoc(1, 32),
 				Name: ast.NewName(&ast.Name{
 					Value: "Hello",
 					Loc:   testLoc(7, 12),
 				}),
 				Fields: []*ast.InputValueDefinition{
 					ast.NewInputValueDefinition(&ast.InputValueDefinition{
-						Loc: testLoc(17, 30),
+						Directives: []*ast.Directive{},
+						Loc:        testLoc(17, 30),
 						Name: ast.NewName(&ast.Name{
 							Value: "world",
 							Loc:   testLoc(17, 22),
@@ -709,10 +743,12 @@ input Hello {
 }
 
 func TestSchemaParser_SimpleInputObjectWithArgsShouldFail(t *testing.T) {
-	body := `
+	body := source.NewSource(&source.Source{
+		Body: `
 input Hello {
   world(foo: Int): String
-}`
+}`,
+	})
 
 	_, err := Parse(ParseParams{
 		Source: body,
@@ -737,14 +773,8 @@ input Hello {
           ^
 4: }
 `,
-		Nodes: []ast.Node{},
-		Source: &source.Source{
-			Body: `
-input Hello {
-  world(foo: Int): String
-}`,
-			Name: "GraphQL",
-		},
+		Nodes:     []ast.Node{},
+		Source:    body,
 		Positions: []int{22},
 		Locations: []location.SourceLocation{
 			{Line: 3, Column: 8},
@@ -757,3 +787,201 @@ input Hello {
 		t.Fatalf("unexpected document, expected: %v, got: %v", expectedError, err)
 	}
 }
+
+func TestSchemaParser_ScalarWithDirective(t *testing.T) {
+	body := `scalar Hello @foo(bar: 1)`
+	astDoc := parse(t, body)
+	expected := ast.NewDocument(&ast.Document{
+		Loc: testLoc(0, 25),
+		Definitions: []ast.Node{
+			ast.NewScalarDefinition(&ast.ScalarDefinition{
+				Loc: testLoc(0, 25),
+				Name: ast.NewName(&ast.Name{
+					Value: "Hello",
+					Loc:   testLoc(7, 12),
+				}),
+				Directives: []*ast.Directive{
+					ast.NewDirective(&ast.Directive{
+						Loc: testLoc(13, 25),
+						Name: ast.NewName(&ast.Name{
+							Value: "foo",
+							Loc:   testLoc(14, 17),
+						}),
+						Arguments: []*ast.Argument{
+							ast.NewArgument(&ast.Argument{
+								Loc: testLoc(18, 24),
+								Name: ast.NewName(&ast.Name{
+									Value: "bar",
+									Loc:   testLoc(18, 21),
+								}),
+								Value: ast.NewIntValue(&ast.IntValue{
+									Value: "1",
+									Loc:   testLoc(23, 24),
+								}),
+							}),
+						},
+					}),
+				},
+			}),
+		},
+	})
+	if !reflect.DeepEqual(astDoc, expected) {
+		t.Fatalf("unexpected document, expected: %v, got: %v", expected, astDoc)
+	}
+}
+
+func TestSchemaParser_DirectivesOnTypeSystemDefinitions(t *testing.T) {
+	body := `
+type Hello implements World @onObject {
+  world(flag: Boolean = true @onArg): String @onField
+}
+enum Site @onEnum { DESKTOP @onEnumValue }
+`
+	astDoc := parse(t, body)
+	directiveNames := func(directives []*ast.Directive) []string {
+		names := []string{}
+		for _, directive := range directives {
+			names = append(names, directive.Name.Value)
+		}
+		return names
+	}
+	object := astDoc.Definitions[0].(*ast.ObjectDefinition)
+	field := object.Fields[0]
+	enum := astDoc.Definitions[1].(*ast.EnumDefinition)
+	results := [][]string{
+		directiveNames(object.Directives),
+		directiveNames(field.Directives),
+		directiveNames(field.Arguments[0].Directives),
+		directiveNames(enum.Directives),
+		directiveNames(enum.Values[0].Directives),
+	}
+	expected := [][]string{
+		{"onObject"},
+		{"onField"},
+		{"onArg"},
+		{"onEnum"},
+		{"onEnumValue"},
+	}
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("unexpected directives, expected: %v, got: %v", expected, results)
+	}
+	if len(object.Interfaces) != 1 {
+		t.Fatalf("expected a single interface, got: %v", object.Interfaces)
+	}
+}
+
+func TestSchemaParser_DescriptionsOnTypeSystemDefinitions(t *testing.T) {
+	body := `
+"The Hello type"
+type Hello {
+  """
+  The world field
+  """
+  world(
+    "Whether to flag"
+    flag: Boolean
+  ): String
+}
+`
+	astDoc := parse(t, body)
+	object := astDoc.Definitions[0].(*ast.ObjectDefinition)
+	field := object.Fields[0]
+	arg := field.Arguments[0]
+	results := []*ast.StringValue{object.Description, field.Description, arg.Description}
+	expected := []struct {
+		value string
+		block bool
+	}{
+		{"The Hello type", false},
+		{"The world field", true},
+		{"Whether to flag", false},
+	}
+	for i, description := range results {
+		if description == nil {
+			t.Fatalf("expected description %v, got nil", expected[i].value)
+		}
+		if description.Value != expected[i].value || description.Block != expected[i].block {
+			t.Fatalf("unexpected description, expected: %v (block: %v), got: %v (block: %v)",
+				expected[i].value, expected[i].block, description.Value, description.Block)
+		}
+	}
+}
+
+func TestSchemaParser_RejectsDescriptionOnOperation(t *testing.T) {
+	_, err := Parse(ParseParams{Source: `"description" query { a }`})
+	if err == nil {
+		t.Fatalf("expected error for description before an operation")
+	}
+}
+
+func TestSchemaParser_SimpleInterfaceInheritingInterface(t *testing.T) {
+	body := `interface Hello implements World { field: String }`
+	astDoc := parse(t, body)
+	definition := astDoc.Definitions[0].(*ast.InterfaceDefinition)
+	expected := []*ast.Named{
+		ast.NewNamed(&ast.Named{
+			Name: ast.NewName(&ast.Name{
+				Value: "World",
+				Loc:   testLoc(27, 32),
+			}),
+			Loc: testLoc(27, 32),
+		}),
+	}
+	if !reflect.DeepEqual(definition.Interfaces, expected) {
+		t.Fatalf("unexpected interfaces, expected: %v, got: %v", expected, definition.Interfaces)
+	}
+}
+
+func TestSchemaParser_ExtensionKinds(t *testing.T) {
+	body := `
+extend schema @onSchema
+extend scalar Hello @onScalar
+extend type Hello implements World @onType
+extend interface Hello @onInterface { world: String }
+extend union Hello = World
+extend enum Hello { WORLD }
+extend input Hello @onInputObject
+`
+	astDoc := parse(t, body)
+	kinds := []string{}
+	for _, definition := range astDoc.Definitions {
+		kinds = append(kinds, definition.GetKind())
+	}
+	expected := []string{
+		"SchemaExtensionDefinition",
+		"ScalarExtensionDefinition",
+		"TypeExtensionDefinition",
+		"InterfaceExtensionDefinition",
+		"UnionExtensionDefinition",
+		"EnumExtensionDefinition",
+		"InputObjectExtensionDefinition",
+	}
+	if !reflect.DeepEqual(kinds, expected) {
+		t.Fatalf("unexpected kinds, expected: %v, got: %v", expected, kinds)
+	}
+	union := astDoc.Definitions[4].(*ast.UnionExtensionDefinition).Definition
+	if len(union.Types) != 1 || union.Types[0].Name.Value != "World" {
+		t.Fatalf("unexpected union members: %v", union.Types)
+	}
+	extension := astDoc.Definitions[2].(*ast.TypeExtensionDefinition)
+	if extension.Loc.Start != 55 || extension.Definition.Loc.Start != 62 || extension.Definition.Loc.End != 97 {
+		t.Fatalf("unexpected locations: %v, %v", extension.Loc, extension.Definition.Loc)
+	}
+}
+
+func TestSchemaParser_ExtensionsRequireContent(t *testing.T) {
+	tests := []struct {
+		body            string
+		expectedMessage string
+	}{
+		{"extend scalar Hello", `Syntax Error GraphQL (1:20) Unexpected EOF`},
+		{"extend type Hello", `Syntax Error GraphQL (1:18) Unexpected EOF`},
+		{"extend schema", `Syntax Error GraphQL (1:14) Unexpected EOF`},
+		{"extend union Hello", `Syntax Error GraphQL (1:19) Unexpected EOF`},
+		{"extend directive @foo", `Syntax Error GraphQL (1:8) Unexpected Name "directive"`},
+	}
+	for _, test := range tests {
+		_, err := Parse(ParseParams{Source: test.body})
+		checkErrorMessage(t, err, test.expectedMessage)
+	}
+}
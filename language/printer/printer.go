@@ -1,8 +1,11 @@
 package printer
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/graphql/language/visitor"
@@ -92,547 +95,1225 @@ func wrap(start, maybeString, end string) string {
 	}
 	return start + maybeString + end
 }
-func block(maybeArray interface{}) string {
+func (c *Config) block(maybeArray interface{}) string {
 	if maybeArray == nil {
 		return ""
 	}
 	s := toSliceString(maybeArray)
-	return indent("{\n"+join(s, "\n")) + "\n}"
+	if len(s) == 0 {
+		return ""
+	}
+	return c.indent("{\n"+join(s, "\n")) + "\n}"
 }
 
-func indent(maybeString interface{}) string {
-	if maybeString == nil {
-		return ""
+func (c *Config) indent(str string) string {
+	return strings.Replace(str, "\n", "\n"+c.indentation(), -1)
+}
+
+// indentation returns the spaces a level of nesting is indented by.
+func (c *Config) indentation() string {
+	if c.Indent <= 0 {
+		return "  "
 	}
-	switch str := maybeString.(type) {
-	case string:
-		return strings.Replace(str, "\n", "\n  ", -1)
+	return strings.Repeat(" ", c.Indent)
+}
+
+// separator returns what separates items printed on one line.
+func (c *Config) separator() string {
+	if c.Commas == SpaceSeparated {
+		return " "
 	}
-	return ""
+	return ", "
 }
 
-var printDocASTReducer = map[string]visitor.VisitFunc{
-	"Name": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Name:
-			return visitor.ActionUpdate, node.Value
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValue(node, "Value")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"Variable": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Variable:
-			return visitor.ActionUpdate, fmt.Sprintf("$%v", node.Name)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, "$" + getMapValueString(node, "Name")
-		}
-		return visitor.ActionNoChange, nil
-	},
+// sorted returns printed items, sorted when so configured.
+func (c *Config) sorted(items []string) []string {
+	if c.SortFields {
+		sort.Strings(items)
+	}
+	return items
+}
 
-	// Document
-	"Document": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Document:
-			definitions := toSliceString(node.Definitions)
-			return visitor.ActionUpdate, join(definitions, "\n\n") + "\n"
-		case map[string]interface{}:
-			definitions := toSliceString(getMapValue(node, "Definitions"))
-			return visitor.ActionUpdate, join(definitions, "\n\n") + "\n"
+// depth returns the number of blocks the node visited is nested in.
+func depth(p visitor.VisitFuncParams) int {
+	depth := 0
+	for _, key := range p.Path {
+		if key == "Selections" || key == "Fields" {
+			depth++
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"OperationDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.OperationDefinition:
-			op := string(node.Operation)
-			name := fmt.Sprintf("%v", node.Name)
-
-			varDefs := wrap("(", join(toSliceString(node.VariableDefinitions), ", "), ")")
-			directives := join(toSliceString(node.Directives), " ")
-			selectionSet := fmt.Sprintf("%v", node.SelectionSet)
-			// Anonymous queries with no directives or variable definitions can use
-			// the query short form.
-			str := ""
-			if name == "" && directives == "" && varDefs == "" && op == ast.OperationTypeQuery {
-				str = selectionSet
-			} else {
-				str = join([]string{
-					op,
-					join([]string{name, varDefs}, ""),
-					directives,
-					selectionSet,
-				}, " ")
+	}
+	return depth
+}
+
+func firstLine(str string) string {
+	if i := strings.Index(str, "\n"); i >= 0 {
+		return str[:i]
+	}
+	return str
+}
+
+// printItems prints items between open and close on one line, or one per
+// line when some of them carry a comment.
+func (c *Config) printItems(open string, items []string, close string) string {
+	if anyComment(items) {
+		return open + "\n" + c.indentation() + c.indent(join(items, "\n")) + "\n" + close
+	}
+	return open + join(items, c.separator()) + close
+}
+
+// printArguments prints arguments, or variable definitions, between
+// parentheses. They are printed one per line when some of them carry a
+// comment, or when the line they are on, where prefix precedes them and
+// suffix follows them, would exceed the maximum length.
+func (c *Config) printArguments(p visitor.VisitFuncParams, prefix string, args []string, suffix string) string {
+	if anyComment(args) {
+		return c.printItems("(", args, ")")
+	}
+	inline := wrap("(", join(args, c.separator()), ")")
+	if c.MaxLineLength <= 0 || inline == "" {
+		return inline
+	}
+	length := depth(p)*len(c.indentation()) + utf8.RuneCountInString(prefix+inline+suffix)
+	if length <= c.MaxLineLength {
+		return inline
+	}
+	return "(\n" + c.indentation() + c.indent(join(args, "\n")) + "\n)"
+}
+
+// newPrintDocASTReducer returns the reducers printing each kind of node as
+// configured.
+func newPrintDocASTReducer(c *Config) map[string]visitor.VisitFunc {
+	return map[string]visitor.VisitFunc{
+		"Name": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Name:
+				return visitor.ActionUpdate, node.Value
+			case map[string]interface{}:
+				return visitor.ActionUpdate, getMapValue(node, "Value")
 			}
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
+			return visitor.ActionNoChange, nil
+		},
+		"Variable": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Variable:
+				return visitor.ActionUpdate, fmt.Sprintf("$%v", node.Name)
+			case map[string]interface{}:
+				return visitor.ActionUpdate, "$" + getMapValueString(node, "Name")
+			}
+			return visitor.ActionNoChange, nil
+		},
 
-			op := getMapValueString(node, "Operation")
-			name := getMapValueString(node, "Name")
+		// Document
+		"Document": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Document:
+				definitions := toSliceString(node.Definitions)
+				return visitor.ActionUpdate, join(definitions, "\n\n") + "\n"
+			case map[string]interface{}:
+				definitions := toSliceString(getMapValue(node, "Definitions"))
+				return visitor.ActionUpdate, join(definitions, "\n\n") + "\n"
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"OperationDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.OperationDefinition:
+				op := string(node.Operation)
+				name := fmt.Sprintf("%v", node.Name)
 
-			varDefs := wrap("(", join(toSliceString(getMapValue(node, "VariableDefinitions")), ", "), ")")
-			directives := join(toSliceString(getMapValue(node, "Directives")), " ")
-			selectionSet := getMapValueString(node, "SelectionSet")
-			str := ""
-			if name == "" && directives == "" && varDefs == "" && op == ast.OperationTypeQuery {
-				str = selectionSet
-			} else {
-				str = join([]string{
-					op,
-					join([]string{name, varDefs}, ""),
-					directives,
-					selectionSet,
-				}, " ")
+				directives := join(toSliceString(node.Directives), " ")
+				varDefs := c.printArguments(p, join([]string{op, name}, " "), toSliceString(node.VariableDefinitions), wrap(" ", directives, "")+" {")
+				selectionSet := fmt.Sprintf("%v", node.SelectionSet)
+				// Anonymous queries with no directives or variable definitions can use
+				// the query short form.
+				str := ""
+				if name == "" && directives == "" && varDefs == "" && op == ast.OperationTypeQuery {
+					str = selectionSet
+				} else {
+					str = join([]string{
+						op,
+						join([]string{name, varDefs}, ""),
+						directives,
+						selectionSet,
+					}, " ")
+				}
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+
+				op := getMapValueString(node, "Operation")
+				name := getMapValueString(node, "Name")
+
+				directives := join(toSliceString(getMapValue(node, "Directives")), " ")
+				varDefs := c.printArguments(p, join([]string{op, name}, " "), toSliceString(getMapValue(node, "VariableDefinitions")), wrap(" ", directives, "")+" {")
+				selectionSet := getMapValueString(node, "SelectionSet")
+				str := ""
+				if name == "" && directives == "" && varDefs == "" && op == ast.OperationTypeQuery {
+					str = selectionSet
+				} else {
+					str = join([]string{
+						op,
+						join([]string{name, varDefs}, ""),
+						directives,
+						selectionSet,
+					}, " ")
+				}
+				return visitor.ActionUpdate, str
 			}
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"VariableDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.VariableDefinition:
-			variable := fmt.Sprintf("%v", node.Variable)
-			ttype := fmt.Sprintf("%v", node.Type)
-			defaultValue := fmt.Sprintf("%v", node.DefaultValue)
+			return visitor.ActionNoChange, nil
+		},
+		"VariableDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.VariableDefinition:
+				variable := fmt.Sprintf("%v", node.Variable)
+				ttype := fmt.Sprintf("%v", node.Type)
+				defaultValue := fmt.Sprintf("%v", node.DefaultValue)
+				directives := toSliceString(node.Directives)
 
-			return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "")
-		case map[string]interface{}:
+				return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "") + wrap(" ", join(directives, " "), "")
+			case map[string]interface{}:
 
-			variable := getMapValueString(node, "Variable")
-			ttype := getMapValueString(node, "Type")
-			defaultValue := getMapValueString(node, "DefaultValue")
+				variable := getMapValueString(node, "Variable")
+				ttype := getMapValueString(node, "Type")
+				defaultValue := getMapValueString(node, "DefaultValue")
+				directives := toSliceString(getMapValue(node, "Directives"))
 
-			return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "")
+				return visitor.ActionUpdate, variable + ": " + ttype + wrap(" = ", defaultValue, "") + wrap(" ", join(directives, " "), "")
 
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"SelectionSet": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.SelectionSet:
-			str := block(node.Selections)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			selections := getMapValue(node, "Selections")
-			str := block(selections)
-			return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"SelectionSet": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.SelectionSet:
+				str := c.block(c.sorted(toSliceString(node.Selections)))
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				selections := getMapValue(node, "Selections")
+				str := c.block(c.sorted(toSliceString(selections)))
+				return visitor.ActionUpdate, str
 
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"Field": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Argument:
-			name := fmt.Sprintf("%v", node.Name)
-			value := fmt.Sprintf("%v", node.Value)
-			return visitor.ActionUpdate, name + ": " + value
-		case map[string]interface{}:
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"Field": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Argument:
+				name := fmt.Sprintf("%v", node.Name)
+				value := fmt.Sprintf("%v", node.Value)
+				return visitor.ActionUpdate, name + ": " + value
+			case map[string]interface{}:
 
-			alias := getMapValueString(node, "Alias")
-			name := getMapValueString(node, "Name")
-			args := toSliceString(getMapValue(node, "Arguments"))
-			directives := toSliceString(getMapValue(node, "Directives"))
-			selectionSet := getMapValueString(node, "SelectionSet")
+				alias := getMapValueString(node, "Alias")
+				name := getMapValueString(node, "Name")
+				args := c.sorted(toSliceString(getMapValue(node, "Arguments")))
+				directives := toSliceString(getMapValue(node, "Directives"))
+				selectionSet := getMapValueString(node, "SelectionSet")
 
-			str := join(
-				[]string{
-					wrap("", alias, ": ") + name + wrap("(", join(args, ", "), ")"),
-					join(directives, " "),
-					selectionSet,
-				},
-				" ",
-			)
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"Argument": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.FragmentSpread:
-			name := fmt.Sprintf("%v", node.Name)
-			directives := toSliceString(node.Directives)
-			return visitor.ActionUpdate, "..." + name + wrap(" ", join(directives, " "), "")
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			value := getMapValueString(node, "Value")
-			return visitor.ActionUpdate, name + ": " + value
-		}
-		return visitor.ActionNoChange, nil
-	},
+				head := wrap("", alias, ": ") + name
+				suffix := wrap(" ", join(directives, " "), "") + wrap(" ", firstLine(selectionSet), "")
+				str := join(
+					[]string{
+						head + c.printArguments(p, head, args, suffix),
+						join(directives, " "),
+						selectionSet,
+					},
+					" ",
+				)
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"Argument": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.FragmentSpread:
+				name := fmt.Sprintf("%v", node.Name)
+				directives := toSliceString(node.Directives)
+				return visitor.ActionUpdate, "..." + name + wrap(" ", join(directives, " "), "")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				value := getMapValueString(node, "Value")
+				return visitor.ActionUpdate, name + ": " + value
+			}
+			return visitor.ActionNoChange, nil
+		},
 
-	// Fragments
-	"FragmentSpread": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.InlineFragment:
-			typeCondition := fmt.Sprintf("%v", node.TypeCondition)
-			directives := toSliceString(node.Directives)
-			selectionSet := fmt.Sprintf("%v", node.SelectionSet)
-			return visitor.ActionUpdate, "... on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			directives := toSliceString(getMapValue(node, "Directives"))
-			return visitor.ActionUpdate, "..." + name + wrap(" ", join(directives, " "), "")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"InlineFragment": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case map[string]interface{}:
-			typeCondition := getMapValueString(node, "TypeCondition")
-			directives := toSliceString(getMapValue(node, "Directives"))
-			selectionSet := getMapValueString(node, "SelectionSet")
-			return visitor.ActionUpdate,
-				join([]string{
-					"...",
-					wrap("on ", typeCondition, ""),
+		// Fragments
+		"FragmentSpread": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.InlineFragment:
+				typeCondition := fmt.Sprintf("%v", node.TypeCondition)
+				directives := toSliceString(node.Directives)
+				selectionSet := fmt.Sprintf("%v", node.SelectionSet)
+				return visitor.ActionUpdate, "... on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				return visitor.ActionUpdate, "..." + name + wrap(" ", join(directives, " "), "")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"InlineFragment": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case map[string]interface{}:
+				typeCondition := getMapValueString(node, "TypeCondition")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				selectionSet := getMapValueString(node, "SelectionSet")
+				return visitor.ActionUpdate,
+					join([]string{
+						"...",
+						wrap("on ", typeCondition, ""),
+						join(directives, " "),
+						selectionSet,
+					}, " ")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"FragmentDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.FragmentDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				typeCondition := fmt.Sprintf("%v", node.TypeCondition)
+				directives := toSliceString(node.Directives)
+				selectionSet := fmt.Sprintf("%v", node.SelectionSet)
+				return visitor.ActionUpdate, "fragment " + name + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				typeCondition := getMapValueString(node, "TypeCondition")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				selectionSet := getMapValueString(node, "SelectionSet")
+				return visitor.ActionUpdate, "fragment " + name + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
+			}
+			return visitor.ActionNoChange, nil
+		},
+
+		// Value
+		"IntValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.IntValue:
+				return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
+			case map[string]interface{}:
+				return visitor.ActionUpdate, getMapValueString(node, "Value")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"FloatValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.FloatValue:
+				return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
+			case map[string]interface{}:
+				return visitor.ActionUpdate, getMapValueString(node, "Value")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"StringValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.StringValue:
+				if node.Block {
+					return visitor.ActionUpdate, c.printBlockString(node.Value, p.Key == "Description")
+				}
+				return visitor.ActionUpdate, printString(node.Value)
+			case map[string]interface{}:
+				if block, _ := getMapValue(node, "Block").(bool); block {
+					return visitor.ActionUpdate, c.printBlockString(getMapValueString(node, "Value"), p.Key == "Description")
+				}
+				return visitor.ActionUpdate, printString(getMapValueString(node, "Value"))
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"BooleanValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.BooleanValue:
+				return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
+			case map[string]interface{}:
+				return visitor.ActionUpdate, getMapValueString(node, "Value")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"NullValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch p.Node.(type) {
+			case *ast.NullValue, map[string]interface{}:
+				return visitor.ActionUpdate, "null"
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"EnumValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.EnumValue:
+				return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
+			case map[string]interface{}:
+				return visitor.ActionUpdate, getMapValueString(node, "Value")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"ListValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.ListValue:
+				return visitor.ActionUpdate, c.printItems("[", toSliceString(node.Values), "]")
+			case map[string]interface{}:
+				return visitor.ActionUpdate, c.printItems("[", toSliceString(getMapValue(node, "Values")), "]")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"ObjectValue": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.ObjectValue:
+				return visitor.ActionUpdate, c.printItems("{", c.sorted(toSliceString(node.Fields)), "}")
+			case map[string]interface{}:
+				return visitor.ActionUpdate, c.printItems("{", c.sorted(toSliceString(getMapValue(node, "Fields"))), "}")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"ObjectField": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.ObjectField:
+				name := fmt.Sprintf("%v", node.Name)
+				value := fmt.Sprintf("%v", node.Value)
+				return visitor.ActionUpdate, name + ": " + value
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				value := getMapValueString(node, "Value")
+				return visitor.ActionUpdate, name + ": " + value
+			}
+			return visitor.ActionNoChange, nil
+		},
+
+		// Directive
+		"Directive": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Directive:
+				name := fmt.Sprintf("%v", node.Name)
+				args := c.sorted(toSliceString(node.Arguments))
+				if len(args) == 0 {
+					return visitor.ActionUpdate, "@" + name
+				}
+				return visitor.ActionUpdate, "@" + name + c.printItems("(", args, ")")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				args := c.sorted(toSliceString(getMapValue(node, "Arguments")))
+				if len(args) == 0 {
+					return visitor.ActionUpdate, "@" + name
+				}
+				return visitor.ActionUpdate, "@" + name + c.printItems("(", args, ")")
+			}
+			return visitor.ActionNoChange, nil
+		},
+
+		// Type
+		"Named": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.Named:
+				return visitor.ActionUpdate, fmt.Sprintf("%v", node.Name)
+			case map[string]interface{}:
+				return visitor.ActionUpdate, getMapValueString(node, "Name")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"List": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.List:
+				return visitor.ActionUpdate, "[" + fmt.Sprintf("%v", node.Type) + "]"
+			case map[string]interface{}:
+				return visitor.ActionUpdate, "[" + getMapValueString(node, "Type") + "]"
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"NonNull": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.NonNull:
+				return visitor.ActionUpdate, fmt.Sprintf("%v", node.Type) + "!"
+			case map[string]interface{}:
+				return visitor.ActionUpdate, getMapValueString(node, "Type") + "!"
+			}
+			return visitor.ActionNoChange, nil
+		},
+
+		// Type System Definitions
+		"SchemaDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.SchemaDefinition:
+				directives := toSliceString(node.Directives)
+				operationTypesBlock := c.block(node.OperationTypes)
+				str := join([]string{"schema", join(directives, " "), operationTypesBlock}, " ")
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				operationTypes := toSliceString(getMapValue(node, "OperationTypes"))
+				directives := toSliceString(getMapValue(node, "Directives"))
+				operationTypesBlock := c.block(operationTypes)
+				str := join([]string{"schema", join(directives, " "), operationTypesBlock}, " ")
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"OperationTypeDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.OperationTypeDefinition:
+				str := fmt.Sprintf("%v: %v", node.Operation, node.Type)
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				operation := getMapValueString(node, "Operation")
+				ttype := getMapValueString(node, "Type")
+				str := fmt.Sprintf("%v: %v", operation, ttype)
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"ScalarDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.ScalarDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				directives := toSliceString(node.Directives)
+				str := join([]string{"scalar", name, join(directives, " ")}, " ")
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), str}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				str := join([]string{"scalar", name, join(directives, " ")}, " ")
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), str}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"ObjectDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.ObjectDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				interfaces := toSliceString(node.Interfaces)
+				directives := toSliceString(node.Directives)
+				fields := node.Fields
+				str := join([]string{
+					"type",
+					name,
+					wrap("implements ", join(interfaces, ", "), ""),
 					join(directives, " "),
-					selectionSet,
+					c.block(fields),
 				}, " ")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"FragmentDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.FragmentDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			typeCondition := fmt.Sprintf("%v", node.TypeCondition)
-			directives := toSliceString(node.Directives)
-			selectionSet := fmt.Sprintf("%v", node.SelectionSet)
-			return visitor.ActionUpdate, "fragment " + name + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			typeCondition := getMapValueString(node, "TypeCondition")
-			directives := toSliceString(getMapValue(node, "Directives"))
-			selectionSet := getMapValueString(node, "SelectionSet")
-			return visitor.ActionUpdate, "fragment " + name + " on " + typeCondition + " " + wrap("", join(directives, " "), " ") + selectionSet
-		}
-		return visitor.ActionNoChange, nil
-	},
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), str}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				interfaces := toSliceString(getMapValue(node, "Interfaces"))
+				directives := toSliceString(getMapValue(node, "Directives"))
+				fields := getMapValue(node, "Fields")
+				str := join([]string{
+					"type",
+					name,
+					wrap("implements ", join(interfaces, ", "), ""),
+					join(directives, " "),
+					c.block(fields),
+				}, " ")
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), str}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"FieldDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.FieldDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				ttype := fmt.Sprintf("%v", node.Type)
+				args := toSliceString(node.Arguments)
+				directives := toSliceString(node.Directives)
+				suffix := ": " + ttype + wrap(" ", join(directives, " "), "")
+				str := name + c.printArgumentDefs(p, name, args, suffix) + suffix
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), str}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				ttype := getMapValueString(node, "Type")
+				args := toSliceString(getMapValue(node, "Arguments"))
+				directives := toSliceString(getMapValue(node, "Directives"))
+				suffix := ": " + ttype + wrap(" ", join(directives, " "), "")
+				str := name + c.printArgumentDefs(p, name, args, suffix) + suffix
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), str}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"InputValueDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.InputValueDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				ttype := fmt.Sprintf("%v", node.Type)
+				defaultValue := fmt.Sprintf("%v", node.DefaultValue)
+				directives := toSliceString(node.Directives)
+				str := join([]string{
+					name + ": " + ttype,
+					wrap("= ", defaultValue, ""),
+					join(directives, " "),
+				}, " ")
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), str}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				ttype := getMapValueString(node, "Type")
+				defaultValue := getMapValueString(node, "DefaultValue")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				str := join([]string{
+					name + ": " + ttype,
+					wrap("= ", defaultValue, ""),
+					join(directives, " "),
+				}, " ")
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), str}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"InterfaceDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.InterfaceDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				interfaces := toSliceString(node.Interfaces)
+				directives := toSliceString(node.Directives)
+				fields := node.Fields
+				str := join([]string{
+					"interface",
+					name,
+					wrap("implements ", join(interfaces, ", "), ""),
+					join(directives, " "),
+					c.block(fields),
+				}, " ")
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), str}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				interfaces := toSliceString(getMapValue(node, "Interfaces"))
+				fields := getMapValue(node, "Fields")
+				str := join([]string{
+					"interface",
+					name,
+					wrap("implements ", join(interfaces, ", "), ""),
+					join(directives, " "),
+					c.block(fields),
+				}, " ")
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), str}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"UnionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.UnionDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				types := toSliceString(node.Types)
+				directives := toSliceString(node.Directives)
+				str := join([]string{"union", name, join(directives, " "), wrap("= ", join(types, " | "), "")}, " ")
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), str}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				types := toSliceString(getMapValue(node, "Types"))
+				directives := toSliceString(getMapValue(node, "Directives"))
+				str := join([]string{"union", name, join(directives, " "), wrap("= ", join(types, " | "), "")}, " ")
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), str}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"EnumDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.EnumDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				directives := toSliceString(node.Directives)
+				values := node.Values
+				str := join([]string{"enum", name, join(directives, " "), c.block(values)}, " ")
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), str}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				values := getMapValue(node, "Values")
+				str := join([]string{"enum", name, join(directives, " "), c.block(values)}, " ")
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), str}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"EnumValueDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.EnumValueDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				directives := toSliceString(node.Directives)
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), join([]string{name, join(directives, " ")}, " ")}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), join([]string{name, join(directives, " ")}, " ")}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"InputObjectDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.InputObjectDefinition:
+				name := fmt.Sprintf("%v", node.Name)
+				directives := toSliceString(node.Directives)
+				fields := node.Fields
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), join([]string{"input", name, join(directives, " "), c.block(fields)}, " ")}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				directives := toSliceString(getMapValue(node, "Directives"))
+				fields := getMapValue(node, "Fields")
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), join([]string{"input", name, join(directives, " "), c.block(fields)}, " ")}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"TypeExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.TypeExtensionDefinition:
+				definition := fmt.Sprintf("%v", node.Definition)
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				definition := getMapValueString(node, "Definition")
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"SchemaExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.SchemaExtensionDefinition:
+				definition := fmt.Sprintf("%v", node.Definition)
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				definition := getMapValueString(node, "Definition")
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"ScalarExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.ScalarExtensionDefinition:
+				definition := fmt.Sprintf("%v", node.Definition)
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				definition := getMapValueString(node, "Definition")
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"InterfaceExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.InterfaceExtensionDefinition:
+				definition := fmt.Sprintf("%v", node.Definition)
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				definition := getMapValueString(node, "Definition")
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"UnionExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.UnionExtensionDefinition:
+				definition := fmt.Sprintf("%v", node.Definition)
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				definition := getMapValueString(node, "Definition")
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"EnumExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.EnumExtensionDefinition:
+				definition := fmt.Sprintf("%v", node.Definition)
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				definition := getMapValueString(node, "Definition")
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"InputObjectExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.InputObjectExtensionDefinition:
+				definition := fmt.Sprintf("%v", node.Definition)
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			case map[string]interface{}:
+				definition := getMapValueString(node, "Definition")
+				str := "extend " + definition
+				return visitor.ActionUpdate, str
+			}
+			return visitor.ActionNoChange, nil
+		},
+		"DirectiveDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
+			switch node := p.Node.(type) {
+			case *ast.DirectiveDefinition:
+				locations := join(toSliceString(node.Locations), " | ")
+				args := c.printArgumentDefs(p, fmt.Sprintf("directive @%v", node.Name), toSliceString(node.Arguments), " on "+locations)
+				str := fmt.Sprintf("directive @%v%v on %v", node.Name, args, locations)
+				return visitor.ActionUpdate, join([]string{c.printDescription(node.Description), str}, "\n")
+			case map[string]interface{}:
+				name := getMapValueString(node, "Name")
+				locations := toSliceString(getMapValue(node, "Locations"))
+				args := toSliceString(getMapValue(node, "Arguments"))
+				argsStr := c.printArgumentDefs(p, "directive @"+name, args, " on "+join(locations, " | "))
+				str := fmt.Sprintf("directive @%v%v on %v", name, argsStr, join(locations, " | "))
+				return visitor.ActionUpdate, join([]string{getMapValueString(node, "Description"), str}, "\n")
+			}
+			return visitor.ActionNoChange, nil
+		},
+	}
+}
 
-	// Value
-	"IntValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.IntValue:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Value")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"FloatValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.FloatValue:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Value")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"StringValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.StringValue:
-			return visitor.ActionUpdate, `"` + fmt.Sprintf("%v", node.Value) + `"`
-		case map[string]interface{}:
-			return visitor.ActionUpdate, `"` + getMapValueString(node, "Value") + `"`
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"BooleanValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.BooleanValue:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Value")
+// printBlockString prints a value as a block string. The lines of
+// descriptions are not indented, as they precede the definition they describe.
+func (c *Config) printBlockString(value string, isDescription bool) string {
+	escaped := strings.Replace(value, `"""`, `\"""`, -1)
+	if (strings.HasPrefix(value, " ") || strings.HasPrefix(value, "\t")) && !strings.Contains(value, "\n") {
+		if strings.HasSuffix(escaped, `"`) {
+			escaped += "\n"
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"EnumValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.EnumValue:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Value)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Value")
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"ListValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ListValue:
-			return visitor.ActionUpdate, "[" + join(toSliceString(node.Values), ", ") + "]"
-		case map[string]interface{}:
-			return visitor.ActionUpdate, "[" + join(toSliceString(getMapValue(node, "Values")), ", ") + "]"
+		return `"""` + escaped + `"""`
+	}
+	if isDescription {
+		return `"""` + "\n" + escaped + "\n" + `"""`
+	}
+	return `"""` + c.indent("\n"+escaped) + "\n" + `"""`
+}
+
+// printDescription prints the description of a type system definition.
+func (c *Config) printDescription(description *ast.StringValue) string {
+	if description == nil {
+		return ""
+	}
+	if description.Block {
+		return c.printBlockString(description.Value, true)
+	}
+	return printString(description.Value)
+}
+
+// printString prints a value as a string, escaping the characters which
+// cannot appear as they are in it.
+func printString(value string) string {
+	var buf bytes.Buffer
+	buf.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < ' ' {
+				fmt.Fprintf(&buf, `\u%04X`, r)
+			} else {
+				buf.WriteRune(r)
+			}
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"ObjectValue": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ObjectValue:
-			return visitor.ActionUpdate, "{" + join(toSliceString(node.Fields), ", ") + "}"
-		case map[string]interface{}:
-			return visitor.ActionUpdate, "{" + join(toSliceString(getMapValue(node, "Fields")), ", ") + "}"
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// printArgumentDefs prints argument definitions on a single line, unless some
+// of them span several lines, as those with a description, or end with a
+// comment, or the line would exceed the maximum length.
+func (c *Config) printArgumentDefs(p visitor.VisitFuncParams, prefix string, args []string, suffix string) string {
+	for _, arg := range args {
+		if strings.Contains(arg, "\n") || hasComment(arg) {
+			return wrap("(\n"+c.indentation(), c.indent(join(args, "\n")), "\n)")
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"ObjectField": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ObjectField:
-			name := fmt.Sprintf("%v", node.Name)
-			value := fmt.Sprintf("%v", node.Value)
-			return visitor.ActionUpdate, name + ": " + value
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			value := getMapValueString(node, "Value")
-			return visitor.ActionUpdate, name + ": " + value
+	}
+	return c.printArguments(p, prefix, args, suffix)
+}
+
+// hasComment determines if a printed node contains a comment, skipping the
+// contents of its strings.
+func hasComment(printed string) bool {
+	for i := 0; i < len(printed); i++ {
+		switch {
+		case printed[i] == '#':
+			return true
+		case strings.HasPrefix(printed[i:], `"""`):
+			end := strings.Index(strings.Replace(printed[i+3:], `\"""`, "    ", -1), `"""`)
+			if end < 0 {
+				return false
+			}
+			i += end + 5
+		case printed[i] == '"':
+			for i++; i < len(printed) && printed[i] != '"'; i++ {
+				if printed[i] == '\\' {
+					i++
+				}
+			}
 		}
-		return visitor.ActionNoChange, nil
-	},
+	}
+	return false
+}
 
-	// Directive
-	"Directive": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Directive:
-			name := fmt.Sprintf("%v", node.Name)
-			args := toSliceString(node.Arguments)
-			return visitor.ActionUpdate, "@" + name + wrap("(", join(args, ", "), ")")
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			args := toSliceString(getMapValue(node, "Arguments"))
-			return visitor.ActionUpdate, "@" + name + wrap("(", join(args, ", "), ")")
+// anyComment determines if some of the printed nodes contain a comment.
+func anyComment(printed []string) bool {
+	for _, str := range printed {
+		if hasComment(str) {
+			return true
 		}
-		return visitor.ActionNoChange, nil
-	},
+	}
+	return false
+}
 
-	// Type
-	"Named": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.Named:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Name)
-		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Name")
+// printComments decorates a reducer to print the comments attached to the
+// node, before, after and at the end of the line of its printed value.
+func printComments(reducer visitor.VisitFunc) visitor.VisitFunc {
+	return func(p visitor.VisitFuncParams) (string, interface{}) {
+		action, result := reducer(p)
+		str, isString := result.(string)
+		if action != visitor.ActionUpdate || !isString {
+			return action, result
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"List": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.List:
-			return visitor.ActionUpdate, "[" + fmt.Sprintf("%v", node.Type) + "]"
-		case map[string]interface{}:
-			return visitor.ActionUpdate, "[" + getMapValueString(node, "Type") + "]"
+		// comments of a document without definitions are not attached
+		if doc, ok := p.Node.(*ast.Document); ok && len(doc.Definitions) == 0 {
+			lines := []string{}
+			for _, comment := range doc.Comments {
+				lines = append(lines, "#"+comment.Value)
+			}
+			return action, join(lines, "\n") + "\n"
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"NonNull": func(p visitor.VisitFuncParams) (string, interface{}) {
+		var leading, closing []string
 		switch node := p.Node.(type) {
-		case *ast.NonNull:
-			return visitor.ActionUpdate, fmt.Sprintf("%v", node.Type) + "!"
 		case map[string]interface{}:
-			return visitor.ActionUpdate, getMapValueString(node, "Type") + "!"
+			comments, ok := node["Comments"].(map[string]interface{})
+			if !ok {
+				return action, result
+			}
+			if trailing, ok := comments["Trailing"].(map[string]interface{}); ok {
+				str += " " + commentLines([]interface{}{trailing})[0]
+			}
+			leading = commentLines(comments["Leading"])
+			closing = commentLines(comments["Closing"])
+		case ast.Node:
+			// leaf nodes, as the items of a list, are not converted to maps
+			field := reflect.Indirect(reflect.ValueOf(node)).FieldByName("Comments")
+			if !field.IsValid() {
+				return action, result
+			}
+			comments, _ := field.Interface().(*ast.CommentGroup)
+			if comments == nil {
+				return action, result
+			}
+			if comments.Trailing != nil {
+				str += " #" + comments.Trailing.Value
+			}
+			for _, comment := range comments.Leading {
+				leading = append(leading, "#"+comment.Value)
+			}
+			for _, comment := range comments.Closing {
+				closing = append(closing, "#"+comment.Value)
+			}
 		}
-		return visitor.ActionNoChange, nil
-	},
+		return action, join([]string{join(leading, "\n"), str, join(closing, "\n")}, "\n")
+	}
+}
 
-	// Type System Definitions
-	"SchemaDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.SchemaDefinition:
-			operationTypesBlock := block(node.OperationTypes)
-			str := fmt.Sprintf("schema %v", operationTypesBlock)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			operationTypes := toSliceString(getMapValue(node, "OperationTypes"))
-			operationTypesBlock := block(operationTypes)
-			str := fmt.Sprintf("schema %v", operationTypesBlock)
-			return visitor.ActionUpdate, str
-		}
-		return visitor.ActionNoChange, nil
-	},
-	"OperationTypeDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.OperationTypeDefinition:
-			str := fmt.Sprintf("%v: %v", node.Operation, node.Type)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			operation := getMapValueString(node, "Operation")
-			ttype := getMapValueString(node, "Type")
-			str := fmt.Sprintf("%v: %v", operation, ttype)
-			return visitor.ActionUpdate, str
+func commentLines(comments interface{}) []string {
+	lines := []string{}
+	list, _ := comments.([]interface{})
+	for _, comment := range list {
+		if comment, ok := comment.(map[string]interface{}); ok {
+			lines = append(lines, "#"+getMapValueString(comment, "Value"))
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"ScalarDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ScalarDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			str := "scalar " + name
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			str := "scalar " + name
-			return visitor.ActionUpdate, str
+	}
+	return lines
+}
+
+var printDocASTReducer = newPrintDocASTReducer(&Config{})
+
+var printDocASTReducerWithComments = map[string]visitor.VisitFunc{}
+
+func init() {
+	for kind, reducer := range printDocASTReducer {
+		printDocASTReducerWithComments[kind] = printComments(reducer)
+	}
+}
+
+func Print(astNode ast.Node) (printed interface{}) {
+	return printAST(astNode, printDocASTReducer)
+}
+
+// PrintWithComments prints the AST like Print, along with the comments kept
+// when parsing it.
+func PrintWithComments(astNode ast.Node) (printed interface{}) {
+	return printAST(astNode, printDocASTReducerWithComments)
+}
+
+func printAST(astNode ast.Node, reducer map[string]visitor.VisitFunc) (printed interface{}) {
+	defer func() interface{} {
+		if r := recover(); r != nil {
+			return fmt.Sprintf("%v", astNode)
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"ObjectDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.ObjectDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			interfaces := toSliceString(node.Interfaces)
-			fields := node.Fields
-			str := "type " + name + " " + wrap("implements ", join(interfaces, ", "), " ") + block(fields)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			interfaces := toSliceString(getMapValue(node, "Interfaces"))
-			fields := getMapValue(node, "Fields")
-			str := "type " + name + " " + wrap("implements ", join(interfaces, ", "), " ") + block(fields)
-			return visitor.ActionUpdate, str
+		return printed
+	}()
+	printed = visitor.Visit(astNode, &visitor.VisitorOptions{
+		LeaveKindMap: reducer,
+	}, nil)
+	return printed
+}
+
+// CommaStyle is how the items of arguments, variable definitions, lists and
+// objects printed on one line are separated.
+type CommaStyle int
+
+const (
+	// CommaSeparated separates them with a comma and a space.
+	CommaSeparated CommaStyle = iota
+	// SpaceSeparated separates them with a space, commas being optional.
+	SpaceSeparated
+)
+
+// Config configures the printing of an AST. Its zero value prints like Print.
+type Config struct {
+	// Indent is the number of spaces a level of nesting is indented by, 2
+	// when zero.
+	Indent int
+	// MaxLineLength is the length past which the arguments of a field or
+	// field definition, and the variable definitions of an operation, are
+	// printed one per line; zero means no limit.
+	MaxLineLength int
+	// Commas is how items printed on one line are separated.
+	Commas CommaStyle
+	// SortFields sorts selections, arguments and the fields of object values
+	// instead of preserving their order.
+	SortFields bool
+	// Comments prints the comments kept when parsing, as PrintWithComments.
+	Comments bool
+	// Minify prints the AST on a single line, without comments and with only
+	// the whitespace separating names and values, block strings being printed
+	// as strings. The other options aside from SortFields are ignored.
+	Minify bool
+}
+
+// Print prints the AST as configured.
+func (c *Config) Print(astNode ast.Node) string {
+	if c.Minify {
+		return (&minifier{sortFields: c.SortFields}).print(astNode)
+	}
+	reducer := newPrintDocASTReducer(c)
+	if c.Comments {
+		for kind, visitFunc := range reducer {
+			reducer[kind] = printComments(visitFunc)
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"FieldDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.FieldDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			ttype := fmt.Sprintf("%v", node.Type)
-			args := toSliceString(node.Arguments)
-			str := name + wrap("(", join(args, ", "), ")") + ": " + ttype
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			ttype := getMapValueString(node, "Type")
-			args := toSliceString(getMapValue(node, "Arguments"))
-			str := name + wrap("(", join(args, ", "), ")") + ": " + ttype
-			return visitor.ActionUpdate, str
+	}
+	printed := printAST(astNode, reducer)
+	str, ok := printed.(string)
+	if !ok {
+		str = fmt.Sprintf("%v", printed)
+	}
+	return str
+}
+
+// minifier prints an AST on a single line, with only the whitespace its
+// tokens need to be lexed apart.
+type minifier struct {
+	sortFields bool
+}
+
+func (m *minifier) print(node ast.Node) string {
+	if node == nil || reflect.ValueOf(node).IsNil() {
+		return ""
+	}
+	switch node := node.(type) {
+	case *ast.Name:
+		return node.Value
+	case *ast.Variable:
+		return "$" + m.print(node.Name)
+
+	// Document
+	case *ast.Document:
+		return m.catAll(node.Definitions)
+	case *ast.OperationDefinition:
+		name := m.print(node.Name)
+		varDefs := wrap("(", m.catAll(node.VariableDefinitions), ")")
+		directives := m.catAll(node.Directives)
+		selectionSet := m.print(node.SelectionSet)
+		// Anonymous queries with no directives or variable definitions can use
+		// the query short form.
+		if name == "" && directives == "" && varDefs == "" && node.Operation == ast.OperationTypeQuery {
+			return selectionSet
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"InputValueDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.InputValueDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			ttype := fmt.Sprintf("%v", node.Type)
-			defaultValue := fmt.Sprintf("%v", node.DefaultValue)
-			str := name + ": " + ttype + wrap(" = ", defaultValue, "")
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			ttype := getMapValueString(node, "Type")
-			defaultValue := getMapValueString(node, "DefaultValue")
-			str := name + ": " + ttype + wrap(" = ", defaultValue, "")
-			return visitor.ActionUpdate, str
+		return cat(node.Operation, name, varDefs, directives, selectionSet)
+	case *ast.VariableDefinition:
+		defaultValue := m.print(node.DefaultValue)
+		if defaultValue != "" {
+			defaultValue = "=" + defaultValue
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"InterfaceDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.InterfaceDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			fields := node.Fields
-			str := "interface " + name + " " + block(fields)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			fields := getMapValue(node, "Fields")
-			str := "interface " + name + " " + block(fields)
-			return visitor.ActionUpdate, str
+		return cat(m.print(node.Variable), ":", m.print(node.Type), defaultValue, m.catAll(node.Directives))
+	case *ast.SelectionSet:
+		return wrap("{", cat(m.sorted(m.printAll(node.Selections))...), "}")
+	case *ast.Field:
+		alias := m.print(node.Alias)
+		if alias != "" {
+			alias += ":"
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"UnionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.UnionDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			types := toSliceString(node.Types)
-			str := "union " + name + " = " + join(types, " | ")
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			types := toSliceString(getMapValue(node, "Types"))
-			str := "union " + name + " = " + join(types, " | ")
-			return visitor.ActionUpdate, str
+		args := wrap("(", cat(m.sorted(m.printAll(node.Arguments))...), ")")
+		return cat(alias, m.print(node.Name), args, m.catAll(node.Directives), m.print(node.SelectionSet))
+	case *ast.Argument:
+		return cat(m.print(node.Name), ":", m.print(node.Value))
+
+	// Fragments
+	case *ast.FragmentSpread:
+		return cat("...", m.print(node.Name), m.catAll(node.Directives))
+	case *ast.InlineFragment:
+		typeCondition := m.print(node.TypeCondition)
+		if typeCondition != "" {
+			typeCondition = cat("on", typeCondition)
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"EnumDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.EnumDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			values := node.Values
-			str := "enum " + name + " " + block(values)
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			values := getMapValue(node, "Values")
-			str := "enum " + name + " " + block(values)
-			return visitor.ActionUpdate, str
+		return cat("...", typeCondition, m.catAll(node.Directives), m.print(node.SelectionSet))
+	case *ast.FragmentDefinition:
+		return cat("fragment", m.print(node.Name), "on", m.print(node.TypeCondition), m.catAll(node.Directives), m.print(node.SelectionSet))
+
+	// Value
+	case *ast.IntValue:
+		return node.Value
+	case *ast.FloatValue:
+		return node.Value
+	case *ast.StringValue:
+		return printString(node.Value)
+	case *ast.BooleanValue:
+		return fmt.Sprintf("%v", node.Value)
+	case *ast.NullValue:
+		return "null"
+	case *ast.EnumValue:
+		return node.Value
+	case *ast.ListValue:
+		return "[" + m.catAll(node.Values) + "]"
+	case *ast.ObjectValue:
+		return "{" + cat(m.sorted(m.printAll(node.Fields))...) + "}"
+	case *ast.ObjectField:
+		return cat(m.print(node.Name), ":", m.print(node.Value))
+
+	// Directive
+	case *ast.Directive:
+		return "@" + m.print(node.Name) + wrap("(", cat(m.sorted(m.printAll(node.Arguments))...), ")")
+
+	// Type
+	case *ast.Named:
+		return m.print(node.Name)
+	case *ast.List:
+		return "[" + m.print(node.Type) + "]"
+	case *ast.NonNull:
+		return m.print(node.Type) + "!"
+
+	// Type System Definitions
+	case *ast.SchemaDefinition:
+		return cat("schema", m.catAll(node.Directives), wrap("{", m.catAll(node.OperationTypes), "}"))
+	case *ast.OperationTypeDefinition:
+		return cat(node.Operation, ":", m.print(node.Type))
+	case *ast.ScalarDefinition:
+		return cat(m.print(node.Description), "scalar", m.print(node.Name), m.catAll(node.Directives))
+	case *ast.ObjectDefinition:
+		return cat(m.print(node.Description), "type", m.print(node.Name), m.implements(node.Interfaces), m.catAll(node.Directives), wrap("{", m.catAll(node.Fields), "}"))
+	case *ast.FieldDefinition:
+		args := wrap("(", m.catAll(node.Arguments), ")")
+		return cat(m.print(node.Description), m.print(node.Name), args, ":", m.print(node.Type), m.catAll(node.Directives))
+	case *ast.InputValueDefinition:
+		defaultValue := m.print(node.DefaultValue)
+		if defaultValue != "" {
+			defaultValue = "=" + defaultValue
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"EnumValueDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.EnumValueDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			return visitor.ActionUpdate, name
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			return visitor.ActionUpdate, name
+		return cat(m.print(node.Description), m.print(node.Name), ":", m.print(node.Type), defaultValue, m.catAll(node.Directives))
+	case *ast.InterfaceDefinition:
+		return cat(m.print(node.Description), "interface", m.print(node.Name), m.implements(node.Interfaces), m.catAll(node.Directives), wrap("{", m.catAll(node.Fields), "}"))
+	case *ast.UnionDefinition:
+		types := strings.Join(m.printAll(node.Types), "|")
+		if types != "" {
+			types = "=" + types
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"InputObjectDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.InputObjectDefinition:
-			name := fmt.Sprintf("%v", node.Name)
-			fields := node.Fields
-			return visitor.ActionUpdate, "input " + name + " " + block(fields)
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			fields := getMapValue(node, "Fields")
-			return visitor.ActionUpdate, "input " + name + " " + block(fields)
+		return cat(m.print(node.Description), "union", m.print(node.Name), m.catAll(node.Directives), types)
+	case *ast.EnumDefinition:
+		return cat(m.print(node.Description), "enum", m.print(node.Name), m.catAll(node.Directives), wrap("{", m.catAll(node.Values), "}"))
+	case *ast.EnumValueDefinition:
+		return cat(m.print(node.Description), m.print(node.Name), m.catAll(node.Directives))
+	case *ast.InputObjectDefinition:
+		return cat(m.print(node.Description), "input", m.print(node.Name), m.catAll(node.Directives), wrap("{", m.catAll(node.Fields), "}"))
+	case *ast.TypeExtensionDefinition:
+		return cat("extend", m.print(node.Definition))
+	case *ast.SchemaExtensionDefinition:
+		return cat("extend", m.print(node.Definition))
+	case *ast.ScalarExtensionDefinition:
+		return cat("extend", m.print(node.Definition))
+	case *ast.InterfaceExtensionDefinition:
+		return cat("extend", m.print(node.Definition))
+	case *ast.UnionExtensionDefinition:
+		return cat("extend", m.print(node.Definition))
+	case *ast.EnumExtensionDefinition:
+		return cat("extend", m.print(node.Definition))
+	case *ast.InputObjectExtensionDefinition:
+		return cat("extend", m.print(node.Definition))
+	case *ast.DirectiveDefinition:
+		args := wrap("(", m.catAll(node.Arguments), ")")
+		locations := strings.Join(m.printAll(node.Locations), "|")
+		return cat(m.print(node.Description), "directive", "@"+m.print(node.Name)+args, "on", locations)
+	}
+	return ""
+}
+
+// printAll prints each node of a slice of nodes.
+func (m *minifier) printAll(nodes interface{}) []string {
+	printed := []string{}
+	slice := reflect.ValueOf(nodes)
+	for i := 0; i < slice.Len(); i++ {
+		if node, ok := slice.Index(i).Interface().(ast.Node); ok {
+			printed = append(printed, m.print(node))
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"TypeExtensionDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.TypeExtensionDefinition:
-			definition := fmt.Sprintf("%v", node.Definition)
-			str := "extend " + definition
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			definition := getMapValueString(node, "Definition")
-			str := "extend " + definition
-			return visitor.ActionUpdate, str
+	}
+	return printed
+}
+
+// catAll prints the nodes of a slice of nodes one after the other.
+func (m *minifier) catAll(nodes interface{}) string {
+	return cat(m.printAll(nodes)...)
+}
+
+func (m *minifier) implements(interfaces []*ast.Named) string {
+	if len(interfaces) == 0 {
+		return ""
+	}
+	return cat("implements", m.catAll(interfaces))
+}
+
+// sorted returns printed items, sorted when so configured.
+func (m *minifier) sorted(items []string) []string {
+	if m.sortFields {
+		sort.Strings(items)
+	}
+	return items
+}
+
+// cat concatenates printed tokens, separating those which would otherwise be
+// lexed as one.
+func cat(tokens ...string) string {
+	var buf bytes.Buffer
+	for _, token := range tokens {
+		if token == "" {
+			continue
 		}
-		return visitor.ActionNoChange, nil
-	},
-	"DirectiveDefinition": func(p visitor.VisitFuncParams) (string, interface{}) {
-		switch node := p.Node.(type) {
-		case *ast.DirectiveDefinition:
-			args := wrap("(", join(toSliceString(node.Arguments), ", "), ")")
-			str := fmt.Sprintf("directive @%v%v on %v", node.Name, args, join(toSliceString(node.Locations), " | "))
-			return visitor.ActionUpdate, str
-		case map[string]interface{}:
-			name := getMapValueString(node, "Name")
-			locations := toSliceString(getMapValue(node, "Locations"))
-			args := toSliceString(getMapValue(node, "Arguments"))
-			argsStr := wrap("(", join(args, ", "), ")")
-			str := fmt.Sprintf("directive @%v%v on %v", name, argsStr, join(locations, " | "))
-			return visitor.ActionUpdate, str
+		if buf.Len() > 0 && needsSpace(buf.Bytes()[buf.Len()-1], token[0]) {
+			buf.WriteByte(' ')
 		}
-		return visitor.ActionNoChange, nil
-	},
+		buf.WriteString(token)
+	}
+	return buf.String()
 }
 
-func Print(astNode ast.Node) (printed interface{}) {
-	defer func() interface{} {
-		if r := recover(); r != nil {
-			return fmt.Sprintf("%v", astNode)
-		}
-		return printed
-	}()
-	printed = visitor.Visit(astNode, &visitor.VisitorOptions{
-		LeaveKindMap: printDocASTReducer,
-	}, nil)
-	return printed
+// needsSpace determines if the characters ending and starting two printed
+// tokens must be separated for the tokens to be lexed apart.
+func needsSpace(last, next byte) bool {
+	isWord := func(code byte) bool {
+		return code == '_' || code >= '0' && code <= '9' || code >= 'A' && code <= 'Z' || code >= 'a' && code <= 'z'
+	}
+	isDigit := func(code byte) bool {
+		return code >= '0' && code <= '9'
+	}
+	return isWord(last) && (isWord(next) || next == '-') ||
+		last == '"' && next == '"' ||
+		isDigit(last) && next == '.'
 }
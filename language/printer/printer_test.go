@@ -176,7 +176,7 @@ fragment frag on Follower {
 }
 
 {
-  unnamed(truthyVal: true, falseyVal: false)
+  unnamed(truthyVal: true, falseyVal: false, nullVal: null)
   query
 }
 `
@@ -186,3 +186,285 @@ fragment frag on Follower {
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(results, expected))
 	}
 }
+
+func TestPrinter_PrintsComments(t *testing.T) {
+	query := `# leading comment
+query Foo(
+  # on a variable
+  $id: ID
+) {
+  # before a field
+  a(id: $id) # after a field
+  b { c } # after a selection set
+  # closing the block
+}
+
+# after the operation
+`
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source: query,
+		Options: parser.ParseOptions{
+			NoLocation:   true,
+			KeepComments: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	results := printer.PrintWithComments(astDoc)
+	expected := `# leading comment
+query Foo(
+  # on a variable
+  $id: ID
+) {
+  # before a field
+  a(id: $id) # after a field
+  b {
+    c
+  } # after a selection set
+  # closing the block
+}
+# after the operation
+`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_PrintsCommentsInArgumentsAndValues(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			"{\n  a(\n    # c\n    x: 1)\n}\n",
+			"{\n  a(\n    # c\n    x: 1\n  )\n}\n",
+		},
+		{
+			"query Q($a: Int # c\n) {\n  f\n}\n",
+			"query Q(\n  $a: Int # c\n) {\n  f\n}\n",
+		},
+		{
+			"{\n  a(x: {y: 1 # c\n  }, z: [1, # d\n 2]) @dir(\n # e\n  w: 3)\n}\n",
+			"{\n  a(\n    x: {\n      y: 1 # c\n    }\n    z: [\n      1 # d\n      2\n    ]\n  ) @dir(\n    # e\n    w: 3\n  )\n}\n",
+		},
+	}
+	for _, test := range tests {
+		astDoc, err := parser.Parse(parser.ParseParams{
+			Source: test.query,
+			Options: parser.ParseOptions{
+				NoLocation:   true,
+				KeepComments: true,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		results := printer.PrintWithComments(astDoc)
+		if !reflect.DeepEqual(test.expected, results) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(test.expected, results))
+		}
+	}
+}
+
+func TestPrinter_PrintsCommentsOfDocumentWithoutDefinitions(t *testing.T) {
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source: "# only\n# comments\n",
+		Options: parser.ParseOptions{
+			KeepComments: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	results := printer.PrintWithComments(astDoc)
+	expected := "# only\n# comments\n"
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_PrintsDirectivesOnVariableDefinitions(t *testing.T) {
+	astDoc := parse(t, `query Q($a: Int = 1 @onVariable, $b: Boolean @onVariable(x: 2)) { f }`)
+	results := printer.Print(astDoc)
+	expected := `query Q($a: Int = 1 @onVariable, $b: Boolean @onVariable(x: 2)) {
+  f
+}
+`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_ZeroConfigPrintsLikePrint(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	astDoc := parse(t, string(b))
+	expected := printer.Print(astDoc)
+	results := (&printer.Config{}).Print(astDoc)
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_ConfigIndentsAndSeparates(t *testing.T) {
+	astDoc := parse(t, `query Q($a: Int, $b: [Int]) { f(x: [1, 2], y: {u: 1, v: 2}) { g @d(p: 1, q: 2) } }`)
+	results := (&printer.Config{
+		Indent: 4,
+		Commas: printer.SpaceSeparated,
+	}).Print(astDoc)
+	expected := `query Q($a: Int $b: [Int]) {
+    f(x: [1 2] y: {u: 1 v: 2}) {
+        g @d(p: 1 q: 2)
+    }
+}
+`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_ConfigWrapsLongArguments(t *testing.T) {
+	astDoc := parse(t, `
+query LongOperationName($first: Int, $after: String, $orderBy: Order) {
+  short(id: 1)
+  viewer {
+    friends(first: $first, after: $after, orderBy: $orderBy) @include(if: true) {
+      name
+    }
+  }
+}
+`)
+	results := (&printer.Config{MaxLineLength: 60}).Print(astDoc)
+	expected := `query LongOperationName(
+  $first: Int
+  $after: String
+  $orderBy: Order
+) {
+  short(id: 1)
+  viewer {
+    friends(
+      first: $first
+      after: $after
+      orderBy: $orderBy
+    ) @include(if: true) {
+      name
+    }
+  }
+}
+`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_ConfigWrapsLongArgumentDefinitions(t *testing.T) {
+	astDoc := parse(t, `type Query { search(text: String, first: Int, after: String): [Result] }`)
+	results := (&printer.Config{MaxLineLength: 40}).Print(astDoc)
+	expected := `type Query {
+  search(
+    text: String
+    first: Int
+    after: String
+  ): [Result]
+}
+`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_ConfigSortsFields(t *testing.T) {
+	astDoc := parse(t, `{ c(z: 1, a: {y: 2, b: 3}) b { y x } ...F a }`)
+	results := (&printer.Config{SortFields: true}).Print(astDoc)
+	expected := `{
+  ...F
+  a
+  b {
+    x
+    y
+  }
+  c(a: {b: 3, y: 2}, z: 1)
+}
+`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_ConfigMinifies(t *testing.T) {
+	b, err := ioutil.ReadFile("../../kitchen-sink.graphql")
+	if err != nil {
+		t.Fatalf("unable to load kitchen-sink.graphql")
+	}
+	astDoc := parse(t, string(b))
+	results := (&printer.Config{Minify: true}).Print(astDoc)
+	expected := `query namedQuery($foo:ComplexFooType$bar:Bar=DefaultBarValue){customUser:user(id:[987 654]){id...on User@defer{field2{id alias:field1(first:10 after:$foo)@include(if:$foo){id...frag}}}...@skip(unless:$foo){id}...{id}}}` +
+		`mutation favPost{fav(post:123)@defer{post{id}}}` +
+		`subscription PostFavSubscription($input:StoryLikeSubscribeInput){postFavSubscribe(input:$input){post{favers{count}favSentence{text}}}}` +
+		`fragment frag on Follower{foo(size:$size bar:$b obj:{key:"value"})}` +
+		`{unnamed(truthyVal:true falseyVal:false nullVal:null)query}`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+	if _, err := parser.Parse(parser.ParseParams{Source: results}); err != nil {
+		t.Fatalf("unable to parse the minified document: %v", err)
+	}
+}
+
+func TestPrinter_ConfigMinifiesStrings(t *testing.T) {
+	astDoc := parse(t, `{ f(a: ["x", "y"], b: "two words") }`)
+	results := (&printer.Config{Minify: true}).Print(astDoc)
+	expected := `{f(a:["x" "y"]b:"two words")}`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestPrinter_ConfigMinifiesEscapedStrings(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{`{ a(x: "say \"hi\"") }`, `{a(x:"say \"hi\"")}`},
+		{`{ a(x: "line\nbreak", y: "back\\slash") }`, `{a(x:"line\nbreak"y:"back\\slash")}`},
+		{"{ a(x: \"\"\"\n  block \"quoted\"\n  string\n\"\"\") }", `{a(x:"block \"quoted\"\nstring")}`},
+		{`{ a(x: ["#", "}"]) }`, `{a(x:["#" "}"])}`},
+	}
+	for _, test := range tests {
+		results := (&printer.Config{Minify: true}).Print(parse(t, test.query))
+		if !reflect.DeepEqual(test.expected, results) {
+			t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(test.expected, results))
+		}
+		if _, err := parser.Parse(parser.ParseParams{Source: results}); err != nil {
+			t.Fatalf("unable to parse the minified document: %v", err)
+		}
+	}
+}
+
+func TestPrinter_ConfigMinifiesTypeSystemDefinitions(t *testing.T) {
+	astDoc := parse(t, `"""
+A type.
+"""
+type Foo implements Bar Baz @tag(name: "foo") {
+  "A field."
+  a(x: Int = -1, y: [String!] = ["a"]): [Int]! @deprecated
+}
+
+union U = Foo | Qux
+
+directive @tag(name: String!) on OBJECT | FIELD_DEFINITION
+
+extend enum E { A B }`)
+	results := (&printer.Config{Minify: true}).Print(astDoc)
+	expected := `"A type."type Foo implements Bar Baz@tag(name:"foo"){"A field."a(x:Int=-1 y:[String!]=["a"]):[Int]!@deprecated}` +
+		`union U=Foo|Qux directive@tag(name:String!)on OBJECT|FIELD_DEFINITION extend enum E{A B}`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+	if _, err := parser.Parse(parser.ParseParams{Source: results}); err != nil {
+		t.Fatalf("unable to parse the minified document: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
 	"github.com/graphql-go/graphql/language/printer"
 	"github.com/graphql-go/graphql/testutil"
 )
@@ -53,43 +54,114 @@ func TestSchemaPrinter_PrintsKitchenSink(t *testing.T) {
 
 	query := string(b)
 	astDoc := parse(t, query)
-	expected := `schema {
+	expected := `schema @onSchema {
   query: QueryType
   mutation: MutationType
 }
 
+"""
+This is a description
+of the ` + "`Foo`" + ` type.
+"""
 type Foo implements Bar {
   one: Type
-  two(argument: InputType!): Type
+  """
+  This is a description of the ` + "`two`" + ` field.
+  """
+  two(
+    """
+    This is a description of the ` + "`argument`" + ` argument.
+    """
+    argument: InputType!
+  ): Type
   three(argument: InputType, other: String): Int
   four(argument: String = "string"): String
   five(argument: [String] = ["string", "string"]): String
   six(argument: InputType = {key: "value"}): Type
 }
 
+type AnnotatedObject @onObject(arg: "value") {
+  annotatedField(arg: Type = "default" @onArg): Type @onField
+}
+
 interface Bar {
   one: Type
   four(argument: String = "string"): String
 }
 
+interface Baz implements Bar {
+  one: Type
+}
+
+interface AnnotatedInterface @onInterface {
+  annotatedField(arg: Type @onArg): Type @onField
+}
+
 union Feed = Story | Article | Advert
 
+union AnnotatedUnion @onUnion = A | B
+
 scalar CustomScalar
 
+scalar AnnotatedScalar @onScalar
+
 enum Site {
   DESKTOP
   MOBILE
 }
 
+enum AnnotatedEnum @onEnum {
+  ANNOTATED_VALUE @onEnumValue
+  OTHER_VALUE
+}
+
 input InputType {
   key: String!
   answer: Int = 42
 }
 
+input AnnotatedInput @onInputObjectType {
+  annotatedField: Type @onField
+}
+
 extend type Foo {
   seven(argument: [String]): Type
 }
 
+extend type Foo @onType {
+  eight: Type
+}
+
+extend type Foo @onType
+
+extend schema @onSchema
+
+extend schema @onSchema {
+  subscription: SubscriptionType
+}
+
+extend scalar CustomScalar @onScalar
+
+extend interface Bar implements Baz @onInterface {
+  two(argument: InputType!): Type
+}
+
+extend union Feed = Photo | Video
+
+extend union Feed @onUnion
+
+extend enum Site {
+  VR
+}
+
+extend enum Site @onEnum
+
+extend input InputType {
+  other: Float = 1.23e4
+}
+
+extend input InputType @onInputObject
+
 directive @skip(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 
 directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
@@ -99,3 +171,73 @@ directive @include(if: Boolean!) on FIELD | FRAGMENT_SPREAD | INLINE_FRAGMENT
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(results, expected))
 	}
 }
+
+func TestSchemaPrinter_PrintsComments(t *testing.T) {
+	query := `# the foo type
+type Foo implements Bar {
+  one: Type # trailing
+  two(
+    argument: InputType! # an argument
+    other: Int = 1
+  ): Type
+  # at the end of Foo
+}
+
+enum Site {
+  # the desktop
+  DESKTOP
+  MOBILE # the mobile
+}
+`
+	astDoc, err := parser.Parse(parser.ParseParams{
+		Source: query,
+		Options: parser.ParseOptions{
+			NoLocation:   true,
+			KeepComments: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	results := printer.PrintWithComments(astDoc)
+	expected := `# the foo type
+type Foo implements Bar {
+  one: Type # trailing
+  two(
+    argument: InputType! # an argument
+    other: Int = 1
+  ): Type
+  # at the end of Foo
+}
+
+enum Site {
+  # the desktop
+  DESKTOP
+  MOBILE # the mobile
+}
+`
+	if !reflect.DeepEqual(expected, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, results))
+	}
+}
+
+func TestSchemaPrinter_EscapesDescriptions(t *testing.T) {
+	query := `"say \"hi\" \\ \n\u0001"
+type Query {
+  "tab\there"
+  hello: String
+}
+`
+	astDoc := parse(t, query)
+	results := printer.Print(astDoc)
+	if !reflect.DeepEqual(query, results) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(query, results))
+	}
+	reparsed := parse(t, results.(string))
+	object := reparsed.Definitions[0].(*ast.ObjectDefinition)
+	descriptions := []string{object.Description.Value, object.Fields[0].Description.Value}
+	expected := []string{"say \"hi\" \\ \n\u0001", "tab\there"}
+	if !reflect.DeepEqual(expected, descriptions) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, descriptions))
+	}
+}
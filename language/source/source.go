@@ -1,12 +1,52 @@
 package source
 
+import (
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
 const (
 	name = "GraphQL"
 )
 
+// ColumnUnit is the unit the columns of locations in a source are counted in.
+type ColumnUnit int
+
+const (
+	// Characters, the default.
+	Characters ColumnUnit = iota
+	// UTF-16 code units, as language server clients count them by default.
+	UTF16
+	// UTF-8 bytes.
+	UTF8
+)
+
 type Source struct {
 	Body string
 	Name string
+	// ColumnUnit is the unit the columns of the locations of the source,
+	// errors included, are counted in.
+	ColumnUnit ColumnUnit
+
+	// the lines of the body, indexed on first use and again whenever the body
+	// changes
+	index     *lineIndex
+	indexLock sync.Mutex
+}
+
+// line is where a line of the body starts, as an offset in characters and a
+// position in bytes, and whether it only holds ASCII characters.
+type line struct {
+	offset   int
+	position int
+	ascii    bool
+}
+
+// lineIndex holds the lines of the body they were indexed from.
+type lineIndex struct {
+	body  string
+	lines []line
 }
 
 func NewSource(s *Source) *Source {
@@ -18,3 +58,101 @@ func NewSource(s *Source) *Source {
 	}
 	return s
 }
+
+// Location returns the line and column, both starting at 1, of the character
+// at the given offset of the body, the column being counted in the
+// ColumnUnit of the source.
+func (s *Source) Location(offset int) (int, int) {
+	return s.LocationIn(offset, s.ColumnUnit)
+}
+
+// LocationIn returns the line and column, both starting at 1, of the
+// character at the given offset of the body, the column being counted in the
+// given unit.
+func (s *Source) LocationIn(offset int, unit ColumnUnit) (int, int) {
+	lines := s.lines()
+	// the last line starting at or before the offset
+	index := sort.Search(len(lines), func(i int) bool {
+		return lines[i].offset > offset
+	}) - 1
+	if index < 0 {
+		index = 0
+	}
+	start := lines[index]
+	if unit == Characters || start.ascii {
+		return index + 1, offset - start.offset + 1
+	}
+	column := 1
+	position := start.position
+	for i := start.offset; i < offset && position < len(s.Body); i++ {
+		code, size := utf8.DecodeRuneInString(s.Body[position:])
+		position += size
+		switch {
+		case unit == UTF8:
+			column += size
+		case code >= 0x10000:
+			column += 2
+		default:
+			column++
+		}
+	}
+	return index + 1, column
+}
+
+// Line returns the text of the given line of the body, starting at 1,
+// without its line terminator.
+func (s *Source) Line(number int) string {
+	lines := s.lines()
+	if number < 1 || number > len(lines) {
+		return ""
+	}
+	start := lines[number-1].position
+	end := len(s.Body)
+	if number < len(lines) {
+		end = lines[number].position
+	}
+	text := s.Body[start:end]
+	for len(text) > 0 && (text[len(text)-1] == '\n' || text[len(text)-1] == '\r') {
+		text = text[:len(text)-1]
+	}
+	return text
+}
+
+// LineCount returns the number of lines of the body.
+func (s *Source) LineCount() int {
+	return len(s.lines())
+}
+
+// Returns the lines of the body, indexing them unless they were indexed for
+// the same body.
+func (s *Source) lines() []line {
+	s.indexLock.Lock()
+	defer s.indexLock.Unlock()
+	if s.index == nil || s.index.body != s.Body {
+		s.index = &lineIndex{
+			body:  s.Body,
+			lines: indexLines(s.Body),
+		}
+	}
+	return s.index.lines
+}
+
+// Indexes the lines of a body, which end with \r\n, \n or \r.
+func indexLines(body string) []line {
+	lines := []line{{ascii: true}}
+	offset := 0
+	for position := 0; position < len(body); {
+		code := body[position]
+		size := 1
+		if code >= utf8.RuneSelf {
+			_, size = utf8.DecodeRuneInString(body[position:])
+			lines[len(lines)-1].ascii = false
+		}
+		position += size
+		offset++
+		if code == '\n' || code == '\r' && (position == len(body) || body[position] != '\n') {
+			lines = append(lines, line{offset: offset, position: position, ascii: true})
+		}
+	}
+	return lines
+}
@@ -0,0 +1,72 @@
+package source
+
+import (
+	"testing"
+)
+
+func TestSourceLocatesCharactersInEachColumnUnit(t *testing.T) {
+	s := NewSource(&Source{Body: "{\r\n  é: \"😀\" a\rb\n}"})
+	tests := []struct {
+		Offset int
+		Unit   ColumnUnit
+		Line   int
+		Column int
+	}{
+		{0, Characters, 1, 1},
+		{1, Characters, 1, 2},
+		{2, Characters, 1, 3},
+		{3, Characters, 2, 1},
+		{5, UTF8, 2, 3},
+		{6, Characters, 2, 4},
+		{6, UTF16, 2, 4},
+		{6, UTF8, 2, 5},
+		{11, Characters, 2, 9},
+		{11, UTF16, 2, 10},
+		{11, UTF8, 2, 13},
+		{13, Characters, 2, 11},
+		{14, Characters, 3, 1},
+		{16, Characters, 4, 1},
+		{17, UTF16, 4, 2},
+	}
+	for _, test := range tests {
+		line, column := s.LocationIn(test.Offset, test.Unit)
+		if line != test.Line || column != test.Column {
+			t.Fatalf("unexpected location of %v in unit %v, expected: %v:%v, got: %v:%v",
+				test.Offset, test.Unit, test.Line, test.Column, line, column)
+		}
+	}
+}
+
+func TestSourceLocationUsesItsColumnUnit(t *testing.T) {
+	s := NewSource(&Source{Body: "\"😀\" a", ColumnUnit: UTF16})
+	if line, column := s.Location(4); line != 1 || column != 6 {
+		t.Fatalf("unexpected location, expected: 1:6, got: %v:%v", line, column)
+	}
+}
+
+func TestSourceReturnsItsLines(t *testing.T) {
+	s := NewSource(&Source{Body: "a\r\nb\rc\n\nd"})
+	expected := []string{"a", "b", "c", "", "d"}
+	if s.LineCount() != len(expected) {
+		t.Fatalf("unexpected line count, expected: %v, got: %v", len(expected), s.LineCount())
+	}
+	for i, text := range expected {
+		if s.Line(i+1) != text {
+			t.Fatalf("unexpected line %v, expected: %q, got: %q", i+1, text, s.Line(i+1))
+		}
+	}
+	if s.Line(0) != "" || s.Line(len(expected)+1) != "" {
+		t.Fatalf("expected no text for lines out of range")
+	}
+}
+
+func TestSourceLocationFollowsItsBody(t *testing.T) {
+	s := NewSource(&Source{Body: "a\nb"})
+	if line, column := s.Location(2); line != 2 || column != 1 {
+		t.Fatalf("unexpected location, expected: 2:1, got: %v:%v", line, column)
+	}
+	s.Body = "ab\nc"
+	if line, column := s.Location(2); line != 1 || column != 3 {
+		t.Fatalf("unexpected location, expected: 1:3, got: %v:%v", line, column)
+	}
+}
@@ -31,6 +31,7 @@ var QueryDocumentKeys = KeyMap{
 		"Variable",
 		"Type",
 		"DefaultValue",
+		"Directives",
 	},
 	"Variable":     []string{"Name"},
 	"SelectionSet": []string{"Selections"},
@@ -66,6 +67,7 @@ var QueryDocumentKeys = KeyMap{
 	"FloatValue":   []string{},
 	"StringValue":  []string{},
 	"BooleanValue": []string{},
+	"NullValue":    []string{},
 	"EnumValue":    []string{},
 	"ListValue":    []string{"Values"},
 	"ObjectValue":  []string{"Fields"},
@@ -83,46 +85,75 @@ var QueryDocumentKeys = KeyMap{
 	"List":    []string{"Type"},
 	"NonNull": []string{"Type"},
 
-	"SchemaDefinition":        []string{"OperationTypes"},
+	"SchemaDefinition":        []string{"Directives", "OperationTypes"},
 	"OperationTypeDefinition": []string{"Type"},
 
-	"ScalarDefinition": []string{"Name"},
+	"ScalarDefinition": []string{
+		"Description",
+		"Name",
+		"Directives",
+	},
 	"ObjectDefinition": []string{
+		"Description",
 		"Name",
 		"Interfaces",
+		"Directives",
 		"Fields",
 	},
 	"FieldDefinition": []string{
+		"Description",
 		"Name",
 		"Arguments",
 		"Type",
+		"Directives",
 	},
 	"InputValueDefinition": []string{
+		"Description",
 		"Name",
 		"Type",
 		"DefaultValue",
+		"Directives",
 	},
 	"InterfaceDefinition": []string{
+		"Description",
 		"Name",
+		"Interfaces",
+		"Directives",
 		"Fields",
 	},
 	"UnionDefinition": []string{
+		"Description",
 		"Name",
+		"Directives",
 		"Types",
 	},
 	"EnumDefinition": []string{
+		"Description",
 		"Name",
+		"Directives",
 		"Values",
 	},
-	"EnumValueDefinition": []string{"Name"},
+	"EnumValueDefinition": []string{
+		"Description",
+		"Name",
+		"Directives",
+	},
 	"InputObjectDefinition": []string{
+		"Description",
 		"Name",
+		"Directives",
 		"Fields",
 	},
 
-	"TypeExtensionDefinition": []string{"Definition"},
+	"TypeExtensionDefinition":        []string{"Definition"},
+	"SchemaExtensionDefinition":      []string{"Definition"},
+	"ScalarExtensionDefinition":      []string{"Definition"},
+	"InterfaceExtensionDefinition":   []string{"Definition"},
+	"UnionExtensionDefinition":       []string{"Definition"},
+	"EnumExtensionDefinition":        []string{"Definition"},
+	"InputObjectExtensionDefinition": []string{"Definition"},
 
-	"DirectiveDefinition": []string{"Name", "Arguments", "Locations"},
+	"DirectiveDefinition": []string{"Description", "Name", "Arguments", "Locations"},
 }
 
 type stack struct {
@@ -781,6 +781,12 @@ func TestVisitor_VisitsKitchenSink(t *testing.T) {
 		[]interface{}{"enter", "BooleanValue", "Value", "Argument"},
 		[]interface{}{"leave", "BooleanValue", "Value", "Argument"},
 		[]interface{}{"leave", "Argument", 1, nil},
+		[]interface{}{"enter", "Argument", 2, nil},
+		[]interface{}{"enter", "Name", "Name", "Argument"},
+		[]interface{}{"leave", "Name", "Name", "Argument"},
+		[]interface{}{"enter", "NullValue", "Value", "Argument"},
+		[]interface{}{"leave", "NullValue", "Value", "Argument"},
+		[]interface{}{"leave", "Argument", 2, nil},
 		[]interface{}{"leave", "Field", 0, nil},
 		[]interface{}{"enter", "Field", 1, nil},
 		[]interface{}{"enter", "Name", "Name", "Field"},
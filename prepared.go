@@ -0,0 +1,374 @@
+package graphql
+
+import (
+	"sync"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"golang.org/x/net/context"
+)
+
+// PreparedQuery is an operation compiled against a schema, to be executed
+// repeatedly with different variables and root values.
+//
+// The work that does not depend on variables (collecting the fields of each
+// selection set for each runtime type, matching fragment type conditions,
+// looking up field definitions and coercing literal arguments) is done once
+// and shared by every execution. A PreparedQuery is safe for concurrent use.
+type PreparedQuery struct {
+	schema    Schema
+	operation *ast.OperationDefinition
+	fragments map[string]ast.Definition
+	plan      *executionPlan
+}
+
+// PreparedParams Params for PreparedQuery.Execute()
+type PreparedParams struct {
+	Root interface{}
+	Args map[string]interface{}
+
+	// Context may be provided to pass application-specific per-request
+	// information to resolve functions.
+	Context context.Context
+}
+
+// Prepare compiles the named operation of the document into a PreparedQuery.
+// The operation name can be omitted if the document contains only one
+// operation. Like Execute, Prepare expects a document that has already been
+// validated against the schema.
+func Prepare(schema Schema, AST *ast.Document, operationName string) (*PreparedQuery, error) {
+	if AST == nil {
+		return nil, gqlerrors.NewFormattedError("Must provide document")
+	}
+	operation, fragments, err := getOperationAndFragments(AST, operationName)
+	if err != nil {
+		return nil, err
+	}
+	return &PreparedQuery{
+		schema:    schema,
+		operation: operation,
+		fragments: fragments,
+		plan:      newExecutionPlan(operation, fragments),
+	}, nil
+}
+
+// Execute executes the prepared operation with the given root value and variables.
+func (q *PreparedQuery) Execute(p PreparedParams) *Result {
+	eCtx, err := q.executionContext(p)
+	if err != nil {
+		return &Result{
+			Errors: gqlerrors.FormatErrors(err),
+		}
+	}
+	return executeRecoveringPanics(eCtx, p.Root)
+}
+
+// ExecuteIncremental executes the prepared operation like Execute, but
+// delivers the data of its deferred fragments and streamed list items in
+// subsequent payloads, as ExecuteIncremental does.
+func (q *PreparedQuery) ExecuteIncremental(p PreparedParams) chan *IncrementalResult {
+	eCtx, err := q.executionContext(p)
+	if err != nil {
+		return sendIncrementalResult(&IncrementalResult{
+			Errors: gqlerrors.FormatErrors(err),
+		})
+	}
+	return executeIncremental(eCtx, p.Root, p.Context)
+}
+
+func (q *PreparedQuery) executionContext(p PreparedParams) (*ExecutionContext, error) {
+	variableValues, err := getVariableValues(q.schema, q.operation.GetVariableDefinitions(), p.Args)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecutionContext{
+		Schema:         q.schema,
+		Fragments:      q.fragments,
+		Root:           p.Root,
+		Operation:      q.operation,
+		VariableValues: variableValues,
+		Context:        p.Context,
+		errMutex:       sync.RWMutex{},
+		plan:           q.plan,
+		directiveArgs:  &directiveArgsCache{},
+	}, nil
+}
+
+// executionPlan memoizes the variable-independent parts of executing an operation.
+// Fields are collected anew when executing incrementally, as the fragments
+// using @defer are then collected apart.
+type executionPlan struct {
+	// static is true when no @skip or @include directive of the document
+	// depends on a variable, in which case the fields collected for a
+	// selection set only depend on the runtime type.
+	static bool
+
+	rootFieldsMutex sync.RWMutex
+	rootFieldsCache map[*Object]map[string][]*ast.Field
+
+	subFieldsMutex sync.RWMutex
+	subFieldsCache map[subFieldsKey]*subFieldsEntry
+
+	fragmentMatchesMutex sync.RWMutex
+	fragmentMatchesCache map[fragmentMatchKey]bool
+
+	fieldDefsMutex sync.RWMutex
+	fieldDefsCache map[fieldDefKey]*FieldDefinition
+
+	argumentsMutex sync.RWMutex
+	argumentsCache map[argumentsKey]map[string]interface{}
+
+	// directiveArgs holds the arguments of the directives used on fields
+	// that do not depend on variables.
+	directiveArgs directiveArgsCache
+}
+
+// subFieldsKey identifies a group of fields merged under one response name.
+// Groups sharing a key are told apart by comparing their fields.
+type subFieldsKey struct {
+	runtimeType *Object
+	first       *ast.Field
+	length      int
+}
+
+type subFieldsEntry struct {
+	fieldASTs []*ast.Field
+	fields    map[string][]*ast.Field
+}
+
+type fragmentMatchKey struct {
+	fragment ast.Node
+	ttype    *Object
+}
+
+type fieldDefKey struct {
+	parentType *Object
+	fieldName  string
+}
+
+type argumentsKey struct {
+	fieldDef *FieldDefinition
+	fieldAST *ast.Field
+}
+
+func newExecutionPlan(operation *ast.OperationDefinition, fragments map[string]ast.Definition) *executionPlan {
+	static := !selectionSetHasVariableDirectives(operation.GetSelectionSet())
+	for _, fragment := range fragments {
+		if !static {
+			break
+		}
+		static = !selectionSetHasVariableDirectives(fragment.GetSelectionSet())
+	}
+	return &executionPlan{
+		static:               static,
+		rootFieldsCache:      map[*Object]map[string][]*ast.Field{},
+		subFieldsCache:       map[subFieldsKey]*subFieldsEntry{},
+		fragmentMatchesCache: map[fragmentMatchKey]bool{},
+		fieldDefsCache:       map[fieldDefKey]*FieldDefinition{},
+		argumentsCache:       map[argumentsKey]map[string]interface{}{},
+	}
+}
+
+func (plan *executionPlan) rootFields(eCtx *ExecutionContext, operationType *Object, selectionSet *ast.SelectionSet) map[string][]*ast.Field {
+	if !plan.static {
+		return collectFields(CollectFieldsParams{
+			ExeContext:   eCtx,
+			RuntimeType:  operationType,
+			SelectionSet: selectionSet,
+		})
+	}
+
+	plan.rootFieldsMutex.RLock()
+	fields, ok := plan.rootFieldsCache[operationType]
+	plan.rootFieldsMutex.RUnlock()
+	if ok {
+		return fields
+	}
+
+	fields = collectFields(CollectFieldsParams{
+		ExeContext:   eCtx,
+		RuntimeType:  operationType,
+		SelectionSet: selectionSet,
+	})
+	plan.rootFieldsMutex.Lock()
+	plan.rootFieldsCache[operationType] = fields
+	plan.rootFieldsMutex.Unlock()
+	return fields
+}
+
+func (plan *executionPlan) subFields(eCtx *ExecutionContext, returnType *Object, fieldASTs []*ast.Field) map[string][]*ast.Field {
+	if !plan.static || len(fieldASTs) == 0 {
+		return collectSubFields(eCtx, returnType, fieldASTs, nil)
+	}
+
+	key := subFieldsKey{returnType, fieldASTs[0], len(fieldASTs)}
+	plan.subFieldsMutex.RLock()
+	entry, ok := plan.subFieldsCache[key]
+	plan.subFieldsMutex.RUnlock()
+	if ok && sameFieldASTs(entry.fieldASTs, fieldASTs) {
+		return entry.fields
+	}
+
+	fields := collectSubFields(eCtx, returnType, fieldASTs, nil)
+	if !ok {
+		plan.subFieldsMutex.Lock()
+		plan.subFieldsCache[key] = &subFieldsEntry{
+			fieldASTs: append([]*ast.Field{}, fieldASTs...),
+			fields:    fields,
+		}
+		plan.subFieldsMutex.Unlock()
+	}
+	return fields
+}
+
+func (plan *executionPlan) fragmentConditionMatches(eCtx *ExecutionContext, fragment ast.Node, ttype *Object) bool {
+	key := fragmentMatchKey{fragment, ttype}
+	plan.fragmentMatchesMutex.RLock()
+	matches, ok := plan.fragmentMatchesCache[key]
+	plan.fragmentMatchesMutex.RUnlock()
+	if ok {
+		return matches
+	}
+
+	matches = fragmentConditionMatches(eCtx, fragment, ttype)
+	plan.fragmentMatchesMutex.Lock()
+	plan.fragmentMatchesCache[key] = matches
+	plan.fragmentMatchesMutex.Unlock()
+	return matches
+}
+
+func (plan *executionPlan) fieldDef(schema Schema, parentType *Object, fieldName string) *FieldDefinition {
+	key := fieldDefKey{parentType, fieldName}
+	plan.fieldDefsMutex.RLock()
+	fieldDef, ok := plan.fieldDefsCache[key]
+	plan.fieldDefsMutex.RUnlock()
+	if ok {
+		return fieldDef
+	}
+
+	fieldDef = getFieldDef(schema, parentType, fieldName)
+	plan.fieldDefsMutex.Lock()
+	plan.fieldDefsCache[key] = fieldDef
+	plan.fieldDefsMutex.Unlock()
+	return fieldDef
+}
+
+// argumentValues returns the arguments of a field, coercing literal-only
+// arguments once. Each call returns a fresh copy of them, input objects and
+// lists included, as resolvers may modify it.
+func (plan *executionPlan) argumentValues(eCtx *ExecutionContext, fieldDef *FieldDefinition, fieldAST *ast.Field) map[string]interface{} {
+	if argumentsHaveVariables(fieldAST.Arguments) {
+		args, _ := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+		return args
+	}
+
+	key := argumentsKey{fieldDef, fieldAST}
+	plan.argumentsMutex.RLock()
+	cached, ok := plan.argumentsCache[key]
+	plan.argumentsMutex.RUnlock()
+	if !ok {
+		cached, _ = getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+		plan.argumentsMutex.Lock()
+		plan.argumentsCache[key] = cached
+		plan.argumentsMutex.Unlock()
+	}
+
+	return copyValue(cached).(map[string]interface{})
+}
+
+// copyValue returns a deep copy of a coerced input value, whose input objects
+// and lists are maps and slices.
+func copyValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for name, field := range value {
+			copied[name] = copyValue(field)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, item := range value {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	}
+	return value
+}
+
+func sameFieldASTs(a, b []*ast.Field) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// selectionSetHasVariableDirectives determines if any directive within the
+// selection set, including nested ones, depends on a variable.
+func selectionSetHasVariableDirectives(selectionSet *ast.SelectionSet) bool {
+	if selectionSet == nil {
+		return false
+	}
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if directivesHaveVariables(selection.Directives) ||
+				selectionSetHasVariableDirectives(selection.SelectionSet) {
+				return true
+			}
+		case *ast.InlineFragment:
+			if directivesHaveVariables(selection.Directives) ||
+				selectionSetHasVariableDirectives(selection.SelectionSet) {
+				return true
+			}
+		case *ast.FragmentSpread:
+			if directivesHaveVariables(selection.Directives) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func directivesHaveVariables(directives []*ast.Directive) bool {
+	for _, directive := range directives {
+		if directive != nil && argumentsHaveVariables(directive.Arguments) {
+			return true
+		}
+	}
+	return false
+}
+
+func argumentsHaveVariables(arguments []*ast.Argument) bool {
+	for _, argument := range arguments {
+		if argument != nil && valueHasVariables(argument.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func valueHasVariables(value ast.Value) bool {
+	switch value := value.(type) {
+	case *ast.Variable:
+		return true
+	case *ast.ListValue:
+		for _, item := range value.Values {
+			if valueHasVariables(item) {
+				return true
+			}
+		}
+	case *ast.ObjectValue:
+		for _, field := range value.Fields {
+			if field != nil && valueHasVariables(field.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
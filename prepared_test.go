@@ -0,0 +1,278 @@
+package graphql_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func prepare(t *testing.T, query string, operationName string) *graphql.PreparedQuery {
+	AST := parseDocument(t, query)
+	prepared, err := graphql.Prepare(testutil.StarWarsSchema, AST, operationName)
+	if err != nil {
+		t.Fatalf("unexpected error preparing query: %v", err)
+	}
+	return prepared
+}
+
+func parseDocument(t testing.TB, query string) *ast.Document {
+	AST, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: query,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error parsing query: %v", err)
+	}
+	return AST
+}
+
+func TestPreparedQuery_MatchesExecute(t *testing.T) {
+	queries := []string{
+		`
+		query HeroNameAndFriendsQuery {
+			hero {
+				id
+				name
+				friends {
+					name
+				}
+			}
+		}
+		`,
+		`
+		query UseFragment {
+			luke: human(id: "1000") {
+				...HumanFragment
+			}
+			leia: human(id: "1003") {
+				...HumanFragment
+			}
+		}
+		fragment HumanFragment on Human {
+			name
+			homePlanet
+		}
+		`,
+		`
+		query CheckTypeOfLuke {
+			hero(episode: EMPIRE) {
+				__typename
+				name
+				... on Droid {
+					primaryFunction
+				}
+				... on Human {
+					homePlanet
+				}
+			}
+		}
+		`,
+	}
+	for _, query := range queries {
+		expected := graphql.Do(graphql.Params{
+			Schema:        testutil.StarWarsSchema,
+			RequestString: query,
+		})
+		prepared := prepare(t, query, "")
+		// executing twice exercises both the cold and the memoized plan
+		for i := 0; i < 2; i++ {
+			result := prepared.Execute(graphql.PreparedParams{})
+			if !reflect.DeepEqual(expected, result) {
+				t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+			}
+		}
+	}
+}
+
+func TestPreparedQuery_ExecutesWithDifferentVariables(t *testing.T) {
+	prepared := prepare(t, `
+		query FetchSomeIDQuery($someId: String!, $withFriends: Boolean!) {
+			human(id: $someId) {
+				name
+				friends @include(if: $withFriends) {
+					name
+				}
+			}
+		}
+	`, "")
+
+	tests := []struct {
+		Args     map[string]interface{}
+		Expected *graphql.Result
+	}{
+		{
+			Args: map[string]interface{}{"someId": "1002", "withFriends": false},
+			Expected: &graphql.Result{
+				Data: map[string]interface{}{
+					"human": map[string]interface{}{
+						"name": "Han Solo",
+					},
+				},
+			},
+		},
+		{
+			Args: map[string]interface{}{"someId": "1003", "withFriends": true},
+			Expected: &graphql.Result{
+				Data: map[string]interface{}{
+					"human": map[string]interface{}{
+						"name": "Leia Organa",
+						"friends": []interface{}{
+							map[string]interface{}{"name": "Luke Skywalker"},
+							map[string]interface{}{"name": "Han Solo"},
+							map[string]interface{}{"name": "C-3PO"},
+							map[string]interface{}{"name": "R2-D2"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Args: map[string]interface{}{"someId": "1002", "withFriends": false},
+			Expected: &graphql.Result{
+				Data: map[string]interface{}{
+					"human": map[string]interface{}{
+						"name": "Han Solo",
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		result := prepared.Execute(graphql.PreparedParams{
+			Args: test.Args,
+		})
+		if !reflect.DeepEqual(test.Expected, result) {
+			t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(test.Expected, result))
+		}
+	}
+}
+
+func TestPreparedQuery_ReportsVariableErrors(t *testing.T) {
+	prepared := prepare(t, `
+		query FetchSomeIDQuery($someId: String!) {
+			human(id: $someId) {
+				name
+			}
+		}
+	`, "")
+	result := prepared.Execute(graphql.PreparedParams{})
+	if result.Data != nil || len(result.Errors) != 1 {
+		t.Fatalf("expected a single variable error, got: %v", result)
+	}
+}
+
+func TestPrepare_RequiresKnownOperation(t *testing.T) {
+	AST := parseDocument(t, `
+		query A { hero { name } }
+		query B { hero { id } }
+	`)
+	if _, err := graphql.Prepare(testutil.StarWarsSchema, AST, ""); err == nil {
+		t.Fatalf("expected an error without operation name")
+	}
+	if _, err := graphql.Prepare(testutil.StarWarsSchema, AST, "C"); err == nil {
+		t.Fatalf("expected an error for unknown operation name")
+	}
+	if _, err := graphql.Prepare(testutil.StarWarsSchema, AST, "B"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPreparedQuery_IsSafeForConcurrentUse(t *testing.T) {
+	query := `
+		query FetchSomeIDQuery($someId: String!) {
+			human(id: $someId) {
+				name
+				friends {
+					name
+					... on Droid {
+						primaryFunction
+					}
+				}
+			}
+		}
+	`
+	prepared := prepare(t, query, "")
+	ids := []string{"1000", "1001", "1002", "1003"}
+	expected := map[string]*graphql.Result{}
+	for _, id := range ids {
+		expected[id] = graphql.Do(graphql.Params{
+			Schema:         testutil.StarWarsSchema,
+			RequestString:  query,
+			VariableValues: map[string]interface{}{"someId": id},
+		})
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*graphql.Result, 4*len(ids))
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = prepared.Execute(graphql.PreparedParams{
+				Args: map[string]interface{}{"someId": ids[i%len(ids)]},
+			})
+		}(i)
+	}
+	wg.Wait()
+	for i, result := range results {
+		if want := expected[ids[i%len(ids)]]; !reflect.DeepEqual(want, result) {
+			t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(want, result))
+		}
+	}
+}
+
+func TestPreparedQuery_CopiesNestedArguments(t *testing.T) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"first": &graphql.Field{
+					Type: graphql.String,
+					Args: graphql.FieldConfigArgument{
+						"filter": &graphql.ArgumentConfig{
+							Type: graphql.NewInputObject(graphql.InputObjectConfig{
+								Name: "Filter",
+								Fields: graphql.InputObjectConfigFieldMap{
+									"names": &graphql.InputObjectFieldConfig{
+										Type: graphql.NewList(graphql.String),
+									},
+								},
+							}),
+						},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						names := p.Args["filter"].(map[string]interface{})["names"].([]interface{})
+						first := names[0]
+						names[0] = "modified"
+						return first, nil
+					},
+				},
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prepared, err := graphql.Prepare(schema, parseDocument(t, `{ first(filter: { names: ["a", "b"] }) }`), "")
+	if err != nil {
+		t.Fatalf("unexpected error preparing query: %v", err)
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"first": "a",
+		},
+	}
+	for i := 0; i < 2; i++ {
+		result := prepared.Execute(graphql.PreparedParams{})
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("wrong result, graphql result diff: %v", testutil.Diff(expected, result))
+		}
+	}
+}
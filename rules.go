@@ -505,7 +505,7 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 							return action, result
 						}
 
-						candidateLocation := getLocationForAppliedNode(appliedTo)
+						candidateLocation := getLocationForAppliedNode(appliedTo, p.Ancestors[:len(p.Ancestors)-1])
 
 						directiveHasLocation := false
 						for _, loc := range directiveDef.Locations {
@@ -527,6 +527,19 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 								MisplaceDirectiveMessage(nodeName, candidateLocation),
 								[]ast.Node{node},
 							)
+						} else if directiveDef == StreamDirective && context.FieldDef() != nil {
+							// @stream only applies to list fields
+							fieldType := context.FieldDef().Type
+							if nonNull, ok := fieldType.(*NonNull); ok {
+								fieldType = nonNull.OfType
+							}
+							if _, ok := fieldType.(*List); !ok {
+								reportError(
+									context,
+									fmt.Sprintf(`Directive "%v" cannot be used on non-list field "%v".`, nodeName, context.FieldDef().Name),
+									[]ast.Node{node},
+								)
+							}
 						}
 
 					}
@@ -540,7 +553,7 @@ func KnownDirectivesRule(context *ValidationContext) *ValidationRuleInstance {
 	}
 }
 
-func getLocationForAppliedNode(appliedTo ast.Node) string {
+func getLocationForAppliedNode(appliedTo ast.Node, ancestors []ast.Node) string {
 	kind := appliedTo.GetKind()
 	if kind == kinds.OperationDefinition {
 		appliedTo, _ := appliedTo.(*ast.OperationDefinition)
@@ -566,6 +579,43 @@ func getLocationForAppliedNode(appliedTo ast.Node) string {
 	if kind == kinds.FragmentDefinition {
 		return DirectiveLocationFragmentDefinition
 	}
+	if kind == kinds.VariableDefinition {
+		return DirectiveLocationVariableDefinition
+	}
+	if kind == kinds.SchemaDefinition {
+		return DirectiveLocationSchema
+	}
+	if kind == kinds.ScalarDefinition {
+		return DirectiveLocationScalar
+	}
+	if kind == kinds.ObjectDefinition {
+		return DirectiveLocationObject
+	}
+	if kind == kinds.FieldDefinition {
+		return DirectiveLocationFieldDefinition
+	}
+	if kind == kinds.InterfaceDefinition {
+		return DirectiveLocationInterface
+	}
+	if kind == kinds.UnionDefinition {
+		return DirectiveLocationUnion
+	}
+	if kind == kinds.EnumDefinition {
+		return DirectiveLocationEnum
+	}
+	if kind == kinds.EnumValueDefinition {
+		return DirectiveLocationEnumValue
+	}
+	if kind == kinds.InputObjectDefinition {
+		return DirectiveLocationInputObject
+	}
+	if kind == kinds.InputValueDefinition {
+		// input values are either arguments or input object fields
+		if len(ancestors) > 0 && ancestors[len(ancestors)-1].GetKind() == kinds.InputObjectDefinition {
+			return DirectiveLocationInputFieldDefinition
+		}
+		return DirectiveLocationArgumentDefinition
+	}
 	return ""
 }
 
@@ -2119,7 +2169,7 @@ func VariablesInAllowedPositionRule(context *ValidationContext) *ValidationRuleI
 func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 	// A value must be provided if the type is non-null.
 	if ttype, ok := ttype.(*NonNull); ok {
-		if valueAST == nil {
+		if valueAST == nil || valueAST.GetKind() == kinds.NullValue {
 			if ttype.OfType.Name() != "" {
 				return false, []string{fmt.Sprintf(`Expected "%v!", found null.`, ttype.OfType.Name())}
 			}
@@ -2129,7 +2179,7 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 		return isValidLiteralValue(ofType, valueAST)
 	}
 
-	if valueAST == nil {
+	if valueAST == nil || valueAST.GetKind() == kinds.NullValue {
 		return true, nil
 	}
 
@@ -2198,7 +2248,11 @@ func isValidLiteralValue(ttype Input, valueAST ast.Value) (bool, []string) {
 	}
 
 	if ttype, ok := ttype.(*Scalar); ok {
-		if isNullish(ttype.ParseLiteral(valueAST)) {
+		parsed, err := ttype.ParseLiteralWithError(valueAST)
+		if err != nil {
+			return false, []string{err.Error()}
+		}
+		if isNullish(parsed) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found %v.`, ttype.Name(), printer.Print(valueAST))}
 		}
 	}
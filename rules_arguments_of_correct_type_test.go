@@ -80,6 +80,34 @@ func TestValidate_ArgValuesOfCorrectType_ValidValue_GoodEnumValue(t *testing.T)
         }
     `)
 }
+func TestValidate_ArgValuesOfCorrectType_ValidValue_NullIntoNullableType(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            intArgField(intArg: null)
+          }
+          complicatedArgs {
+            complexArgField(complexArg: { requiredField: true, intField: null })
+          }
+        }
+    `)
+}
+
+func TestValidate_ArgValuesOfCorrectType_InvalidNonNullableValue_NullIntoNonNullableType(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
+        {
+          complicatedArgs {
+            multipleReqs(req1: null)
+          }
+        }
+        `,
+		[]gqlerrors.FormattedError{
+			testutil.RuleError(
+				"Argument \"req1\" has invalid value null.\nExpected \"Int!\", found null.",
+				4, 32,
+			),
+		})
+}
 
 func TestValidate_ArgValuesOfCorrectType_InvalidStringValues_IntIntoString(t *testing.T) {
 	testutil.ExpectFailsRule(t, graphql.ArgumentsOfCorrectTypeRule, `
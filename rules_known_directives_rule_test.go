@@ -84,3 +84,84 @@ func TestValidate_KnownDirectives_WithMisplacedDirectives(t *testing.T) {
 		testutil.RuleError(`Directive "operationOnly" may not be used on FRAGMENT_SPREAD.`, 4, 17),
 	})
 }
+func TestValidate_KnownDirectives_WithWellPlacedVariableDefinitionDirective(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.KnownDirectivesRule, `
+      query Foo($var: Boolean @onVariableDefinition) {
+        name @include(if: $var)
+      }
+    `)
+}
+func TestValidate_KnownDirectives_WithMisplacedVariableDefinitionDirective(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.KnownDirectivesRule, `
+      query Foo($var: Boolean @include(if: true)) {
+        name
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "include" may not be used on VARIABLE_DEFINITION.`, 2, 31),
+	})
+}
+func TestValidate_KnownDirectives_WithinSchemaLanguage_WithWellPlacedDirectives(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.KnownDirectivesRule, `
+      schema @onSchema {
+        query: MyQuery
+      }
+
+      extend type MyObj @onObject {
+        myField(myArg: Int @onArgumentDefinition): String
+      }
+
+      input MyInput {
+        myField: Int @onInputFieldDefinition
+      }
+    `)
+}
+func TestValidate_KnownDirectives_WithinSchemaLanguage_WithMisplacedDirectives(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.KnownDirectivesRule, `
+      schema @onObject {
+        query: MyQuery
+      }
+
+      extend type MyObj @onSchema {
+        myField(myArg: Int @onInputFieldDefinition): String
+      }
+
+      input MyInput {
+        myField: Int @onArgumentDefinition
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "onObject" may not be used on SCHEMA.`, 2, 14),
+		testutil.RuleError(`Directive "onSchema" may not be used on OBJECT.`, 6, 25),
+		testutil.RuleError(`Directive "onInputFieldDefinition" may not be used on ARGUMENT_DEFINITION.`, 7, 28),
+		testutil.RuleError(`Directive "onArgumentDefinition" may not be used on INPUT_FIELD_DEFINITION.`, 11, 22),
+	})
+}
+func TestValidate_KnownDirectives_WithDeferAndStreamDirectives(t *testing.T) {
+	testutil.ExpectPassesRule(t, graphql.KnownDirectivesRule, `
+      {
+        human {
+          pets @stream(initialCount: 1) {
+            name
+          }
+          ... on Human @defer(label: "name") {
+            name
+          }
+          ...HumanFields @defer
+        }
+      }
+      fragment HumanFields on Human {
+        iq
+      }
+    `)
+}
+func TestValidate_KnownDirectives_WithStreamOnNonListField(t *testing.T) {
+	testutil.ExpectFailsRule(t, graphql.KnownDirectivesRule, `
+      {
+        human @stream {
+          name @defer
+        }
+      }
+    `, []gqlerrors.FormattedError{
+		testutil.RuleError(`Directive "stream" cannot be used on non-list field "human".`, 3, 15),
+		testutil.RuleError(`Directive "defer" may not be used on FIELD.`, 4, 16),
+	})
+}
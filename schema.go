@@ -3,6 +3,7 @@ package graphql
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 )
 
 type SchemaConfig struct {
@@ -26,6 +27,9 @@ type TypeMap map[string]Type
 //      Subscription: MyAppSubscriptionRootType,
 //    });
 type Schema struct {
+	// id identifies the schema, and every copy of it, in document caches.
+	id uint64
+
 	typeMap    TypeMap
 	directives []*Directive
 
@@ -39,10 +43,14 @@ type Schema struct {
 	typeMapMutex *sync.RWMutex
 }
 
+// schemaCount is the number of schemas created, from which their ids are drawn.
+var schemaCount uint64
+
 func NewSchema(config SchemaConfig) (Schema, error) {
 	var err error
 
 	schema := Schema{
+		id:              atomic.AddUint64(&schemaCount, 1),
 		implementations: map[string][]*Object{},
 		possibleTypeMap: map[string]map[string]bool{},
 		typeMapMutex:    &sync.RWMutex{},
@@ -65,12 +73,18 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 	schema.mutationType = config.Mutation
 	schema.subscriptionType = config.Subscription
 
-	// Provide `@include() and `@skip()` directives by default.
-	schema.directives = config.Directives
-	if len(schema.directives) == 0 {
-		schema.directives = []*Directive{
-			IncludeDirective,
-			SkipDirective,
+	// Always provide the specified `@include()`, `@skip()` and `@deprecated()`
+	// directives, unless directives of the same names are given.
+	schema.directives = append([]*Directive{}, config.Directives...)
+	for _, specified := range SpecifiedDirectives {
+		given := false
+		for _, dir := range config.Directives {
+			if dir != nil && dir.Name == specified.Name {
+				given = true
+			}
+		}
+		if !given {
+			schema.directives = append(schema.directives, specified)
 		}
 	}
 	// Ensure directive definitions are error-free
@@ -139,6 +153,11 @@ func NewSchema(config SchemaConfig) (Schema, error) {
 		}
 	}
 
+	// Enforce valid uses of directives on type system definitions
+	if err := assertValidAppliedDirectives(&schema); err != nil {
+		return schema, err
+	}
+
 	return schema, nil
 }
 
@@ -0,0 +1,345 @@
+package graphql
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/printer"
+)
+
+// PrintSchema prints a schema in the schema definition language, leaving out
+// the directives and scalars defined by the GraphQL specification and the
+// introspection types. Fields, arguments, enum values and input fields are
+// printed sorted by name, as a schema does not keep their order.
+//
+// The directives used on type system definitions are printed, and elements
+// deprecated through DeprecationReason are printed using @deprecated.
+func PrintSchema(schema Schema) string {
+	definitions := []ast.Node{}
+	if definition := schemaDefinitionAST(schema); definition != nil {
+		definitions = append(definitions, definition)
+	}
+	for _, directive := range schema.Directives() {
+		if isSpecifiedDirective(directive) {
+			continue
+		}
+		definitions = append(definitions, directiveDefinitionAST(schema, directive))
+	}
+	names := []string{}
+	for name, ttype := range schema.TypeMap() {
+		if strings.HasPrefix(name, "__") || ttype == specifiedScalars[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if definition := typeDefinitionAST(schema, schema.Type(name)); definition != nil {
+			definitions = append(definitions, definition)
+		}
+	}
+	return printer.Print(ast.NewDocument(&ast.Document{
+		Definitions: definitions,
+	})).(string)
+}
+
+func isSpecifiedDirective(directive *Directive) bool {
+	for _, specified := range SpecifiedDirectives {
+		if directive == specified {
+			return true
+		}
+	}
+	return false
+}
+
+// schemaDefinitionAST returns the schema definition of a schema, or nil when
+// its root types have the default names.
+func schemaDefinitionAST(schema Schema) *ast.SchemaDefinition {
+	rootTypes := []struct {
+		operation string
+		ttype     *Object
+		name      string
+	}{
+		{ast.OperationTypeQuery, schema.QueryType(), "Query"},
+		{ast.OperationTypeMutation, schema.MutationType(), "Mutation"},
+		{ast.OperationTypeSubscription, schema.SubscriptionType(), "Subscription"},
+	}
+	operationTypes := []*ast.OperationTypeDefinition{}
+	defaultNames := true
+	for _, rootType := range rootTypes {
+		if rootType.ttype == nil {
+			continue
+		}
+		if rootType.ttype.Name() != rootType.name {
+			defaultNames = false
+		}
+		operationTypes = append(operationTypes, ast.NewOperationTypeDefinition(&ast.OperationTypeDefinition{
+			Operation: rootType.operation,
+			Type:      namedAST(rootType.ttype.Name()),
+		}))
+	}
+	if defaultNames {
+		return nil
+	}
+	return ast.NewSchemaDefinition(&ast.SchemaDefinition{
+		OperationTypes: operationTypes,
+	})
+}
+
+func directiveDefinitionAST(schema Schema, directive *Directive) *ast.DirectiveDefinition {
+	locations := []*ast.Name{}
+	for _, location := range directive.Locations {
+		locations = append(locations, nameAST(location))
+	}
+	return ast.NewDirectiveDefinition(&ast.DirectiveDefinition{
+		Description: descriptionAST(directive.Description),
+		Name:        nameAST(directive.Name),
+		Arguments:   argumentDefinitionASTs(schema, directive.Args),
+		Locations:   locations,
+	})
+}
+
+func typeDefinitionAST(schema Schema, ttype Type) ast.Node {
+	switch ttype := ttype.(type) {
+	case *Scalar:
+		return ast.NewScalarDefinition(&ast.ScalarDefinition{
+			Description: descriptionAST(ttype.Description()),
+			Name:        nameAST(ttype.Name()),
+			Directives:  directiveASTs(schema, ttype.Directives(), ""),
+		})
+	case *Object:
+		interfaces := []*ast.Named{}
+		for _, iface := range ttype.Interfaces() {
+			interfaces = append(interfaces, namedAST(iface.Name()))
+		}
+		return ast.NewObjectDefinition(&ast.ObjectDefinition{
+			Description: descriptionAST(ttype.Description()),
+			Name:        nameAST(ttype.Name()),
+			Interfaces:  interfaces,
+			Directives:  directiveASTs(schema, ttype.Directives(), ""),
+			Fields:      fieldDefinitionASTs(schema, ttype.Fields()),
+		})
+	case *Interface:
+		return ast.NewInterfaceDefinition(&ast.InterfaceDefinition{
+			Description: descriptionAST(ttype.Description()),
+			Name:        nameAST(ttype.Name()),
+			Directives:  directiveASTs(schema, ttype.Directives(), ""),
+			Fields:      fieldDefinitionASTs(schema, ttype.Fields()),
+		})
+	case *Union:
+		types := []*ast.Named{}
+		for _, object := range ttype.Types() {
+			types = append(types, namedAST(object.Name()))
+		}
+		return ast.NewUnionDefinition(&ast.UnionDefinition{
+			Description: descriptionAST(ttype.Description()),
+			Name:        nameAST(ttype.Name()),
+			Directives:  directiveASTs(schema, ttype.Directives(), ""),
+			Types:       types,
+		})
+	case *Enum:
+		values := append([]*EnumValueDefinition{}, ttype.Values()...)
+		sort.Sort(enumValuesByName(values))
+		valueASTs := []*ast.EnumValueDefinition{}
+		for _, value := range values {
+			valueASTs = append(valueASTs, ast.NewEnumValueDefinition(&ast.EnumValueDefinition{
+				Description: descriptionAST(value.Description),
+				Name:        nameAST(value.Name),
+				Directives:  directiveASTs(schema, value.Directives, value.DeprecationReason),
+			}))
+		}
+		return ast.NewEnumDefinition(&ast.EnumDefinition{
+			Description: descriptionAST(ttype.Description()),
+			Name:        nameAST(ttype.Name()),
+			Directives:  directiveASTs(schema, ttype.Directives(), ""),
+			Values:      valueASTs,
+		})
+	case *InputObject:
+		fields := ttype.Fields()
+		names := []string{}
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fieldASTs := []*ast.InputValueDefinition{}
+		for _, name := range names {
+			field := fields[name]
+			fieldASTs = append(fieldASTs, ast.NewInputValueDefinition(&ast.InputValueDefinition{
+				Description:  descriptionAST(field.Description()),
+				Name:         nameAST(name),
+				Type:         typeAST(field.Type),
+				DefaultValue: astFromValue(field.DefaultValue, field.Type),
+				Directives:   directiveASTs(schema, field.Directives, field.DeprecationReason),
+			}))
+		}
+		return ast.NewInputObjectDefinition(&ast.InputObjectDefinition{
+			Description: descriptionAST(ttype.Description()),
+			Name:        nameAST(ttype.Name()),
+			Directives:  directiveASTs(schema, ttype.Directives(), ""),
+			Fields:      fieldASTs,
+		})
+	}
+	return nil
+}
+
+func fieldDefinitionASTs(schema Schema, fields FieldDefinitionMap) []*ast.FieldDefinition {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fieldASTs := []*ast.FieldDefinition{}
+	for _, name := range names {
+		field := fields[name]
+		fieldASTs = append(fieldASTs, ast.NewFieldDefinition(&ast.FieldDefinition{
+			Description: descriptionAST(field.Description),
+			Name:        nameAST(name),
+			Arguments:   argumentDefinitionASTs(schema, field.Args),
+			Type:        typeAST(field.Type),
+			Directives:  directiveASTs(schema, field.Directives, field.DeprecationReason),
+		}))
+	}
+	return fieldASTs
+}
+
+func argumentDefinitionASTs(schema Schema, args []*Argument) []*ast.InputValueDefinition {
+	args = append([]*Argument{}, args...)
+	sort.Sort(argumentsByName(args))
+	argASTs := []*ast.InputValueDefinition{}
+	for _, arg := range args {
+		argASTs = append(argASTs, ast.NewInputValueDefinition(&ast.InputValueDefinition{
+			Description:  descriptionAST(arg.Description()),
+			Name:         nameAST(arg.Name()),
+			Type:         typeAST(arg.Type),
+			DefaultValue: astFromValue(arg.DefaultValue, arg.Type),
+			Directives:   directiveASTs(schema, arg.Directives, arg.DeprecationReason),
+		}))
+	}
+	return argASTs
+}
+
+// directiveASTs returns the uses of the given directives, adding a use of
+// @deprecated when a deprecation reason is given but the directive is not
+// used. Arguments given their default value are left out.
+func directiveASTs(schema Schema, directives []*AppliedDirective, deprecationReason string) []*ast.Directive {
+	if deprecationReason != "" {
+		deprecated := false
+		for _, directive := range directives {
+			if directive != nil && directive.Name == DeprecatedDirective.Name {
+				deprecated = true
+			}
+		}
+		if !deprecated {
+			args := map[string]interface{}{}
+			if deprecationReason != DefaultDeprecationReason {
+				args["reason"] = deprecationReason
+			}
+			directives = append(directives, &AppliedDirective{
+				Name: DeprecatedDirective.Name,
+				Args: args,
+			})
+		}
+	}
+	directiveASTs := []*ast.Directive{}
+	for _, directive := range directives {
+		if directive == nil {
+			continue
+		}
+		argDefs := map[string]*Argument{}
+		if definition := schema.Directive(directive.Name); definition != nil {
+			for _, arg := range definition.Args {
+				argDefs[arg.Name()] = arg
+			}
+		}
+		names := []string{}
+		for name, value := range directive.Args {
+			if argDefs[name] != nil && reflect.DeepEqual(argDefs[name].DefaultValue, value) {
+				continue
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		argASTs := []*ast.Argument{}
+		for _, name := range names {
+			var argType Type
+			if argDefs[name] != nil {
+				argType = argDefs[name].Type
+			}
+			value := astFromValue(directive.Args[name], argType)
+			if value == nil {
+				value = ast.NewNullValue(&ast.NullValue{})
+			}
+			argASTs = append(argASTs, ast.NewArgument(&ast.Argument{
+				Name:  nameAST(name),
+				Value: value,
+			}))
+		}
+		directiveASTs = append(directiveASTs, ast.NewDirective(&ast.Directive{
+			Name:      nameAST(directive.Name),
+			Arguments: argASTs,
+		}))
+	}
+	return directiveASTs
+}
+
+func typeAST(ttype Type) ast.Type {
+	switch ttype := ttype.(type) {
+	case *List:
+		return ast.NewList(&ast.List{
+			Type: typeAST(ttype.OfType),
+		})
+	case *NonNull:
+		return ast.NewNonNull(&ast.NonNull{
+			Type: typeAST(ttype.OfType),
+		})
+	}
+	return namedAST(ttype.Name())
+}
+
+func descriptionAST(description string) *ast.StringValue {
+	if description == "" {
+		return nil
+	}
+	return ast.NewStringValue(&ast.StringValue{
+		Value: description,
+		Block: true,
+	})
+}
+
+func namedAST(name string) *ast.Named {
+	return ast.NewNamed(&ast.Named{
+		Name: nameAST(name),
+	})
+}
+
+func nameAST(name string) *ast.Name {
+	return ast.NewName(&ast.Name{
+		Value: name,
+	})
+}
+
+type enumValuesByName []*EnumValueDefinition
+
+func (s enumValuesByName) Len() int {
+	return len(s)
+}
+func (s enumValuesByName) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s enumValuesByName) Less(i, j int) bool {
+	return s[i].Name < s[j].Name
+}
+
+type argumentsByName []*Argument
+
+func (s argumentsByName) Len() int {
+	return len(s)
+}
+func (s argumentsByName) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s argumentsByName) Less(i, j int) bool {
+	return s[i].Name() < s[j].Name()
+}
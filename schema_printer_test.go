@@ -0,0 +1,124 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+)
+
+func TestPrintSchema_PrintsSchemasBuiltFromSDL(t *testing.T) {
+	sdl := `schema {
+  query: Root
+}
+
+directive @auth(role: Role = ADMIN) on OBJECT | FIELD_DEFINITION
+
+directive @limit(max: Int!) on ARGUMENT_DEFINITION
+
+union Agent = User | Robot
+
+scalar Date
+
+input Filter {
+  names: [String!]
+  role: Role = USER
+  scope: Scope = {public: true}
+}
+
+interface Node {
+  id: ID!
+}
+
+type Robot implements Node {
+  id: ID!
+  model: String @deprecated(reason: "Use serial.")
+  serial: String
+}
+
+enum Role {
+  ADMIN
+  USER @deprecated
+}
+
+type Root {
+  agents(filter: Filter, first: Int = 10 @limit(max: 100)): [Agent] @auth
+  node: Node
+}
+
+input Scope {
+  public: Boolean
+}
+
+type User implements Node @auth(role: USER) {
+  id: ID!
+  joined: Date
+  name: String
+}
+`
+	schema, err := graphql.BuildSchema(sdl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	printed := graphql.PrintSchema(schema)
+	if !reflect.DeepEqual(sdl, printed) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(sdl, printed))
+	}
+}
+
+func TestPrintSchema_PrintsDeprecationReasons(t *testing.T) {
+	color := graphql.NewEnum(graphql.EnumConfig{
+		Name: "Color",
+		Values: graphql.EnumValueConfigMap{
+			"RED": &graphql.EnumValueConfig{},
+			"CRIMSON": &graphql.EnumValueConfig{
+				DeprecationReason: graphql.DefaultDeprecationReason,
+			},
+		},
+	})
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"oldField": &graphql.Field{
+					Type:              graphql.String,
+					DeprecationReason: "Use newField.",
+				},
+				"newField": &graphql.Field{
+					Type: color,
+					Args: graphql.FieldConfigArgument{
+						"old": &graphql.ArgumentConfig{
+							Type: graphql.Int,
+							Directives: []*graphql.AppliedDirective{
+								{Name: "deprecated", Args: map[string]interface{}{"reason": "Unused."}},
+							},
+						},
+						"shade": &graphql.ArgumentConfig{
+							Type:         color,
+							DefaultValue: "RED",
+						},
+					},
+				},
+			},
+		}),
+		Directives: []*graphql.Directive{graphql.IncludeDirective},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `enum Color {
+  CRIMSON @deprecated
+  RED
+}
+
+type Query {
+  newField(old: Int @deprecated(reason: "Unused."), shade: Color = RED): Color
+  oldField: String @deprecated(reason: "Use newField.")
+}
+`
+	printed := graphql.PrintSchema(schema)
+	if !reflect.DeepEqual(expected, printed) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, printed))
+	}
+}
@@ -0,0 +1,200 @@
+package graphql
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Subscribe executes the operation of the request, sending a Result on the
+// returned channel for every event of a subscription operation, or a single
+// Result for queries and mutations. The channel is closed once the event
+// stream ends or the context of the request is done.
+//
+// A request that fails to parse or validate, whose variables are invalid or
+// whose event stream cannot be created, is not executed: Subscribe then
+// returns no channel, and a Result holding the errors preventing its
+// execution instead. Results sent on the channel always come from events.
+func Subscribe(p Params) (chan *Result, *Result) {
+	AST, result := ParseAndValidate(p)
+	if result != nil {
+		return nil, result
+	}
+
+	return ExecuteSubscription(ExecuteParams{
+		Schema:        p.Schema,
+		Root:          p.RootObject,
+		AST:           AST,
+		OperationName: p.OperationName,
+		Args:          p.VariableValues,
+		Context:       p.Context,
+	})
+}
+
+// ExecuteSubscription is to Subscribe what Execute is to Do: it executes an
+// operation of a document that has already been validated.
+//
+// The root field of a subscription operation must provide a Subscribe
+// function returning a chan interface{} of events. The operation is then
+// executed with each event as its root value. Like Subscribe, it returns a
+// Result holding errors, and no channel, if the operation cannot be executed.
+func ExecuteSubscription(p ExecuteParams) (chan *Result, *Result) {
+	eCtx, err := buildExecutionContext(BuildExecutionCtxParams{
+		Schema:        p.Schema,
+		Root:          p.Root,
+		AST:           p.AST,
+		OperationName: p.OperationName,
+		Args:          p.Args,
+		Context:       p.Context,
+	})
+	if err != nil {
+		return nil, &Result{
+			Errors: gqlerrors.FormatErrors(err),
+		}
+	}
+
+	if eCtx.Operation.GetOperation() != ast.OperationTypeSubscription {
+		return sendResult(executeRecoveringPanics(eCtx, p.Root)), nil
+	}
+
+	events, err := createSourceEventStream(eCtx)
+	if err != nil {
+		return nil, &Result{
+			Errors: gqlerrors.FormatErrors(err),
+		}
+	}
+
+	var done <-chan struct{}
+	if p.Context != nil {
+		done = p.Context.Done()
+	}
+
+	results := make(chan *Result)
+	go func() {
+		defer close(results)
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				eventCtx := &ExecutionContext{
+					Schema:         eCtx.Schema,
+					Fragments:      eCtx.Fragments,
+					Root:           event,
+					Operation:      eCtx.Operation,
+					VariableValues: eCtx.VariableValues,
+					Context:        eCtx.Context,
+					errMutex:       sync.RWMutex{},
+					directiveArgs:  eCtx.directiveArgs,
+				}
+				select {
+				case <-done:
+					return
+				case results <- executeRecoveringPanics(eventCtx, event):
+				}
+			}
+		}
+	}()
+	return results, nil
+}
+
+// createSourceEventStream calls the Subscribe function of the single root
+// field of a subscription operation.
+func createSourceEventStream(eCtx *ExecutionContext) (events <-chan interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	operation, ok := eCtx.Operation.(*ast.OperationDefinition)
+	if !ok {
+		return nil, fmt.Errorf("Can only subscribe to operations")
+	}
+	subscriptionType, err := getOperationRootType(eCtx.Schema, operation)
+	if err != nil {
+		return nil, err
+	}
+	fields := collectFields(CollectFieldsParams{
+		ExeContext:   eCtx,
+		RuntimeType:  subscriptionType,
+		SelectionSet: operation.GetSelectionSet(),
+	})
+	if len(fields) != 1 {
+		return nil, NewLocatedError(
+			"Subscription operations must select exactly one top level field.",
+			[]ast.Node{operation},
+		)
+	}
+
+	var responseName string
+	var fieldASTs []*ast.Field
+	for name, asts := range fields {
+		responseName, fieldASTs = name, asts
+	}
+	fieldAST := fieldASTs[0]
+	fieldName := ""
+	if fieldAST.Name != nil {
+		fieldName = fieldAST.Name.Value
+	}
+	fieldDef := getFieldDef(eCtx.Schema, subscriptionType, fieldName)
+	if fieldDef == nil {
+		return nil, NewLocatedError(
+			fmt.Sprintf(`Cannot subscribe to unknown field "%v".`, fieldName),
+			FieldASTsToNodeASTs(fieldASTs),
+		)
+	}
+	if fieldDef.Subscribe == nil {
+		return nil, NewLocatedError(
+			fmt.Sprintf(`Subscription field "%v" does not provide a Subscribe function.`, fieldName),
+			FieldASTsToNodeASTs(fieldASTs),
+		)
+	}
+
+	args, _ := getArgumentValues(fieldDef.Args, fieldAST.Arguments, eCtx.VariableValues)
+	source, err := fieldDef.Subscribe(ResolveParams{
+		Source: eCtx.Root,
+		Args:   args,
+		Info: ResolveInfo{
+			FieldName:       fieldName,
+			FieldASTs:       fieldASTs,
+			ReturnType:      fieldDef.Type,
+			ParentType:      subscriptionType,
+			Schema:          eCtx.Schema,
+			Fragments:       eCtx.Fragments,
+			RootValue:       eCtx.Root,
+			Operation:       eCtx.Operation,
+			VariableValues:  eCtx.VariableValues,
+			Path:            (*ResponsePath)(nil).WithKey(responseName),
+			FieldDefinition: fieldDef,
+		},
+		Context: eCtx.Context,
+	})
+	if err != nil {
+		return nil, NewLocatedError(err.Error(), FieldASTsToNodeASTs(fieldASTs))
+	}
+
+	switch source := source.(type) {
+	case chan interface{}:
+		return source, nil
+	case <-chan interface{}:
+		return source, nil
+	}
+	return nil, NewLocatedError(
+		fmt.Sprintf(`Subscription field "%v" must return a chan interface{}, got: %T.`, fieldName, source),
+		FieldASTsToNodeASTs(fieldASTs),
+	)
+}
+
+// sendResult returns a closed channel holding a single result.
+func sendResult(result *Result) chan *Result {
+	results := make(chan *Result, 1)
+	results <- result
+	close(results)
+	return results
+}
@@ -0,0 +1,134 @@
+package graphql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+	"golang.org/x/net/context"
+)
+
+var counterSubscriptionSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hello": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return "world", nil
+				},
+			},
+		},
+	}),
+	Subscription: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"counter": &graphql.Field{
+				Type: graphql.Int,
+				Args: graphql.FieldConfigArgument{
+					"to": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.Int),
+					},
+				},
+				Subscribe: func(p graphql.ResolveParams) (interface{}, error) {
+					events := make(chan interface{})
+					go func() {
+						defer close(events)
+						for i := 1; i <= p.Args["to"].(int); i++ {
+							select {
+							case events <- i:
+							case <-p.Context.Done():
+								return
+							}
+						}
+					}()
+					return events, nil
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+			"unsubscribable": &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	}),
+})
+
+func subscribe(t *testing.T, p graphql.Params) chan *graphql.Result {
+	results, result := graphql.Subscribe(p)
+	if result != nil {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+	return results
+}
+
+func collectResults(results chan *graphql.Result) []*graphql.Result {
+	collected := []*graphql.Result{}
+	for result := range results {
+		collected = append(collected, result)
+	}
+	return collected
+}
+
+func TestSubscribe_SendsAResultPerEvent(t *testing.T) {
+	results := subscribe(t, graphql.Params{
+		Schema:         counterSubscriptionSchema,
+		RequestString:  `subscription Count($to: Int!) { counter(to: $to) }`,
+		VariableValues: map[string]interface{}{"to": 3},
+		Context:        context.Background(),
+	})
+	expected := []*graphql.Result{
+		{Data: map[string]interface{}{"counter": 1}},
+		{Data: map[string]interface{}{"counter": 2}},
+		{Data: map[string]interface{}{"counter": 3}},
+	}
+	if collected := collectResults(results); !reflect.DeepEqual(expected, collected) {
+		t.Fatalf("wrong results, graphql result diff: %v", testutil.Diff(expected, collected))
+	}
+}
+
+func TestSubscribe_StopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results := subscribe(t, graphql.Params{
+		Schema:        counterSubscriptionSchema,
+		RequestString: `subscription { counter(to: 1000000) }`,
+		Context:       ctx,
+	})
+	<-results
+	cancel()
+	for range results {
+	}
+}
+
+func TestSubscribe_SendsSingleResultForQueries(t *testing.T) {
+	results := subscribe(t, graphql.Params{
+		Schema:        counterSubscriptionSchema,
+		RequestString: `{ hello }`,
+	})
+	expected := []*graphql.Result{
+		{Data: map[string]interface{}{"hello": "world"}},
+	}
+	if collected := collectResults(results); !reflect.DeepEqual(expected, collected) {
+		t.Fatalf("wrong results, graphql result diff: %v", testutil.Diff(expected, collected))
+	}
+}
+
+func TestSubscribe_ReportsRequestErrors(t *testing.T) {
+	for _, query := range []string{
+		`subscription { counter(to: 1`,
+		`subscription ($to: Int!) { counter(to: $to) }`,
+		`subscription { unknown }`,
+		`subscription { unsubscribable }`,
+		`subscription { counter(to: 1) unsubscribable }`,
+	} {
+		results, result := graphql.Subscribe(graphql.Params{
+			Schema:        counterSubscriptionSchema,
+			RequestString: query,
+		})
+		if results != nil || result == nil || result.Data != nil || !result.HasErrors() {
+			t.Fatalf("%v: expected no results and a result with errors, got: %v", query, result)
+		}
+	}
+}
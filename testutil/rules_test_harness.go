@@ -463,8 +463,30 @@ func init() {
 				Name:      "operationOnly",
 				Locations: []string{graphql.DirectiveLocationQuery},
 			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "onVariableDefinition",
+				Locations: []string{graphql.DirectiveLocationVariableDefinition},
+			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "onSchema",
+				Locations: []string{graphql.DirectiveLocationSchema},
+			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "onObject",
+				Locations: []string{graphql.DirectiveLocationObject},
+			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "onArgumentDefinition",
+				Locations: []string{graphql.DirectiveLocationArgumentDefinition},
+			}),
+			graphql.NewDirective(graphql.DirectiveConfig{
+				Name:      "onInputFieldDefinition",
+				Locations: []string{graphql.DirectiveLocationInputFieldDefinition},
+			}),
 			graphql.IncludeDirective,
 			graphql.SkipDirective,
+			graphql.DeferDirective,
+			graphql.StreamDirective,
 		},
 		Types: []graphql.Type{
 			catType,
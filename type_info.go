@@ -124,7 +124,8 @@ func (ti *TypeInfo) Enter(node ast.Node) {
 			ttype, _ = typeFromAST(*schema, node.TypeCondition)
 			ti.typeStack = append(ti.typeStack, ttype)
 		} else {
-			ti.typeStack = append(ti.typeStack, ti.Type())
+			ttype, _ = GetNamed(ti.Type()).(Output)
+			ti.typeStack = append(ti.typeStack, ttype)
 		}
 	case *ast.FragmentDefinition:
 		typeConditionAST := node.TypeCondition
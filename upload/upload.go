@@ -0,0 +1,414 @@
+// Package upload implements the GraphQL multipart request specification
+// (https://github.com/jaydenseric/graphql-multipart-request-spec), allowing
+// files to be sent as variables of GraphQL operations.
+package upload
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// File is the runtime value of an Upload variable.
+type File struct {
+	multipart.File
+
+	Filename string
+	Size     int64
+	Header   textproto.MIMEHeader
+}
+
+// Scalar is the GraphQL Upload type definition.
+// Values can only be provided through variables of a multipart request.
+var Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Upload",
+	Description: "The `Upload` scalar type represents a file upload sent as part of a multipart request.",
+	SerializeWithError: func(value interface{}) (interface{}, error) {
+		return nil, errors.New("Upload cannot be serialized.")
+	},
+	ParseValueWithError: func(value interface{}) (interface{}, error) {
+		if file, ok := value.(*File); ok {
+			return file, nil
+		}
+		return nil, fmt.Errorf(`Expected type "Upload", found "%v".`, value)
+	},
+	ParseLiteralWithError: func(valueAST ast.Value) (interface{}, error) {
+		return nil, errors.New(`Expected type "Upload", found a literal. Upload values must be provided as variables.`)
+	},
+})
+
+var (
+	// ErrMissingOperations is returned when the "operations" field is absent.
+	ErrMissingOperations = errors.New(`Multipart request is missing the "operations" field.`)
+	// ErrMissingMap is returned when the "map" field is absent.
+	ErrMissingMap = errors.New(`Multipart request is missing the "map" field.`)
+	// ErrBodyTooLarge is returned when the request body exceeds Options.MaxBodySize.
+	ErrBodyTooLarge = errors.New("Multipart request body is too large.")
+	// ErrTooManyFiles is returned when the request contains more than Options.MaxFiles files.
+	ErrTooManyFiles = errors.New("Multipart request contains too many files.")
+	// ErrFileTooLarge is returned when a file exceeds Options.MaxFileSize.
+	ErrFileTooLarge = errors.New("Multipart request contains a file that is too large.")
+)
+
+// Options limits the resources a single multipart request may use.
+// Zero values disable the corresponding limit.
+type Options struct {
+	// MaxMemory is the number of bytes of files kept in memory, those of
+	// DefaultOptions if zero; further files are stored in temporary files.
+	MaxMemory int64
+
+	// MaxBodySize is the maximum size in bytes of the whole request body.
+	MaxBodySize int64
+
+	// MaxFileSize is the maximum size in bytes of a single file.
+	MaxFileSize int64
+
+	// MaxFiles is the maximum number of files in a single request.
+	MaxFiles int
+}
+
+// DefaultOptions are the limits used by the handler package when none are
+// configured.
+var DefaultOptions = Options{
+	MaxMemory:   32 << 20,
+	MaxBodySize: 64 << 20,
+	MaxFileSize: 32 << 20,
+	MaxFiles:    16,
+}
+
+// Operation is a GraphQL operation decoded from the "operations" field.
+type Operation struct {
+	Query         string
+	Variables     map[string]interface{}
+	OperationName string
+}
+
+// Request is a parsed multipart request.
+// Close must be called once its operations have been executed to release the files.
+type Request struct {
+	// Operations holds the operations of the request, a single one unless
+	// the "operations" field is an array, in which case Batch is true.
+	Operations []*Operation
+	Batch      bool
+
+	files []*File
+}
+
+// Close closes every file of the request and removes any temporary files.
+func (req *Request) Close() error {
+	var err error
+	for _, file := range req.files {
+		if e := file.Close(); e != nil && err == nil {
+			err = e
+		}
+		if f, ok := file.File.(*os.File); ok {
+			if e := os.Remove(f.Name()); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+	return err
+}
+
+// IsMultipart reports whether the request body is multipart/form-data.
+func IsMultipart(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data")
+}
+
+// ParseRequest parses a multipart request, replacing the variables referenced by
+// the "map" field with the corresponding files.
+//
+// The parts are read as they arrive: the "operations" and "map" fields must
+// come first, as the specification requires, and the limits of the options
+// are enforced while reading, before any part exceeding them is stored.
+// Files are kept in memory up to MaxMemory bytes in total, and in temporary
+// files beyond.
+func ParseRequest(w http.ResponseWriter, r *http.Request, opts Options) (*Request, error) {
+	var body *limitedBody
+	if opts.MaxBodySize > 0 {
+		body = &limitedBody{ReadCloser: r.Body, remaining: opts.MaxBodySize}
+		r.Body = body
+	}
+	req, err := parseRequest(r, opts)
+	if err != nil && body != nil && body.exceeded {
+		// the multipart reader wraps the errors of the body in its own
+		return nil, ErrBodyTooLarge
+	}
+	return req, err
+}
+
+func parseRequest(r *http.Request, opts Options) (*Request, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse multipart request: %v", err)
+	}
+	req := &Request{}
+	operations, fileMap, err := readFields(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	memory := opts.MaxMemory
+	if memory <= 0 {
+		memory = DefaultOptions.MaxMemory
+	}
+	files := map[string]*File{}
+	received := 0
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			req.Close()
+			return nil, readError(err)
+		}
+		received++
+		if opts.MaxFiles > 0 && received > opts.MaxFiles {
+			part.Close()
+			req.Close()
+			return nil, ErrTooManyFiles
+		}
+		key := part.FormName()
+		if _, ok := fileMap[key]; !ok || files[key] != nil {
+			// parts the map does not refer to are skipped without being stored
+			_, err := io.Copy(ioutil.Discard, part)
+			part.Close()
+			if err != nil {
+				req.Close()
+				return nil, readError(err)
+			}
+			continue
+		}
+		file, err := readFile(part, opts.MaxFileSize, &memory)
+		part.Close()
+		if err != nil {
+			req.Close()
+			return nil, err
+		}
+		req.files = append(req.files, file)
+		files[key] = file
+	}
+
+	for key, paths := range fileMap {
+		file, ok := files[key]
+		if !ok {
+			req.Close()
+			return nil, fmt.Errorf(`Multipart request is missing file "%v".`, key)
+		}
+		for _, path := range paths {
+			if err := setPath(operations, strings.Split(path, "."), file); err != nil {
+				req.Close()
+				return nil, fmt.Errorf(`Invalid path "%v" for file "%v": %v`, path, key, err)
+			}
+		}
+	}
+
+	if list, ok := operations.([]interface{}); ok {
+		req.Batch = true
+		for _, value := range list {
+			operation, err := newOperation(value)
+			if err != nil {
+				req.Close()
+				return nil, err
+			}
+			req.Operations = append(req.Operations, operation)
+		}
+		return req, nil
+	}
+	operation, err := newOperation(operations)
+	if err != nil {
+		req.Close()
+		return nil, err
+	}
+	req.Operations = []*Operation{operation}
+	return req, nil
+}
+
+// readFields reads the "operations" and "map" fields opening the request.
+func readFields(reader *multipart.Reader) (interface{}, map[string][]string, error) {
+	var operations interface{}
+	fileMap := map[string][]string{}
+	for _, name := range []string{"operations", "map"} {
+		part, err := reader.NextPart()
+		if err == io.EOF || err == nil && part.FormName() != name {
+			if name == "operations" {
+				return nil, nil, ErrMissingOperations
+			}
+			return nil, nil, ErrMissingMap
+		}
+		if err != nil {
+			return nil, nil, readError(err)
+		}
+		b, err := ioutil.ReadAll(part)
+		part.Close()
+		if err != nil {
+			return nil, nil, readError(err)
+		}
+		if name == "operations" {
+			err = json.Unmarshal(b, &operations)
+		} else {
+			err = json.Unmarshal(b, &fileMap)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf(`Could not parse "%v" field: %v`, name, err)
+		}
+	}
+	return operations, fileMap, nil
+}
+
+// readFile stores a file part, in memory while the remaining memory allows
+// it and in a temporary file otherwise, failing once it exceeds maxSize.
+func readFile(part *multipart.Part, maxSize int64, memory *int64) (*File, error) {
+	file := &File{
+		Filename: part.FileName(),
+		Header:   part.Header,
+	}
+	var reader io.Reader = part
+	if maxSize > 0 {
+		reader = io.LimitReader(part, maxSize+1)
+	}
+	var buf bytes.Buffer
+	size, err := io.CopyN(&buf, reader, *memory+1)
+	if err != nil && err != io.EOF {
+		return nil, readError(err)
+	}
+	if size <= *memory {
+		*memory -= size
+		if maxSize > 0 && size > maxSize {
+			return nil, ErrFileTooLarge
+		}
+		file.File = memoryFile{bytes.NewReader(buf.Bytes())}
+		file.Size = size
+		return file, nil
+	}
+
+	tmp, err := ioutil.TempFile("", "graphql-upload-")
+	if err != nil {
+		return nil, err
+	}
+	size, err = io.Copy(tmp, io.MultiReader(&buf, reader))
+	if err == nil && maxSize > 0 && size > maxSize {
+		err = ErrFileTooLarge
+	}
+	if err == nil {
+		_, err = tmp.Seek(0, 0)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, readError(err)
+	}
+	file.File = tmp
+	file.Size = size
+	return file, nil
+}
+
+// memoryFile is a file kept in memory.
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (memoryFile) Close() error {
+	return nil
+}
+
+// limitedBody limits the size of a request body, recording once it is
+// exceeded.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	exceeded  bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, ErrBodyTooLarge
+	}
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		return n, err
+	}
+	n = int(b.remaining)
+	b.remaining = 0
+	b.exceeded = true
+	return n, ErrBodyTooLarge
+}
+
+// readError reports an error met while reading the body.
+func readError(err error) error {
+	if err == ErrBodyTooLarge || err == ErrFileTooLarge {
+		return err
+	}
+	return fmt.Errorf("Could not parse multipart request: %v", err)
+}
+
+// newOperation converts a decoded JSON object into an Operation.
+func newOperation(value interface{}) (*Operation, error) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New(`The "operations" field must be an object or an array of objects.`)
+	}
+	operation := &Operation{}
+	operation.Query, _ = obj["query"].(string)
+	operation.OperationName, _ = obj["operationName"].(string)
+	operation.Variables, _ = obj["variables"].(map[string]interface{})
+	return operation, nil
+}
+
+// setPath replaces the value found by following path from value.
+func setPath(value interface{}, path []string, file *File) error {
+	if len(path) == 0 {
+		return errors.New("empty path")
+	}
+	key := path[0]
+	last := len(path) == 1
+	switch container := value.(type) {
+	case map[string]interface{}:
+		if last {
+			container[key] = file
+			return nil
+		}
+		next, ok := container[key]
+		if !ok {
+			return fmt.Errorf(`no value at "%v"`, key)
+		}
+		return setPath(next, path[1:], file)
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(container) {
+			return fmt.Errorf(`no value at index "%v"`, key)
+		}
+		if last {
+			container[index] = file
+			return nil
+		}
+		return setPath(container[index], path[1:], file)
+	}
+	return fmt.Errorf(`cannot traverse into "%v"`, key)
+}
+
+// StatusCode returns the HTTP status code that best describes err.
+func StatusCode(err error) int {
+	switch err {
+	case ErrBodyTooLarge, ErrTooManyFiles, ErrFileTooLarge:
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,231 @@
+package upload_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/testutil"
+	"github.com/graphql-go/graphql/upload"
+)
+
+var uploadSchema, _ = graphql.NewSchema(graphql.SchemaConfig{
+	Query: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"ok": &graphql.Field{
+				Type: graphql.Boolean,
+			},
+		},
+	}),
+	Mutation: graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"upload": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"file": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(upload.Scalar),
+					},
+				},
+			},
+		},
+	}),
+})
+
+type testFile struct {
+	key      string
+	filename string
+	content  string
+}
+
+func newUploadRequest(t *testing.T, operations, fileMap string, files ...testFile) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("operations", operations)
+	writer.WriteField("map", fileMap)
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.key, file.filename)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		part.Write([]byte(file.content))
+	}
+	writer.Close()
+	req := httptest.NewRequest("POST", "/graphql", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func readFile(t *testing.T, value interface{}) string {
+	file, ok := value.(*upload.File)
+	if !ok {
+		t.Fatalf("expected a file, got: %v", value)
+	}
+	b, err := ioutil.ReadAll(file)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return file.Filename + ":" + string(b)
+}
+
+func TestParseRequest_ReplacesVariablesWithFiles(t *testing.T) {
+	req := newUploadRequest(t,
+		`{"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}, "operationName": "A"}`,
+		`{"0": ["variables.file"]}`,
+		testFile{"0", "a.txt", "hello"},
+	)
+	parsed, err := upload.ParseRequest(httptest.NewRecorder(), req, upload.DefaultOptions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer parsed.Close()
+	if parsed.Batch || len(parsed.Operations) != 1 {
+		t.Fatalf("expected a single operation, got: %v", parsed.Operations)
+	}
+	operation := parsed.Operations[0]
+	if operation.Query != "mutation ($file: Upload!) { upload(file: $file) }" || operation.OperationName != "A" {
+		t.Fatalf("unexpected operation: %v", operation)
+	}
+	if content := readFile(t, operation.Variables["file"]); content != "a.txt:hello" {
+		t.Fatalf("unexpected file, got: %v", content)
+	}
+}
+
+func TestParseRequest_ParsesBatches(t *testing.T) {
+	req := newUploadRequest(t,
+		`[{"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}, {"query": "mutation ($files: [Upload]) { upload(file: $files) }", "variables": {"files": [null, null]}}]`,
+		`{"0": ["0.variables.file", "1.variables.files.0"], "1": ["1.variables.files.1"]}`,
+		testFile{"0", "a.txt", "a"},
+		testFile{"1", "b.txt", "b"},
+	)
+	parsed, err := upload.ParseRequest(httptest.NewRecorder(), req, upload.DefaultOptions)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer parsed.Close()
+	if !parsed.Batch || len(parsed.Operations) != 2 {
+		t.Fatalf("expected a batch of two operations, got: %v", parsed.Operations)
+	}
+	files := parsed.Operations[1].Variables["files"].([]interface{})
+	if parsed.Operations[0].Variables["file"] != files[0] {
+		t.Fatalf("expected the file to be shared by both operations")
+	}
+	expected := []string{"a.txt:a", "b.txt:b"}
+	contents := []string{readFile(t, files[0]), readFile(t, files[1])}
+	if !reflect.DeepEqual(expected, contents) {
+		t.Fatalf("Unexpected files, Diff: %v", testutil.Diff(expected, contents))
+	}
+}
+
+func TestParseRequest_StoresLargeFilesOnDisk(t *testing.T) {
+	req := newUploadRequest(t,
+		`{"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}`,
+		`{"0": ["variables.file"]}`,
+		testFile{"0", "a.txt", "hello"},
+	)
+	parsed, err := upload.ParseRequest(httptest.NewRecorder(), req, upload.Options{MaxMemory: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file := parsed.Operations[0].Variables["file"].(*upload.File)
+	tmp, ok := file.File.(*os.File)
+	if !ok {
+		t.Fatalf("expected a temporary file, got: %T", file.File)
+	}
+	if file.Size != 5 || readFile(t, file) != "a.txt:hello" {
+		t.Fatalf("unexpected file of size %v", file.Size)
+	}
+	if err := parsed.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(tmp.Name()); !os.IsNotExist(err) {
+		t.Fatalf("expected the temporary file to be removed, got: %v", err)
+	}
+}
+
+func TestParseRequest_EnforcesLimitsWhileReading(t *testing.T) {
+	operations := `{"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}`
+	tests := []struct {
+		name     string
+		options  upload.Options
+		req      *http.Request
+		expected error
+	}{
+		{
+			name:     "file parts beyond the map",
+			options:  upload.Options{MaxFiles: 1},
+			req:      newUploadRequest(t, operations, `{"0": ["variables.file"]}`, testFile{"0", "a.txt", "a"}, testFile{"1", "b.txt", "b"}),
+			expected: upload.ErrTooManyFiles,
+		},
+		{
+			name:     "file too large in memory",
+			options:  upload.Options{MaxFileSize: 4},
+			req:      newUploadRequest(t, operations, `{"0": ["variables.file"]}`, testFile{"0", "a.txt", "hello"}),
+			expected: upload.ErrFileTooLarge,
+		},
+		{
+			name:     "file too large on disk",
+			options:  upload.Options{MaxFileSize: 4, MaxMemory: 1},
+			req:      newUploadRequest(t, operations, `{"0": ["variables.file"]}`, testFile{"0", "a.txt", "hello"}),
+			expected: upload.ErrFileTooLarge,
+		},
+		{
+			name:     "body too large",
+			options:  upload.Options{MaxBodySize: 16},
+			req:      newUploadRequest(t, operations, `{"0": ["variables.file"]}`, testFile{"0", "a.txt", "hello"}),
+			expected: upload.ErrBodyTooLarge,
+		},
+	}
+	for _, test := range tests {
+		_, err := upload.ParseRequest(httptest.NewRecorder(), test.req, test.options)
+		if err != test.expected {
+			t.Fatalf("%v: unexpected error, got: %v, expected: %v", test.name, err, test.expected)
+		}
+	}
+}
+
+func TestParseRequest_AcceptsBodiesOfTheMaximumSize(t *testing.T) {
+	req := newUploadRequest(t,
+		`{"query": "mutation ($file: Upload!) { upload(file: $file) }", "variables": {"file": null}}`,
+		`{"0": ["variables.file"]}`,
+		testFile{"0", "a.txt", "hello"},
+	)
+	parsed, err := upload.ParseRequest(httptest.NewRecorder(), req, upload.Options{MaxBodySize: req.ContentLength})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer parsed.Close()
+	if file := readFile(t, parsed.Operations[0].Variables["file"]); file != "a.txt:hello" {
+		t.Fatalf("unexpected file, got: %v", file)
+	}
+}
+
+func TestParseRequest_RequiresOperationsFirst(t *testing.T) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("map", `{}`)
+	writer.WriteField("operations", `{"query": "{ ok }"}`)
+	writer.Close()
+	req := httptest.NewRequest("POST", "/graphql", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if _, err := upload.ParseRequest(httptest.NewRecorder(), req, upload.DefaultOptions); err != upload.ErrMissingOperations {
+		t.Fatalf("unexpected error, got: %v", err)
+	}
+}
+
+func TestScalar_RejectsLiterals(t *testing.T) {
+	result := graphql.Do(graphql.Params{
+		Schema:        uploadSchema,
+		RequestString: `mutation { upload(file: "a.txt") }`,
+	})
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected one error, got: %v", result.Errors)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/graphql-go/graphql/gqlerrors"
@@ -17,6 +18,9 @@ import (
 // Prepares an object map of variableValues of the correct type based on the
 // provided variable definitions and arbitrary input. If the input cannot be
 // parsed to match the variable definitions, a GraphQLError will be returned.
+//
+// Variables neither provided nor having a default value are left out of the
+// map, so that they can be told from variables explicitly set to null.
 func getVariableValues(schema Schema, definitionASTs []*ast.VariableDefinition, inputs map[string]interface{}) (map[string]interface{}, error) {
 	values := map[string]interface{}{}
 	for _, defAST := range definitionASTs {
@@ -24,17 +28,21 @@ func getVariableValues(schema Schema, definitionASTs []*ast.VariableDefinition,
 			continue
 		}
 		varName := defAST.Variable.Name.Value
-		varValue, err := getVariableValue(schema, defAST, inputs[varName])
+		input, hasValue := inputs[varName]
+		varValue, err := getVariableValue(schema, defAST, input, hasValue)
 		if err != nil {
 			return values, err
 		}
-		values[varName] = varValue
+		if hasValue || defAST.DefaultValue != nil {
+			values[varName] = varValue
+		}
 	}
 	return values, nil
 }
 
 // Prepares an object map of argument values given a list of argument
-// definitions and list of argument AST nodes.
+// definitions and list of argument AST nodes. Arguments explicitly set to
+// null, either with the null literal or with a variable, are mapped to nil.
 func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVariables map[string]interface{}) (map[string]interface{}, error) {
 
 	argASTMap := map[string]*ast.Argument{}
@@ -51,6 +59,10 @@ func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVar
 		if argAST, ok := argASTMap[name]; ok {
 			valueAST = argAST.Value
 		}
+		if isExplicitNull(valueAST, variableVariables) {
+			results[name] = nil
+			continue
+		}
 		value := valueFromAST(valueAST, argDef.Type, variableVariables)
 		if isNullish(value) {
 			value = argDef.DefaultValue
@@ -62,9 +74,27 @@ func getArgumentValues(argDefs []*Argument, argASTs []*ast.Argument, variableVar
 	return results, nil
 }
 
+// isExplicitNull tells whether a value AST is the null literal, or a variable
+// explicitly set to null.
+func isExplicitNull(valueAST ast.Value, variables map[string]interface{}) bool {
+	switch valueAST := valueAST.(type) {
+	case *ast.NullValue:
+		return true
+	case *ast.Variable:
+		if valueAST.Name == nil {
+			return false
+		}
+		value, ok := variables[valueAST.Name.Value]
+		return ok && value == nil
+	}
+	return false
+}
+
 // Given a variable definition, and any value of input, return a value which
-// adheres to the variable definition, or throw an error.
-func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input interface{}) (interface{}, error) {
+// adheres to the variable definition, or throw an error. hasValue tells
+// whether the input was provided, as the default value of the variable does
+// not replace an explicit null.
+func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, input interface{}, hasValue bool) (interface{}, error) {
 	ttype, err := typeFromAST(schema, definitionAST.Type)
 	if err != nil {
 		return nil, err
@@ -85,7 +115,7 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 
 	isValid, messages := isValidInputValue(input, ttype)
 	if isValid {
-		if isNullish(input) {
+		if isNullish(input) && (!hasValue || input != nil) {
 			defaultValue := definitionAST.DefaultValue
 			if defaultValue != nil {
 				variables := map[string]interface{}{}
@@ -95,6 +125,17 @@ func getVariableValue(schema Schema, definitionAST *ast.VariableDefinition, inpu
 		}
 		return coerceValue(ttype, input), nil
 	}
+	if hasValue && input == nil {
+		return "", gqlerrors.NewError(
+			fmt.Sprintf(`Variable "$%v" of non-null type `+
+				`"%v" must not be null.`, variable.Name.Value, printer.Print(definitionAST.Type)),
+			[]ast.Node{definitionAST},
+			"",
+			nil,
+			[]int{},
+			nil,
+		)
+	}
 	if isNullish(input) {
 		return "", gqlerrors.NewError(
 			fmt.Sprintf(`Variable "$%v" of required type `+
@@ -160,7 +201,11 @@ func coerceValue(ttype Input, value interface{}) interface{} {
 
 		obj := map[string]interface{}{}
 		for fieldName, field := range ttype.Fields() {
-			value, _ := valueMap[fieldName]
+			value, hasValue := valueMap[fieldName]
+			if hasValue && value == nil {
+				obj[fieldName] = nil
+				continue
+			}
 			fieldValue := coerceValue(field.Type, value)
 			if isNullish(fieldValue) {
 				fieldValue = field.DefaultValue
@@ -299,7 +344,10 @@ func isValidInputValue(value interface{}, ttype Input) (bool, []string) {
 
 	switch ttype := ttype.(type) {
 	case *Scalar:
-		parsedVal := ttype.ParseValue(value)
+		parsedVal, err := ttype.ParseValueWithError(value)
+		if err != nil {
+			return false, []string{err.Error()}
+		}
 		if isNullish(parsedVal) {
 			return false, []string{fmt.Sprintf(`Expected type "%v", found "%v".`, ttype.Name(), value)}
 		}
@@ -345,6 +393,7 @@ func isNullish(value interface{}) bool {
  * | Boolean              | Boolean       |
  * | String / Enum Value  | String        |
  * | Int / Float          | Number        |
+ * | Null                 | null          |
  *
  */
 func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interface{}) interface{} {
@@ -354,7 +403,7 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 		return val
 	}
 
-	if valueAST == nil {
+	if valueAST == nil || valueAST.GetKind() == kinds.NullValue {
 		return nil
 	}
 
@@ -410,6 +459,10 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 			if !ok || fieldAST == nil {
 				continue
 			}
+			if isExplicitNull(fieldAST.Value, variables) {
+				obj[fieldName] = nil
+				continue
+			}
 			fieldValue := valueFromAST(fieldAST.Value, field.Type, variables)
 			if isNullish(fieldValue) {
 				fieldValue = field.DefaultValue
@@ -436,6 +489,46 @@ func valueFromAST(valueAST ast.Value, ttype Input, variables map[string]interfac
 	return nil
 }
 
+// valueFromASTUntyped produces the value of a constant AST value, as it
+// would be given as a variable value, without knowing its type.
+func valueFromASTUntyped(valueAST ast.Value) interface{} {
+	switch valueAST := valueAST.(type) {
+	case *ast.IntValue:
+		if intValue, err := strconv.Atoi(valueAST.Value); err == nil {
+			return intValue
+		}
+		if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return floatValue
+		}
+	case *ast.FloatValue:
+		if floatValue, err := strconv.ParseFloat(valueAST.Value, 64); err == nil {
+			return floatValue
+		}
+	case *ast.StringValue:
+		return valueAST.Value
+	case *ast.BooleanValue:
+		return valueAST.Value
+	case *ast.EnumValue:
+		return valueAST.Value
+	case *ast.ListValue:
+		values := []interface{}{}
+		for _, itemAST := range valueAST.Values {
+			values = append(values, valueFromASTUntyped(itemAST))
+		}
+		return values
+	case *ast.ObjectValue:
+		fields := map[string]interface{}{}
+		for _, fieldAST := range valueAST.Fields {
+			if fieldAST == nil || fieldAST.Name == nil {
+				continue
+			}
+			fields[fieldAST.Name.Value] = valueFromASTUntyped(fieldAST.Value)
+		}
+		return fields
+	}
+	return nil
+}
+
 func invariant(condition bool, message string) error {
 	if !condition {
 		return gqlerrors.NewFormattedError(message)
@@ -695,7 +695,7 @@ func TestVariables_NullableScalars_AllowsNullableInputsToBeSetToNullInAVariable(
 	}
 	expected := &graphql.Result{
 		Data: map[string]interface{}{
-			"fieldWithNullableStringInput": nil,
+			"fieldWithNullableStringInput": "null",
 		},
 	}
 
@@ -773,6 +773,104 @@ func TestVariables_NullableScalars_AllowsNullableInputsToBeSetToAValueDirectly(t
 		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
 	}
 }
+func TestVariables_NullableScalars_AllowsNullableInputsToBeSetToNullDirectly(t *testing.T) {
+	doc := `
+      {
+        fieldWithNullableStringInput(input: null)
+      }
+	`
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"fieldWithNullableStringInput": `null`,
+		},
+	}
+
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_NullableScalars_ExplicitNullOverridesDefaultValues(t *testing.T) {
+	doc := `
+      query SetsNullable($value: String = "default") {
+        literal: fieldWithDefaultArgumentValue(input: null)
+        variable: fieldWithDefaultArgumentValue(input: $value)
+      }
+	`
+	params := map[string]interface{}{
+		"value": nil,
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"literal":  `null`,
+			"variable": `null`,
+		},
+	}
+
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+		Args:   params,
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
+
+func TestVariables_ObjectsAndNullability_PassesAlongExplicitNullFields(t *testing.T) {
+	doc := `
+      query q($input: TestInputObject) {
+        literal: fieldWithObjectInput(input: {a: null, c: "baz"})
+        variable: fieldWithObjectInput(input: $input)
+      }
+	`
+	params := map[string]interface{}{
+		"input": map[string]interface{}{
+			"a": nil,
+			"c": "baz",
+		},
+	}
+	expected := &graphql.Result{
+		Data: map[string]interface{}{
+			"literal":  `{"a":null,"c":"baz"}`,
+			"variable": `{"a":null,"c":"baz"}`,
+		},
+	}
+
+	ast := testutil.TestParse(t, doc)
+
+	// execute
+	ep := graphql.ExecuteParams{
+		Schema: variablesTestSchema,
+		AST:    ast,
+		Args:   params,
+	}
+	result := testutil.TestExecute(t, ep)
+	if len(result.Errors) > 0 {
+		t.Fatalf("wrong result, unexpected errors: %v", result.Errors)
+	}
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("Unexpected result, Diff: %v", testutil.Diff(expected, result))
+	}
+}
 
 func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeOmittedInAVariable(t *testing.T) {
 
@@ -825,7 +923,7 @@ func TestVariables_NonNullableScalars_DoesNotAllowNonNullableInputsToBeSetToNull
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Message: `Variable "$value" of required type "String!" was not provided.`,
+				Message: `Variable "$value" of non-null type "String!" must not be null.`,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 31,
@@ -962,7 +1060,7 @@ func TestVariables_ListsAndNullability_AllowsListsToBeNull(t *testing.T) {
 
 	expected := &graphql.Result{
 		Data: map[string]interface{}{
-			"list": nil,
+			"list": "null",
 		},
 	}
 	ast := testutil.TestParse(t, doc)
@@ -1148,7 +1246,7 @@ func TestVariables_ListsAndNullability_AllowsListsOfNonNullsToBeNull(t *testing.
 	}
 	expected := &graphql.Result{
 		Data: map[string]interface{}{
-			"listNN": nil,
+			"listNN": "null",
 		},
 	}
 	ast := testutil.TestParse(t, doc)
@@ -1250,7 +1348,7 @@ func TestVariables_ListsAndNullability_DoesNotAllowNonNullListOfNonNullsToBeNull
 		Data: nil,
 		Errors: []gqlerrors.FormattedError{
 			{
-				Message: `Variable "$input" of required type "[String!]!" was not provided.`,
+				Message: `Variable "$input" of non-null type "[String!]!" must not be null.`,
 				Locations: []location.SourceLocation{
 					{
 						Line: 2, Column: 17,